@@ -29,18 +29,12 @@ import (
 func NewCmdDebug(out io.Writer) *cobra.Command {
 	return NewCmd(out, "debug").
 		WithDescription("Runs a pipeline file in debug mode").
-		WithLongDescription("Similar to `dev`, but configures the pipeline for debugging.").
+		WithLongDescription("Similar to `dev`, but configures the pipeline for debugging. Changed files are synced as usual but artifacts with a debugger attached are not rebuilt.").
 		WithCommonFlags().
 		NoArgs(cancelWithCtrlC(context.Background(), doDebug))
 }
 
 func doDebug(ctx context.Context, out io.Writer) error {
-	// HACK: disable watcher to prevent redeploying changed containers during debugging
-	// TODO: enable file-sync but avoid redeploys of artifacts being debugged
-	if len(opts.TargetImages) == 0 {
-		opts.TargetImages = []string{"none"}
-	}
-
 	deploy.AddManifestTransform(debugging.ApplyDebuggingTransforms)
 
 	return doDev(ctx, out)
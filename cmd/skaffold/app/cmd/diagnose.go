@@ -38,7 +38,8 @@ func NewCmdDiagnose(out io.Writer) *cobra.Command {
 		WithDescription("Run a diagnostic on Skaffold").
 		WithFlags(func(f *pflag.FlagSet) {
 			f.StringVarP(&opts.ConfigurationFile, "filename", "f", "skaffold.yaml", "Filename or URL to the pipeline file")
-			f.StringSliceVarP(&opts.Profiles, "profile", "p", nil, "Activate profiles by name")
+			f.StringSliceVarP(&opts.Profiles, "profile", "p", nil, "Activate profiles by name (prefixed with '-' to deactivate a profile)")
+			f.StringSliceVarP(&opts.Modules, "module", "m", nil, "Only run the configs with these names, and the configs they require")
 		}).
 		NoArgs(cancelWithCtrlC(context.Background(), doDiagnose))
 }
@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewCmdEvents(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "A set of commands for working with the Skaffold events.",
+	}
+
+	cmd.AddCommand(NewCmdEventsReplay(out))
+	return cmd
+}
+
+// NewCmdEventsReplay describes the CLI command to serve saved events through the event API.
+func NewCmdEventsReplay(out io.Writer) *cobra.Command {
+	return NewCmd(out, "replay").
+		WithDescription("Serve the events saved with --event-log-file through the event API").
+		WithLongDescription("Reads the events saved by a previous run with --event-log-file and serves them, along with the resulting state, through the gRPC and HTTP event API until interrupted. The API is read-only.").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.IntVar(&opts.RPCPort, "rpc-port", constants.DefaultRPCPort, "tcp port to expose event API")
+			f.IntVar(&opts.RPCHTTPPort, "rpc-http-port", constants.DefaultRPCHTTPPort, "tcp port to expose event REST API over HTTP")
+			f.StringVar(&opts.RPCSocket, "rpc-socket", "", "Serve the event API on this unix domain socket, only accessible to the current user, instead of a tcp port")
+			f.StringVar(&opts.RPCHTTPSocket, "rpc-http-socket", "", "Serve the event REST API on this unix domain socket, only accessible to the current user, instead of a tcp port")
+			f.StringVar(&opts.RPCTLSCert, "rpc-tls-cert", "", "Certificate file to serve the event API over TLS")
+			f.StringVar(&opts.RPCTLSKey, "rpc-tls-key", "", "Private key file to serve the event API over TLS")
+			f.StringVar(&opts.RPCToken, "rpc-token", "", "Require this bearer token in the Authorization header of the event API requests. Prefer setting SKAFFOLD_RPC_TOKEN")
+		}).
+		ExactArgs(1, func(out io.Writer, args []string) error {
+			return cancelWithCtrlC(context.Background(), func(ctx context.Context, out io.Writer) error {
+				return doReplay(ctx, out, args[0])
+			})(out)
+		})
+}
+
+func doReplay(ctx context.Context, out io.Writer, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Wrap(err, "opening event log file")
+	}
+	defer f.Close()
+
+	// The replayed events can't be changed and there is no dev loop to control.
+	opts.RPCReadOnly = true
+
+	runCtx := &runcontext.RunContext{
+		Opts:    opts,
+		Cfg:     &latest.Pipeline{},
+		Trigger: make(chan bool),
+	}
+	event.InitializeState(runCtx)
+
+	count, err := event.Replay(f)
+	if err != nil {
+		return errors.Wrapf(err, "replaying %s", filename)
+	}
+
+	shutdown, err := server.Initialize(runCtx)
+	if err != nil {
+		return errors.Wrap(err, "initializing skaffold server")
+	}
+	defer shutdown()
+
+	color.Default.Fprintf(out, "Serving %d events from %s. Press Ctrl+C to exit\n", count, filename)
+	<-ctx.Done()
+
+	return nil
+}
@@ -55,12 +55,21 @@ var FlagRegistry = []Flag{
 	{
 		Name:          "profile",
 		Shorthand:     "p",
-		Usage:         "Activate profiles by name",
+		Usage:         "Activate profiles by name (prefixed with '-' to deactivate a profile)",
 		Value:         &opts.Profiles,
 		DefValue:      []string{},
 		FlagAddMethod: "StringSliceVar",
 		DefinedOn:     []string{"all"},
 	},
+	{
+		Name:          "module",
+		Shorthand:     "m",
+		Usage:         "Only run the configs with these names, and the configs they require",
+		Value:         &opts.Modules,
+		DefValue:      []string{},
+		FlagAddMethod: "StringSliceVar",
+		DefinedOn:     []string{"all"},
+	},
 	{
 		Name:          "namespace",
 		Shorthand:     "n",
@@ -127,6 +136,62 @@ var FlagRegistry = []Flag{
 		FlagAddMethod: "IntVar",
 		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
 	},
+	{
+		Name:          "rpc-socket",
+		Usage:         "Serve the event API on this unix domain socket, only accessible to the current user, instead of a tcp port",
+		Value:         &opts.RPCSocket,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "rpc-http-socket",
+		Usage:         "Serve the event REST API on this unix domain socket, only accessible to the current user, instead of a tcp port",
+		Value:         &opts.RPCHTTPSocket,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "rpc-tls-cert",
+		Usage:         "Certificate file to serve the event API over TLS",
+		Value:         &opts.RPCTLSCert,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "rpc-tls-key",
+		Usage:         "Private key file to serve the event API over TLS",
+		Value:         &opts.RPCTLSKey,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "rpc-token",
+		Usage:         "Require this bearer token in the Authorization header of the event API requests. Prefer setting SKAFFOLD_RPC_TOKEN",
+		Value:         &opts.RPCToken,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "rpc-read-only",
+		Usage:         "Only serve the state and the events through the event API, rejecting the requests that inject events or control the dev loop",
+		Value:         &opts.RPCReadOnly,
+		DefValue:      false,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+	},
+	{
+		Name:          "event-log-file",
+		Usage:         "Save the events as JSON lines to this file, to be replayed with skaffold events replay",
+		Value:         &opts.EventLogFile,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "build", "run", "debug", "deploy"},
+	},
 	{
 		Name:          "label",
 		Shorthand:     "l",
@@ -214,12 +279,68 @@ var FlagRegistry = []Flag{
 	},
 	{
 		Name:          "port-forward",
-		Usage:         "Port-forward exposed container ports within pods",
+		Usage:         "Port-forward exposed container ports within pods, or the resources listed in the portForward section",
 		Value:         &opts.PortForward,
 		DefValue:      false,
 		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "debug", "run"},
+	},
+	{
+		Name:          "auto-build",
+		Usage:         "When set to false, builds wait for API request instead of running automatically",
+		Value:         &opts.AutoBuild,
+		DefValue:      true,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "debug"},
+	},
+	{
+		Name:          "auto-sync",
+		Usage:         "When set to false, syncs wait for API request instead of running automatically",
+		Value:         &opts.AutoSync,
+		DefValue:      true,
+		FlagAddMethod: "BoolVar",
 		DefinedOn:     []string{"dev", "debug"},
 	},
+	{
+		Name:          "auto-deploy",
+		Usage:         "When set to false, deploys wait for API request instead of running automatically",
+		Value:         &opts.AutoDeploy,
+		DefValue:      true,
+		FlagAddMethod: "BoolVar",
+		DefinedOn:     []string{"dev", "debug"},
+	},
+	{
+		Name:          "log-include",
+		Usage:         "Only print the logs of containers matching a regexp, optionally prefixed with image=, pod= or container=",
+		Value:         &opts.LogInclude,
+		DefValue:      []string{},
+		FlagAddMethod: "StringArrayVar",
+		DefinedOn:     []string{"dev", "debug", "deploy", "run"},
+	},
+	{
+		Name:          "log-exclude",
+		Usage:         "Don't print the logs of containers matching a regexp, optionally prefixed with image=, pod= or container=",
+		Value:         &opts.LogExclude,
+		DefValue:      []string{},
+		FlagAddMethod: "StringArrayVar",
+		DefinedOn:     []string{"dev", "debug", "deploy", "run"},
+	},
+	{
+		Name:          "log-format",
+		Usage:         "Format of the container logs. One of: raw, json. With json, JSON log lines are printed with their level coloured",
+		Value:         &opts.LogFormat,
+		DefValue:      "raw",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "debug", "deploy", "run"},
+	},
+	{
+		Name:          "log-dir",
+		Usage:         "Directory where the logs of each container are also written to a separate file",
+		Value:         &opts.LogDir,
+		DefValue:      "",
+		FlagAddMethod: "StringVar",
+		DefinedOn:     []string{"dev", "debug", "deploy", "run"},
+	},
 }
 
 var commandFlags []*pflag.Flag
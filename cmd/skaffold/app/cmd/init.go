@@ -25,11 +25,13 @@ import (
 )
 
 var (
-	composeFile  string
-	cliArtifacts []string
-	skipBuild    bool
-	force        bool
-	analyze      bool
+	composeFile       string
+	cliArtifacts      []string
+	skipBuild         bool
+	force             bool
+	analyze           bool
+	generateManifests bool
+	answersFile       string
 )
 
 // NewCmdInit describes the CLI command to generate a Skaffold configuration.
@@ -41,19 +43,23 @@ func NewCmdInit(out io.Writer) *cobra.Command {
 			f.BoolVar(&skipBuild, "skip-build", false, "Skip generating build artifacts in Skaffold config")
 			f.BoolVar(&force, "force", false, "Force the generation of the Skaffold config")
 			f.StringVar(&composeFile, "compose-file", "", "Initialize from a docker-compose file")
-			f.StringSliceVarP(&cliArtifacts, "artifact", "a", nil, "'='-delimited dockerfile/image pair to generate build artifact\n(example: --artifact=/web/Dockerfile.web=gcr.io/web-project/image)")
-			f.BoolVar(&analyze, "analyze", false, "Print all discoverable Dockerfiles and images in JSON format to stdout")
+			f.StringSliceVarP(&cliArtifacts, "artifact", "a", nil, "'='-delimited build file/image pair to generate build artifact, for example a Dockerfile or a pom.xml\n(example: --artifact=/web/Dockerfile.web=gcr.io/web-project/image)")
+			f.BoolVar(&analyze, "analyze", false, "Print all discoverable builders and images in JSON format to stdout")
+			f.BoolVar(&generateManifests, "generate-manifests", false, "Generate a Deployment and a Service in the k8s directory for each artifact, when the sources have no kubernetes manifests")
+			f.StringVar(&answersFile, "answers", "", "Run without prompts, using a JSON or YAML file in the --analyze format that sets the image of each builder and the deployer to use ('-' for stdin)")
 		}).
 		NoArgs(doInit)
 }
 
 func doInit(out io.Writer) error {
 	return initializer.DoInit(out, initializer.Config{
-		ComposeFile:  composeFile,
-		CliArtifacts: cliArtifacts,
-		SkipBuild:    skipBuild,
-		Force:        force,
-		Analyze:      analyze,
-		Opts:         opts,
+		ComposeFile:       composeFile,
+		CliArtifacts:      cliArtifacts,
+		SkipBuild:         skipBuild,
+		Force:             force,
+		Analyze:           analyze,
+		GenerateManifests: generateManifests,
+		Answers:           answersFile,
+		Opts:              opts,
 	})
 }
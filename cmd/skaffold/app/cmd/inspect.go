@@ -0,0 +1,150 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	inspectOutput       string
+	inspectOverrides    bool
+	inspectDependencies bool
+)
+
+// NewCmdInspect describes the CLI command to print the effective configuration.
+func NewCmdInspect(out io.Writer) *cobra.Command {
+	return NewCmd(out, "inspect").
+		WithDescription("Print the effective Skaffold config").
+		WithLongDescription("Print the Skaffold config after profiles, templates, default values and flags are applied.").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.StringVarP(&opts.ConfigurationFile, "filename", "f", "skaffold.yaml", "Filename or URL to the pipeline file")
+			f.StringSliceVarP(&opts.Profiles, "profile", "p", nil, "Activate profiles by name (prefixed with '-' to deactivate a profile)")
+			f.StringSliceVarP(&opts.Modules, "module", "m", nil, "Only run the configs with these names, and the configs they require")
+			f.StringVarP(&opts.DefaultRepo, "default-repo", "d", "", "Default repository value (overrides global config)")
+			f.StringVarP(&inspectOutput, "output", "o", "yaml", "Output format: yaml or json")
+			f.BoolVar(&inspectOverrides, "overrides", false, "List the values set by profiles, templates, default values and flags")
+			f.BoolVar(&inspectDependencies, "dependencies", false, "List the files each artifact and the deployers depend on")
+		}).
+		NoArgs(cancelWithCtrlC(context.Background(), doInspect))
+}
+
+// inspection is printed when overrides or dependencies are requested.
+type inspection struct {
+	Config       *latest.SkaffoldConfig `yaml:"config"`
+	Overrides    []schema.Override      `yaml:"overrides,omitempty"`
+	Dependencies *dependencies          `yaml:"dependencies,omitempty"`
+}
+
+type dependencies struct {
+	Artifacts map[string][]string `yaml:"artifacts,omitempty"`
+	Deploy    []string            `yaml:"deploy,omitempty"`
+}
+
+func doInspect(ctx context.Context, out io.Writer) error {
+	if inspectOutput != "yaml" && inspectOutput != "json" {
+		return fmt.Errorf("invalid output format %s, should be yaml or json", inspectOutput)
+	}
+
+	configs, config, err := mergeConfigs(opts, schema.TraceConfigs)
+	if err != nil {
+		return err
+	}
+
+	tracer := schema.NewOverrideTracer("", config)
+	if err := applyDefaultRepo(config, opts); err != nil {
+		return err
+	}
+	tracer.Record(config, "--default-repo")
+
+	if !inspectOverrides && !inspectDependencies {
+		return printInspection(out, config)
+	}
+
+	result := inspection{Config: config}
+	if inspectOverrides {
+		result.Overrides = append(configs.Overrides, tracer.Overrides()...)
+	}
+	if inspectDependencies {
+		r, err := runner.NewForConfig(opts, config)
+		if err != nil {
+			return errors.Wrap(err, "creating runner")
+		}
+		defer r.RPCServerShutdown()
+
+		artifacts, deploy, err := r.ListDependencies(ctx)
+		if err != nil {
+			return err
+		}
+		result.Dependencies = &dependencies{Artifacts: artifacts, Deploy: deploy}
+	}
+
+	return printInspection(out, result)
+}
+
+// printInspection prints a value in the requested format. Json is converted from
+// yaml, since the configuration only has yaml tags.
+func printInspection(out io.Writer, v interface{}) error {
+	buf, err := yaml.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshalling configuration")
+	}
+
+	if inspectOutput == "json" {
+		var tree interface{}
+		if err := yaml.Unmarshal(buf, &tree); err != nil {
+			return errors.Wrap(err, "converting configuration to json")
+		}
+		if buf, err = json.MarshalIndent(jsonCompatible(tree), "", "  "); err != nil {
+			return errors.Wrap(err, "converting configuration to json")
+		}
+		buf = append(buf, '\n')
+	}
+
+	_, err = out.Write(buf)
+	return err
+}
+
+// jsonCompatible replaces the yaml maps, which have interface{} keys, with json objects.
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, value := range v {
+			m[fmt.Sprintf("%v", key)] = jsonCompatible(value)
+		}
+		return m
+	case []interface{}:
+		for i, value := range v {
+			v[i] = jsonCompatible(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
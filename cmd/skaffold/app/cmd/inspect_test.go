@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestPrintInspection(t *testing.T) {
+	result := inspection{
+		Config: &latest.SkaffoldConfig{
+			APIVersion: latest.Version,
+			Kind:       "Config",
+			Pipeline: latest.Pipeline{Build: latest.BuildConfig{
+				Artifacts: []*latest.Artifact{{ImageName: "app"}},
+			}},
+		},
+		Overrides: []schema.Override{{Path: "build.artifacts[0].image", Value: "app", Source: "--default-repo"}},
+	}
+
+	tests := []struct {
+		description string
+		output      string
+		expected    string
+	}{
+		{
+			description: "yaml",
+			output:      "yaml",
+			expected: `config:
+  apiVersion: ` + latest.Version + `
+  kind: Config
+  build:
+    artifacts:
+    - image: app
+overrides:
+- path: build.artifacts[0].image
+  value: app
+  source: --default-repo
+`,
+		},
+		{
+			description: "json",
+			output:      "json",
+			expected: `{
+  "config": {
+    "apiVersion": "` + latest.Version + `",
+    "build": {
+      "artifacts": [
+        {
+          "image": "app"
+        }
+      ]
+    },
+    "kind": "Config"
+  },
+  "overrides": [
+    {
+      "path": "build.artifacts[0].image",
+      "source": "--default-repo",
+      "value": "app"
+    }
+  ]
+}
+`,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.Override(&inspectOutput, test.output)
+
+			var out bytes.Buffer
+			err := printInspection(&out, result)
+
+			t.CheckNoError(err)
+			t.CheckDeepEqual(test.expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/validation"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// NewCmdLint describes the CLI command to check the configuration without building.
+func NewCmdLint(out io.Writer) *cobra.Command {
+	return NewCmd(out, "lint").
+		WithDescription("Check the Skaffold config for errors, without building").
+		WithFlags(func(f *pflag.FlagSet) {
+			f.StringVarP(&opts.ConfigurationFile, "filename", "f", "skaffold.yaml", "Filename or URL to the pipeline file")
+			f.StringSliceVarP(&opts.Profiles, "profile", "p", nil, "Activate profiles by name (prefixed with '-' to deactivate a profile)")
+			f.StringSliceVarP(&opts.Modules, "module", "m", nil, "Only run the configs with these names, and the configs they require")
+		}).
+		NoArgs(doLint)
+}
+
+func doLint(out io.Writer) error {
+	return lint(out, opts.ConfigurationFile)
+}
+
+func lint(out io.Writer, configFile string) error {
+	lintOpts := *opts
+	lintOpts.ConfigurationFile = configFile
+
+	configs, err := schema.TraceConfigs(&lintOpts)
+	if err != nil {
+		return err
+	}
+
+	var sources []validation.Source
+	for i, config := range configs.Selected {
+		source := validation.Source{Config: config, File: configs.SelectedFiles[i]}
+		for _, override := range configs.Overrides {
+			if override.File == source.File && strings.HasPrefix(override.Source, "profile ") {
+				source.ProfilePaths = append(source.ProfilePaths, override.Path)
+			}
+		}
+		sources = append(sources, source)
+	}
+
+	errs := validation.Lint(sources)
+	if len(configs.Selected) > 1 {
+		if _, err := configs.Merge(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		color.Default.Fprintln(out, "no problems found")
+		return nil
+	}
+
+	for _, err := range errs {
+		color.Red.Fprintln(out, err)
+	}
+	return fmt.Errorf("found %d problem(s) in the config", len(errs))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		description string
+		config      string
+		profiles    []string
+		expected    string
+		shouldErr   bool
+	}{
+		{
+			description: "valid config",
+			config: `build:
+  artifacts:
+  - image: app
+test:
+- image: app
+`,
+			expected: "no problems found\n",
+		},
+		{
+			description: "errors with positions",
+			config: `build:
+  artifacts:
+  - image: app
+  - image: app
+    context: missing
+test:
+- image: other
+deploy:
+  helm:
+    releases:
+    - name: release
+      chartPath: chart
+      values:
+        image: app
+        sidecar: sidecar
+`,
+			expected: `skaffold.yaml:6:5: duplicate artifact image app
+skaffold.yaml:9:3: test image other is not built by any artifact
+skaffold.yaml:17:9: helm value sidecar of release release references image sidecar, which is not built by any artifact
+skaffold.yaml:7:5: artifact app has a context missing that is not a directory
+`,
+			shouldErr: true,
+		},
+		{
+			description: "struct tag errors with positions",
+			config: `build:
+  artifacts:
+  - image: app
+deploy:
+  helm:
+    releases:
+    - name: release
+`,
+			expected: `skaffold.yaml:9:7: required value not set: chartPath
+`,
+			shouldErr: true,
+		},
+		{
+			description: "no position for fields changed by a profile",
+			config: `build:
+  artifacts:
+  - image: app
+  - image: web
+    context: missing
+profiles:
+- name: local
+  build:
+    artifacts:
+    - image: web
+      context: missing
+`,
+			profiles: []string{"local"},
+			expected: `skaffold.yaml: artifact web has a context missing that is not a directory
+`,
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			tmpDir := t.NewTempDir().
+				Write("skaffold.yaml", fmt.Sprintf("apiVersion: %s\nkind: Config\n%s", latest.Version, test.config)).
+				Write("Dockerfile", "FROM scratch")
+			t.Chdir(tmpDir.Root())
+			t.Override(&opts.Profiles, test.profiles)
+
+			var out bytes.Buffer
+			err := lint(&out, "skaffold.yaml")
+
+			t.CheckError(test.shouldErr, err)
+			t.CheckDeepEqual(test.expected, out.String())
+		})
+	}
+}
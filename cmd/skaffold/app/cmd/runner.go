@@ -25,7 +25,6 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
-	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/defaults"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/validation"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/update"
@@ -47,45 +46,80 @@ func withRunner(ctx context.Context, action func(*runner.SkaffoldRunner, *latest
 
 // newRunner creates a SkaffoldRunner and returns the SkaffoldConfig associated with it.
 func newRunner(opts *config.SkaffoldOptions) (*runner.SkaffoldRunner, *latest.SkaffoldConfig, error) {
-	parsed, err := schema.ParseConfig(opts.ConfigurationFile, true)
+	config, files, err := loadConfig(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runner, err := runner.NewForConfig(opts, config)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating runner")
+	}
+	runner.ConfigFiles = files
+	runner.ReloadConfig = func() (*latest.SkaffoldConfig, error) {
+		config, files, err := loadConfig(opts)
+		if err != nil {
+			return nil, err
+		}
+		runner.ConfigFiles = files
+		return config, nil
+	}
+
+	return runner, config, nil
+}
+
+// loadConfig parses the skaffold configuration and the configurations it requires,
+// applies the profiles, sets default values, validates and merges the result.
+// It also returns the configuration files that were read.
+func loadConfig(opts *config.SkaffoldOptions) (*latest.SkaffoldConfig, []string, error) {
+	configs, config, err := mergeConfigs(opts, schema.ParseConfigs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := applyDefaultRepo(config, opts); err != nil {
+		return nil, nil, err
+	}
+
+	return config, configs.Files, nil
+}
+
+// mergeConfigs parses, validates and merges the configurations with the given parse function.
+func mergeConfigs(opts *config.SkaffoldOptions, parse func(*config.SkaffoldOptions) (*schema.Configs, error)) (*schema.Configs, *latest.SkaffoldConfig, error) {
+	configs, err := parse(opts)
 	if err != nil {
 		// If the error is NOT that the file doesn't exist, then we warn the user
 		// that maybe they are using an outdated version of Skaffold that's unable to read
 		// the configuration.
-		if !os.IsNotExist(err) {
+		if schema.IsParseError(err) && !os.IsNotExist(err) {
 			warnIfUpdateIsAvailable()
 		}
 
 		return nil, nil, errors.Wrap(err, "parsing skaffold config")
 	}
 
-	config := parsed.(*latest.SkaffoldConfig)
-
-	if err = schema.ApplyProfiles(config, opts); err != nil {
-		return nil, nil, errors.Wrap(err, "applying profiles")
+	for _, c := range configs.Selected {
+		if err := validation.Process(c); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid skaffold config")
+		}
 	}
 
-	if err := defaults.Set(config); err != nil {
-		return nil, nil, errors.Wrap(err, "setting default values")
+	config, err := configs.Merge()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "merging skaffold configs")
 	}
 
-	if err := validation.Process(config); err != nil {
-		return nil, nil, errors.Wrap(err, "invalid skaffold config")
-	}
+	return configs, config, nil
+}
 
+func applyDefaultRepo(config *latest.SkaffoldConfig, opts *config.SkaffoldOptions) error {
 	defaultRepo, err := configutil.GetDefaultRepo(opts.DefaultRepo)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "getting default repo")
+		return errors.Wrap(err, "getting default repo")
 	}
 
 	applyDefaultRepoSubstitution(config, defaultRepo)
-
-	runner, err := runner.NewForConfig(opts, config)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating runner")
-	}
-
-	return runner, config, nil
+	return nil
 }
 
 func warnIfUpdateIsAvailable() {
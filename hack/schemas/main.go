@@ -28,9 +28,12 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/yamltags"
 	"github.com/pkg/errors"
 	blackfriday "gopkg.in/russross/blackfriday.v2"
 )
@@ -69,6 +72,14 @@ type Definition struct {
 	HTMLDescription      string                 `json:"x-intellij-html-description,omitempty"`
 	Default              interface{}            `json:"default,omitempty"`
 	Examples             []string               `json:"examples,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Minimum              *int64                 `json:"minimum,omitempty"`
+	Maximum              *int64                 `json:"maximum,omitempty"`
+	Dependencies         map[string][]string    `json:"dependencies,omitempty"`
+	AllOf                []*Definition          `json:"allOf,omitempty"`
+	If                   *Definition            `json:"if,omitempty"`
+	Then                 *Definition            `json:"then,omitempty"`
 
 	inlines []*Definition
 	tags    string
@@ -138,6 +149,98 @@ func yamlFieldName(field *ast.Field) string {
 	return strings.Split(yamlTag, ",")[0]
 }
 
+// yamltagsOf returns the yamltags of a field, split into names and values.
+func yamltagsOf(tagValue string) [][]string {
+	tags := reflect.StructTag(strings.Replace(tagValue, "`", "", -1)).Get("yamltags")
+	if tags == "" {
+		return nil
+	}
+
+	var parts [][]string
+	for _, tag := range strings.Split(tags, ",") {
+		parts = append(parts, strings.SplitN(tag, "=", 2))
+	}
+	return parts
+}
+
+// setConstraints translates the yamltags that constrain a value into json schema keywords.
+func setConstraints(def *Definition, tagValue string) {
+	for _, tag := range yamltagsOf(tagValue) {
+		switch tag[0] {
+		case "enum":
+			def.Enum = strings.Split(tag[1], "|")
+		case "enumIgnoreCase":
+			def.Pattern = ignoreCasePattern(strings.Split(tag[1], "|"))
+		case "pattern":
+			def.Pattern = tag[1]
+		case "duration":
+			def.Pattern = yamltags.DurationPattern
+		case "min", "max":
+			limit, err := strconv.ParseInt(tag[1], 10, 64)
+			if err != nil {
+				panic(fmt.Sprintf("invalid %s yamltag: %s", tag[0], tag[1]))
+			}
+			if tag[0] == "min" {
+				def.Minimum = &limit
+			} else {
+				def.Maximum = &limit
+			}
+		}
+	}
+
+	// Templated values are checked after they are expanded.
+	templated := reflect.StructTag(strings.Replace(tagValue, "`", "", -1)).Get("skaffold") == "template"
+	if templated && def.Pattern != "" {
+		def.Pattern = "(" + def.Pattern + ")|\\{\\{"
+	}
+}
+
+// ignoreCasePattern matches any of the values, without regard to case.
+// Json schema patterns have no case-insensitive flag, so each letter is
+// matched with a character class such as `[Tt]`.
+func ignoreCasePattern(values []string) string {
+	var alternatives []string
+	for _, value := range values {
+		var pattern strings.Builder
+		for _, r := range value {
+			lower, upper := unicode.ToLower(r), unicode.ToUpper(r)
+			if lower == upper {
+				pattern.WriteString(regexp.QuoteMeta(string(r)))
+			} else {
+				fmt.Fprintf(&pattern, "[%c%c]", upper, lower)
+			}
+		}
+		alternatives = append(alternatives, pattern.String())
+	}
+	return "^(" + strings.Join(alternatives, "|") + ")$"
+}
+
+// setRequiredIf translates a requiredIf yamltag of a field into json schema keywords on its struct.
+func setRequiredIf(def *Definition, yamlName string, value string) {
+	parts := strings.SplitN(value, ":", 2)
+	other := parts[0]
+
+	if len(parts) == 1 {
+		if def.Dependencies == nil {
+			def.Dependencies = map[string][]string{}
+		}
+		def.Dependencies[other] = append(def.Dependencies[other], yamlName)
+		return
+	}
+
+	def.AllOf = append(def.AllOf, &Definition{
+		If: &Definition{
+			Properties: map[string]*Definition{
+				other: {Enum: strings.Split(parts[1], "|")},
+			},
+			Required: []string{other},
+		},
+		Then: &Definition{
+			Required: []string{yamlName},
+		},
+	})
+}
+
 func setTypeOrRef(def *Definition, typeName string) {
 	switch typeName {
 	case "string":
@@ -205,8 +308,13 @@ func (g *schemaGenerator) newDefinition(name string, t ast.Expr, comment string,
 				continue
 			}
 
-			if strings.Contains(field.Tag.Value, "required") {
-				def.Required = append(def.Required, yamlName)
+			for _, tag := range yamltagsOf(field.Tag.Value) {
+				switch tag[0] {
+				case "required":
+					def.Required = append(def.Required, yamlName)
+				case "requiredIf":
+					setRequiredIf(def, yamlName, tag[1])
+				}
 			}
 
 			if def.Properties == nil {
@@ -225,6 +333,8 @@ func (g *schemaGenerator) newDefinition(name string, t ast.Expr, comment string,
 		}
 	}
 
+	setConstraints(def, tags)
+
 	description := strings.TrimSpace(strings.Replace(comment, "\n", " ", -1))
 
 	// Extract default value
@@ -317,6 +427,13 @@ func (g *schemaGenerator) Apply(inputPath string) ([]byte, error) {
 				}
 				def.PreferredOrder = append(def.PreferredOrder, inlineStructRef.PreferredOrder...)
 				def.Required = append(def.Required, inlineStructRef.Required...)
+				def.AllOf = append(def.AllOf, inlineStructRef.AllOf...)
+				for k, v := range inlineStructRef.Dependencies {
+					if def.Dependencies == nil {
+						def.Dependencies = map[string][]string{}
+					}
+					def.Dependencies[k] = append(def.Dependencies[k], v...)
+				}
 				continue
 			}
 
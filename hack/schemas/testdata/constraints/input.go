@@ -0,0 +1,47 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latest
+
+// TestStruct for testing the schema generator.
+type TestStruct struct {
+	// Variant should be an enum
+	Variant string `yaml:"variant" yamltags:"enum=Tags|CommitSha"`
+
+	// Mode should be an enum that ignores case
+	Mode string `yaml:"mode" yamltags:"enumIgnoreCase=Tags|CommitSha"`
+
+	// Timeout should be a duration
+	Timeout string `yaml:"timeout" yamltags:"duration"`
+
+	// Secret should match a pattern, or be a template
+	Secret string `yaml:"secret" yamltags:"pattern=\\.json$" skaffold:"template"`
+
+	// Port should be in a range
+	Port int `yaml:"port" yamltags:"min=1,max=65535"`
+
+	// Op is a field that others depend on
+	Op string `yaml:"op"`
+
+	// From should be required for some ops
+	From string `yaml:"from" yamltags:"requiredIf=op:move|copy"`
+
+	// Cache is a field that others depend on
+	Cache string `yaml:"cache"`
+
+	// HostPath should be required with cache
+	HostPath string `yaml:"hostPath" yamltags:"requiredIf=cache"`
+}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
@@ -43,8 +45,9 @@ type ImageDetails struct {
 }
 
 type detailsErr struct {
-	details *cachedArtifactDetails
-	err     error
+	details  *cachedArtifactDetails
+	err      error
+	duration time.Duration
 }
 
 // RetrieveCachedArtifacts checks to see if artifacts are cached, and returns tags for cached images, otherwise a list of images to be built
@@ -63,8 +66,9 @@ func (c *Cache) RetrieveCachedArtifacts(ctx context.Context, out io.Writer, arti
 
 		i := i
 		go func() {
+			start := time.Now()
 			details, err := c.retrieveCachedArtifactDetails(ctx, artifacts[i])
-			detailsErrs[i] <- detailsErr{details: details, err: err}
+			detailsErrs[i] <- detailsErr{details: details, err: err, duration: time.Since(start)}
 		}()
 	}
 
@@ -84,11 +88,15 @@ func (c *Cache) RetrieveCachedArtifacts(ctx context.Context, out io.Writer, arti
 			details := d.details
 			err := d.err
 			if err != nil || details.needsRebuild {
+				event.CacheMiss(artifact.ImageName, d.duration)
+				metrics.CacheLookup(artifact.ImageName, false)
 				color.Red.Fprintln(out, "Not found. Rebuilding.")
 				needToBuild = append(needToBuild, artifact)
 				continue
 			}
 
+			event.CacheHit(artifact.ImageName, details.hashTag, d.duration)
+			metrics.CacheLookup(artifact.ImageName, true)
 			color.Green.Fprint(out, "Found")
 			if details.needsRetag {
 				color.Green.Fprint(out, ". Retagging")
@@ -26,6 +26,8 @@ import (
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/testutil"
 	"github.com/docker/docker/api/types"
@@ -121,6 +123,9 @@ func Test_RetrieveCachedArtifacts(t *testing.T) {
 			expectedBuildResults: []build.Artifact{{ImageName: "image1", Tag: "image1:hash"}},
 		},
 	}
+	event.InitializeState(&runcontext.RunContext{
+		Cfg: &latest.Pipeline{},
+	})
 	for _, test := range tests {
 		testutil.Run(t, test.description, func(t *testutil.T) {
 			t.Override(&hashForArtifact, mockHashForArtifact(test.hashes))
@@ -22,10 +22,12 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/pkg/errors"
 )
@@ -75,8 +77,10 @@ func InParallel(ctx context.Context, out io.Writer, tags tag.ImageTags, artifact
 
 func runBuild(ctx context.Context, cw io.WriteCloser, tags tag.ImageTags, artifact *latest.Artifact, results *sync.Map, build artifactBuilder) {
 	event.BuildInProgress(artifact.ImageName)
+	start := time.Now()
 
 	finalTag, err := getBuildResult(ctx, cw, tags, artifact, build)
+	metrics.ArtifactBuilt(artifact, time.Since(start), err)
 	if err != nil {
 		event.BuildFailed(artifact.ImageName, err)
 		results.Store(artifact.ImageName, err)
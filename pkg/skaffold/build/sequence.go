@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/pkg/errors"
 )
@@ -42,7 +44,9 @@ func InSequence(ctx context.Context, out io.Writer, tags tag.ImageTags, artifact
 			return nil, fmt.Errorf("unable to find tag for image %s", artifact.ImageName)
 		}
 
+		start := time.Now()
 		finalTag, err := buildArtifact(ctx, out, artifact, tag)
+		metrics.ArtifactBuilt(artifact, time.Since(start), err)
 		if err != nil {
 			event.BuildFailed(artifact.ImageName, err)
 			return nil, errors.Wrapf(err, "building [%s]", artifact.ImageName)
@@ -31,6 +31,9 @@ type SkaffoldOptions struct {
 	Tail               bool
 	TailDev            bool
 	PortForward        bool
+	AutoBuild          bool
+	AutoSync           bool
+	AutoDeploy         bool
 	SkipTests          bool
 	CacheArtifacts     bool
 	EnableRPC          bool
@@ -47,10 +50,22 @@ type SkaffoldOptions struct {
 	CustomLabels       []string
 	TargetImages       []string
 	Profiles           []string
+	Modules            []string
 	InsecureRegistries []string
 	Command            string
 	RPCPort            int
 	RPCHTTPPort        int
+	RPCSocket          string
+	RPCHTTPSocket      string
+	RPCTLSCert         string
+	RPCTLSKey          string
+	RPCToken           string
+	RPCReadOnly        bool
+	EventLogFile       string
+	LogInclude         []string
+	LogExclude         []string
+	LogFormat          string
+	LogDir             string
 }
 
 // Labels returns a map of labels to be applied to all deployed
@@ -67,8 +82,15 @@ func (opts *SkaffoldOptions) Labels() map[string]string {
 	if opts.Namespace != "" {
 		labels["skaffold.dev/namespace"] = opts.Namespace
 	}
-	if len(opts.Profiles) > 0 {
-		labels["skaffold.dev/profiles"] = strings.Join(opts.Profiles, "__")
+	var profiles []string
+	for _, profile := range opts.Profiles {
+		// Deactivated profiles aren't valid label values
+		if !strings.HasPrefix(profile, "-") {
+			profiles = append(profiles, profile)
+		}
+	}
+	if len(profiles) > 0 {
+		labels["skaffold.dev/profiles"] = strings.Join(profiles, "__")
 	}
 	for _, cl := range opts.CustomLabels {
 		l := strings.SplitN(cl, "=", 2)
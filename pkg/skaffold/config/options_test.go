@@ -62,6 +62,18 @@ func TestLabels(t *testing.T) {
 				"skaffold.dev/profiles": "profile1__profile2",
 			},
 		},
+		{
+			description: "deactivated profiles",
+			options:     SkaffoldOptions{Profiles: []string{"profile1", "-auto"}},
+			expectedLabels: map[string]string{
+				"skaffold.dev/profiles": "profile1",
+			},
+		},
+		{
+			description:    "only deactivated profiles",
+			options:        SkaffoldOptions{Profiles: []string{"-auto"}},
+			expectedLabels: map[string]string{},
+		},
 		{
 			description: "tail",
 			options:     SkaffoldOptions{Tail: true},
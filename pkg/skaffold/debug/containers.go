@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+)
+
+// ContainerManager watches the selected pods for containers configured for debugging,
+// and notifies the event API when they start or terminate.
+type ContainerManager struct {
+	podSelector kubernetes.PodSelector
+	namespaces  []string
+
+	// active is the set of debuggable containers, keyed by namespace/pod/container, that are running
+	active map[string]containerDebugConfiguration
+	lock   sync.Mutex
+	cancel context.CancelFunc
+}
+
+// containerDebugConfiguration is a container's entry in the debug configuration annotation of its pod
+type containerDebugConfiguration struct {
+	runtime    string
+	workingDir string
+	portName   string
+	port       int32
+}
+
+// NewContainerManager returns a ContainerManager for the given pods.
+func NewContainerManager(podSelector kubernetes.PodSelector, namespaces []string) *ContainerManager {
+	return &ContainerManager{
+		podSelector: podSelector,
+		namespaces:  namespaces,
+		active:      make(map[string]containerDebugConfiguration),
+	}
+}
+
+// Start begins watching pods in the background.
+func (d *ContainerManager) Start(ctx context.Context) error {
+	aggregate := make(chan watch.Event)
+	stopWatchers, err := kubernetes.AggregatePodWatcher(d.namespaces, aggregate)
+	if err != nil {
+		stopWatchers()
+		return errors.Wrap(err, "initializing pod watcher")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	d.cancel = cancel
+
+	go func() {
+		defer stopWatchers()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-aggregate:
+				if !ok {
+					return
+				}
+				if pod, ok := evt.Object.(*v1.Pod); ok && d.podSelector.Select(pod) {
+					d.checkPod(pod, evt.Type == watch.Deleted)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops watching pods.
+func (d *ContainerManager) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+}
+
+// checkPod notifies the debuggable containers of the pod that have started or terminated since the pod was last seen.
+func (d *ContainerManager) checkPod(pod *v1.Pod, deleted bool) {
+	annotation, found := pod.Annotations[debugConfigAnnotation]
+	if !found {
+		return
+	}
+	configurations, err := decodeContainerConfigurations(annotation)
+	if err != nil {
+		logrus.Debugf("Unable to read debug configuration of pod %s: %v", pod.Name, err)
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	terminating := deleted || pod.DeletionTimestamp != nil
+	for name, config := range configurations {
+		key := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, name)
+		_, wasRunning := d.active[key]
+		running := !terminating && isContainerRunning(pod, name)
+
+		switch {
+		case running && !wasRunning:
+			d.active[key] = config
+			event.DebuggingContainerStarted(pod.Name, name, pod.Namespace, config.runtime, config.workingDir, config.portName, config.port)
+		case !running && wasRunning:
+			delete(d.active, key)
+			event.DebuggingContainerTerminated(pod.Name, name, pod.Namespace, config.runtime, config.workingDir, config.portName, config.port)
+		}
+	}
+}
+
+func isContainerRunning(pod *v1.Pod, name string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == name {
+			return status.State.Running != nil
+		}
+	}
+	return false
+}
+
+// decodeContainerConfigurations parses a debug configuration annotation, as produced by `encodeConfigurations`.
+func decodeContainerConfigurations(annotation string) (map[string]containerDebugConfiguration, error) {
+	var decoded map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(annotation), &decoded); err != nil {
+		return nil, err
+	}
+
+	configurations := make(map[string]containerDebugConfiguration)
+	for name, values := range decoded {
+		var config containerDebugConfiguration
+		var keys []string
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			switch value := values[key].(type) {
+			case string:
+				switch key {
+				case "runtime":
+					config.runtime = value
+				case "workingDir":
+					config.workingDir = value
+				}
+			case float64:
+				// any numeric value is the debug port, named by its key
+				if config.portName == "" {
+					config.portName = key
+					config.port = int32(value)
+				}
+			}
+		}
+		configurations[name] = config
+	}
+	return configurations, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeContainerConfigurations(t *testing.T) {
+	tests := []struct {
+		description string
+		annotation  string
+		shouldErr   bool
+		expected    map[string]containerDebugConfiguration
+	}{
+		{
+			description: "go and python containers",
+			annotation:  `{"app":{"dlv":56268,"runtime":"go","workingDir":"/work"},"worker":{"dap":5678,"runtime":"python"}}`,
+			expected: map[string]containerDebugConfiguration{
+				"app":    {runtime: "go", workingDir: "/work", portName: "dlv", port: 56268},
+				"worker": {runtime: "python", portName: "dap", port: 5678},
+			},
+		},
+		{
+			description: "no port",
+			annotation:  `{"app":{"runtime":"go"}}`,
+			expected: map[string]containerDebugConfiguration{
+				"app": {runtime: "go"},
+			},
+		},
+		{
+			description: "invalid",
+			annotation:  `{"app"`,
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			configurations, err := decodeContainerConfigurations(test.annotation)
+
+			t.CheckErrorAndDeepEqual(test.shouldErr, err, test.expected, configurations, cmp.AllowUnexported(containerDebugConfiguration{}))
+		})
+	}
+}
+
+func TestContainerManagerCheckPod(t *testing.T) {
+	event.InitializeState(&runcontext.RunContext{
+		Cfg:  &latest.Pipeline{},
+		Opts: &config.SkaffoldOptions{},
+	})
+
+	pod := func(running bool, deleting bool) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod",
+				Namespace:   "ns",
+				Annotations: map[string]string{"debug.cloud.google.com/config": `{"app":{"dlv":56268,"runtime":"go"}}`},
+			},
+		}
+		state := v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}
+		if running {
+			state = v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+		}
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{{Name: "app", State: state}}
+		if deleting {
+			pod.DeletionTimestamp = &metav1.Time{}
+		}
+		return pod
+	}
+	isActive := func(manager *ContainerManager) bool {
+		_, found := manager.active["ns/pod/app"]
+		return found
+	}
+
+	manager := NewContainerManager(kubernetes.NewImageList(), nil)
+
+	manager.checkPod(pod(false, false), false)
+	testutil.CheckDeepEqual(t, false, isActive(manager))
+
+	manager.checkPod(pod(true, false), false)
+	testutil.CheckDeepEqual(t, true, isActive(manager))
+
+	manager.checkPod(pod(true, true), false)
+	testutil.CheckDeepEqual(t, false, isActive(manager))
+
+	manager.checkPod(pod(true, false), false)
+	testutil.CheckDeepEqual(t, true, isActive(manager))
+
+	manager.checkPod(pod(true, false), true)
+	testutil.CheckDeepEqual(t, false, isActive(manager))
+}
@@ -32,8 +32,12 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy/kubectl"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 )
 
+// PortNames are the names of the container ports on which the configured debuggers listen.
+var PortNames = []string{"dlv", "jdwp", "devtools", "dap"}
+
 var (
 	decodeFromYaml = scheme.Codecs.UniversalDeserializer().Decode
 	encodeAsYaml   = func(o runtime.Object) ([]byte, error) {
@@ -49,13 +53,23 @@ var (
 )
 
 // ApplyDebuggingTransforms applies language-platform-specific transforms to a list of manifests.
-func ApplyDebuggingTransforms(l kubectl.ManifestList, builds []build.Artifact, insecureRegistries map[string]bool) (kubectl.ManifestList, error) {
+// The artifacts' configured runtime types take precedence over the runtime detected from their images.
+func ApplyDebuggingTransforms(l kubectl.ManifestList, builds []build.Artifact, artifacts []*latest.Artifact, insecureRegistries map[string]bool) (kubectl.ManifestList, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	retriever := func(image string) (imageConfiguration, error) {
 		if artifact := findArtifact(image, builds); artifact != nil {
-			return retrieveImageConfiguration(ctx, artifact, insecureRegistries)
+			config, err := retrieveImageConfiguration(ctx, artifact, insecureRegistries)
+			if err != nil {
+				return config, err
+			}
+			for _, a := range artifacts {
+				if a.ImageName == artifact.ImageName {
+					config.runtimeType = a.RuntimeType
+				}
+			}
+			return config, nil
 		}
 		return imageConfiguration{}, errors.Errorf("no build artifact for [%q]", image)
 	}
@@ -118,6 +132,7 @@ func retrieveImageConfiguration(ctx context.Context, artifact *build.Artifact, i
 		entrypoint: config.Entrypoint,
 		arguments:  config.Cmd,
 		labels:     config.Labels,
+		workingDir: config.WorkingDir,
 	}, nil
 }
 
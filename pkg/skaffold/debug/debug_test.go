@@ -106,18 +106,22 @@ func TestPodEncodeDecode(t *testing.T) {
 // testTransformer is a simple transformer that applies to everything
 type testTransformer struct{}
 
+func (t testTransformer) Runtime() string {
+	return "test"
+}
+
 func (t testTransformer) IsApplicable(config imageConfiguration) bool {
 	return true
 }
 
-func (t testTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) map[string]interface{} {
+func (t testTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) (map[string]interface{}, string) {
 	port := portAlloc(9999)
 	container.Ports = append(container.Ports, v1.ContainerPort{Name: "test", ContainerPort: port})
 
 	testEnv := v1.EnvVar{Name: "KEY", Value: "value"}
 	container.Env = append(container.Env, testEnv)
 
-	return map[string]interface{}{"key": "value"}
+	return map[string]interface{}{"key": "value"}, ""
 }
 
 func TestApplyDebuggingTransforms(t *testing.T) {
@@ -30,12 +30,38 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 )
 
 // portAllocator is a function that takes a desired port and returns an available port
 // Ports are normally uint16 but Kubernetes ContainerPort.containerPort is an integer
 type portAllocator func(int32) int32
 
+const (
+	// debuggingSupportVolume is the name of the volume shared between the debugging support
+	// init containers and the containers being debugged
+	debuggingSupportVolume = "debugging-support-files"
+
+	// debuggingSupportMountPath is where the debugging support files are mounted
+	debuggingSupportMountPath = "/dbg"
+
+	// runtimeAnnotation selects the language runtime when set as an image label or as a pod annotation.
+	// As a pod annotation, the value may also be a comma-separated list of `container=runtime` pairs.
+	runtimeAnnotation = "debug.cloud.google.com/runtime"
+
+	// debugConfigAnnotation records the debug configuration of each container of a pod
+	debugConfigAnnotation = "debug.cloud.google.com/config"
+
+	// debugProbeTimeoutSeconds is the minimum timeout for probes of containers being debugged,
+	// so that a process paused at a breakpoint isn't restarted or taken out of service
+	debugProbeTimeoutSeconds = 600
+)
+
+// debugHelpersRegistry is the registry holding the images that install
+// runtime-specific debugging support files, such as `dlv`
+var debugHelpersRegistry = "gcr.io/gcp-dev-tools/duct-tape"
+
 // configurationRetriever retrieves an container image configuration
 type configurationRetriever func(string) (imageConfiguration, error)
 
@@ -45,15 +71,23 @@ type imageConfiguration struct {
 	env        map[string]string
 	entrypoint []string
 	arguments  []string
+	workingDir string
+	// runtimeType is the runtime configured for the artifact, if any
+	runtimeType string
 }
 
 // containerTransformer transforms a container definition
 type containerTransformer interface {
+	// Runtime returns the name of the runtime handled by this transformer, as used in the debug configuration.
+	Runtime() string
+
 	// IsApplicable determines if this container is suitable to be transformed.
 	IsApplicable(config imageConfiguration) bool
 
-	// Apply configures a container definition for debugging, returning a simple map describing the debug configuration details or `nil` if it could not be done
-	Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) map[string]interface{}
+	// Apply configures a container definition for debugging, returning a simple map describing the debug configuration details or `nil` if it could not be done.
+	// The second result names a debugging support image (e.g., `go`) whose files must be installed
+	// into the container under `/dbg`, or is empty if none are required.
+	Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) (map[string]interface{}, string)
 }
 
 var containerTransforms []containerTransformer
@@ -122,26 +156,81 @@ func transformPodSpec(metadata *metav1.ObjectMeta, podSpec *v1.PodSpec, retrieve
 	}
 	// containers are required to have unique name within a pod
 	configurations := make(map[string]map[string]interface{})
+	var supportImages []string
 	for i := range podSpec.Containers {
 		container := &podSpec.Containers[i]
+		runtime := annotatedRuntime(metadata.Annotations[runtimeAnnotation], container.Name)
 		// we only reconfigure build artifacts
-		if configuration, err := transformContainer(container, retrieveImageConfiguration, portAlloc); err == nil {
-			configurations[container.Name] = configuration
-			// todo: add this artifact to the watch list?
-		} else {
+		configuration, supportImage, err := transformContainer(container, retrieveImageConfiguration, portAlloc, runtime)
+		if err != nil {
 			logrus.Infof("Image [%s] not configured for debugging: %v", container.Image, err)
+			continue
+		}
+		configurations[container.Name] = configuration
+		if configuration != nil {
+			extendProbeTimeouts(container)
 		}
+		if supportImage != "" {
+			container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: debuggingSupportVolume, MountPath: debuggingSupportMountPath})
+			if !util.StrSliceContains(supportImages, supportImage) {
+				supportImages = append(supportImages, supportImage)
+			}
+		}
+	}
+	if len(supportImages) > 0 {
+		addDebuggingSupport(podSpec, supportImages)
 	}
 	if len(configurations) > 0 {
 		if metadata.Annotations == nil {
 			metadata.Annotations = make(map[string]string)
 		}
-		metadata.Annotations["debug.cloud.google.com/config"] = encodeConfigurations(configurations)
+		metadata.Annotations[debugConfigAnnotation] = encodeConfigurations(configurations)
 		return true
 	}
 	return false
 }
 
+// annotatedRuntime returns the runtime selected for a container by the pod's runtime annotation, if any.
+func annotatedRuntime(annotation string, containerName string) string {
+	runtime := ""
+	for _, entry := range strings.Split(annotation, ",") {
+		entry = strings.TrimSpace(entry)
+		if split := strings.SplitN(entry, "=", 2); len(split) == 2 {
+			if strings.TrimSpace(split[0]) == containerName {
+				return strings.TrimSpace(split[1])
+			}
+		} else if entry != "" {
+			runtime = entry
+		}
+	}
+	return runtime
+}
+
+// extendProbeTimeouts extends the timeouts of the container's liveness and readiness probes.
+func extendProbeTimeouts(container *v1.Container) {
+	for _, probe := range []*v1.Probe{container.LivenessProbe, container.ReadinessProbe} {
+		if probe != nil && probe.TimeoutSeconds < debugProbeTimeoutSeconds {
+			probe.TimeoutSeconds = debugProbeTimeoutSeconds
+		}
+	}
+}
+
+// addDebuggingSupport adds a shared volume to the podSpec along with an init container
+// per support image to populate it with the runtime's debugging support files.
+func addDebuggingSupport(podSpec *v1.PodSpec, supportImages []string) {
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name:         debuggingSupportVolume,
+		VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+	})
+	for _, supportImage := range supportImages {
+		podSpec.InitContainers = append(podSpec.InitContainers, v1.Container{
+			Name:         fmt.Sprintf("install-%s-support", supportImage),
+			Image:        fmt.Sprintf("%s/%s", debugHelpersRegistry, supportImage),
+			VolumeMounts: []v1.VolumeMount{{Name: debuggingSupportVolume, MountPath: debuggingSupportMountPath}},
+		})
+	}
+}
+
 // allocatePort walks the podSpec's containers looking for an available port that is close to desiredPort.
 // We deal with wrapping and avoid allocating ports < 1024
 func allocatePort(podSpec *v1.PodSpec, desiredPort int32) int32 {
@@ -177,12 +266,15 @@ func isPortAvailable(podSpec *v1.PodSpec, port int32) bool {
 }
 
 // transformContainer rewrites the container definition to enable debugging.
-// Returns a debugging configuration description or an error if the rewrite was unsuccessful.
-func transformContainer(container *v1.Container, retrieveImageConfiguration configurationRetriever, portAlloc portAllocator) (map[string]interface{}, error) {
+// The runtime is selected explicitly by the given runtime, the artifact's configured runtime type
+// or the image's runtime label, in that order, and is otherwise guessed from the image configuration.
+// Returns a debugging configuration description and the name of any required debugging support image,
+// or an error if the rewrite was unsuccessful.
+func transformContainer(container *v1.Container, retrieveImageConfiguration configurationRetriever, portAlloc portAllocator, runtime string) (map[string]interface{}, string, error) {
 	var config imageConfiguration
 	config, err := retrieveImageConfiguration(container.Image)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// update image configuration values with those set in the k8s manifest
@@ -197,13 +289,57 @@ func transformContainer(container *v1.Container, retrieveImageConfiguration conf
 	if len(container.Args) > 0 {
 		config.arguments = container.Args
 	}
+	if container.WorkingDir != "" {
+		config.workingDir = container.WorkingDir
+	}
+
+	if runtime == "" {
+		runtime = config.runtimeType
+	}
+	if runtime == "" {
+		runtime = config.labels[runtimeAnnotation]
+	}
+
+	// look inside `sh -c` and entrypoint script wrappers for the actual program
+	wrapper, unwrapped := unwrapCommandLine(config)
+
+	transform, err := selectTransformer(runtime, unwrapped)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "container [%s]", container.Name)
+	}
+	if wrapper == nil {
+		configuration, supportImage := transform.Apply(container, config, portAlloc)
+		return configuration, supportImage, nil
+	}
+
+	// let the transformer rewrite the wrapped program as the container's arguments
+	command, args := container.Command, container.Args
+	container.Command, container.Args = nil, nil
+	configuration, supportImage := transform.Apply(container, unwrapped, portAlloc)
+	program := container.Args
+	container.Command, container.Args = command, args
+	if len(program) > 0 {
+		wrapper.rewrap(container, program)
+	}
+	return configuration, supportImage, nil
+}
 
+// selectTransformer returns the transformer for the given runtime, or the first applicable transformer if no runtime is given.
+func selectTransformer(runtime string, config imageConfiguration) (containerTransformer, error) {
+	if runtime != "" {
+		for _, transform := range containerTransforms {
+			if transform.Runtime() == runtime {
+				return transform, nil
+			}
+		}
+		return nil, errors.Errorf("unknown runtime %q", runtime)
+	}
 	for _, transform := range containerTransforms {
 		if transform.IsApplicable(config) {
-			return transform.Apply(container, config, portAlloc), nil
+			return transform, nil
 		}
 	}
-	return nil, errors.Errorf("unable to determine runtime for [%s]", container.Name)
+	return nil, errors.New("unable to determine runtime")
 }
 
 func encodeConfigurations(configurations map[string]map[string]interface{}) string {
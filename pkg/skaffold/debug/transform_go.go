@@ -0,0 +1,205 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+)
+
+type dlvTransformer struct{}
+
+func init() {
+	containerTransforms = append(containerTransforms, dlvTransformer{})
+}
+
+const (
+	// dlv has no standard port; 56268 is unlikely to clash with application ports
+	defaultDlvPort = 56268
+)
+
+// dlvSpec captures the useful delve runtime options
+type dlvSpec struct {
+	mode            string
+	headless        bool
+	continueOnStart bool
+	multiclient     bool
+	host            string
+	port            int32
+	apiVersion      int
+}
+
+func newDlvSpec(port int32) dlvSpec {
+	return dlvSpec{mode: "exec", headless: true, continueOnStart: true, multiclient: true, port: port, apiVersion: 2}
+}
+
+// isLaunchingDlv determines if the arguments seems to be invoking delve
+func isLaunchingDlv(args []string) bool {
+	return len(args) > 0 && (args[0] == "dlv" || strings.HasSuffix(args[0], "/dlv"))
+}
+
+func (t dlvTransformer) Runtime() string {
+	return "go"
+}
+
+func (t dlvTransformer) IsApplicable(config imageConfiguration) bool {
+	// GOTRACEBACK and GODEBUG are only meaningful to Go programs, and KO_DATA_PATH is set on images built by ko
+	for _, name := range []string{"GOTRACEBACK", "KO_DATA_PATH", "GODEBUG"} {
+		if _, found := config.env[name]; found {
+			return true
+		}
+	}
+	if len(config.entrypoint) > 0 {
+		return isLaunchingDlv(config.entrypoint)
+	}
+	return isLaunchingDlv(config.arguments)
+}
+
+// Apply configures a container definition for Go debugging with delve.
+// Returns a simple map describing the debug configuration details and
+// the name of the support image providing `dlv`.
+func (t dlvTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) (map[string]interface{}, string) {
+	logrus.Infof("Configuring [%s] for Go/Delve debugging", container.Name)
+
+	// try to find an existing `dlv` command
+	spec := retrieveDlvSpec(config)
+	supportImage := ""
+
+	if spec == nil {
+		newSpec := newDlvSpec(portAlloc(defaultDlvPort))
+		spec = &newSpec
+		switch {
+		case len(config.entrypoint) > 0:
+			container.Command = rewriteDlvCommandLine(config.entrypoint, *spec, config.arguments)
+			// overriding the command causes the image's CMD to be ignored
+			if len(container.Args) == 0 {
+				container.Args = config.arguments
+			}
+
+		case len(config.arguments) > 0:
+			container.Args = rewriteDlvCommandLine(config.arguments, *spec, nil)
+
+		default:
+			logrus.Warnf("Skipping [%s] as it does not specify a command to debug", container.Name)
+			return nil, ""
+		}
+		supportImage = "go"
+	}
+
+	dlvPort := v1.ContainerPort{
+		Name:          "dlv",
+		ContainerPort: spec.port,
+	}
+	container.Ports = append(container.Ports, dlvPort)
+
+	configuration := map[string]interface{}{
+		"runtime": "go",
+		"dlv":     spec.port,
+	}
+	if config.workingDir != "" {
+		configuration["workingDir"] = config.workingDir
+	}
+	return configuration, supportImage
+}
+
+// retrieveDlvSpec returns the delve configuration if the container is already launched with `dlv`
+func retrieveDlvSpec(config imageConfiguration) *dlvSpec {
+	// the arguments of a `dlv` entrypoint can be given in the image's CMD
+	if isLaunchingDlv(config.entrypoint) {
+		return extractDlvSpec(append(append([]string{}, config.entrypoint...), config.arguments...))
+	}
+	if len(config.entrypoint) == 0 && isLaunchingDlv(config.arguments) {
+		return extractDlvSpec(config.arguments)
+	}
+	return nil
+}
+
+// extractDlvSpec parses a `dlv` command-line, using delve's defaults for unspecified options
+func extractDlvSpec(args []string) *dlvSpec {
+	spec := dlvSpec{apiVersion: 1, port: defaultDlvPort}
+	if len(args) < 2 {
+		return &spec
+	}
+	spec.mode = args[1]
+	for _, arg := range args[2:] {
+		switch {
+		case arg == "--":
+			return &spec
+		case arg == "--headless" || arg == "--headless=true":
+			spec.headless = true
+		case arg == "--continue" || arg == "--continue=true":
+			spec.continueOnStart = true
+		case arg == "--accept-multiclient" || arg == "--accept-multiclient=true":
+			spec.multiclient = true
+		case strings.HasPrefix(arg, "--api-version="):
+			if version, err := strconv.Atoi(arg[14:]); err == nil {
+				spec.apiVersion = version
+			}
+		case strings.HasPrefix(arg, "--listen="):
+			address := arg[9:]
+			split := strings.SplitN(address, ":", 2)
+			if len(split) == 2 {
+				spec.host = split[0]
+				address = split[1]
+			}
+			port, err := strconv.ParseInt(address, 10, 32)
+			if err != nil {
+				logrus.Errorf("Invalid dlv listen address \"%s\": %s\n", arg[9:], err)
+				return nil
+			}
+			spec.port = int32(port)
+		}
+	}
+	return &spec
+}
+
+// args returns the delve command-line for this spec, up to but excluding the program to debug
+func (spec dlvSpec) args() []string {
+	args := []string{debuggingSupportMountPath + "/go/bin/dlv", spec.mode}
+	if spec.headless {
+		args = append(args, "--headless")
+	}
+	if spec.continueOnStart {
+		args = append(args, "--continue")
+	}
+	if spec.multiclient {
+		args = append(args, "--accept-multiclient")
+	}
+	if spec.port > 0 {
+		args = append(args, "--listen="+spec.host+":"+strconv.FormatInt(int64(spec.port), 10))
+	}
+	if spec.apiVersion > 0 {
+		args = append(args, "--api-version="+strconv.Itoa(spec.apiVersion))
+	}
+	return args
+}
+
+// rewriteDlvCommandLine rewrites a command-line to run the program under `dlv exec`.
+// The trailing arguments are those that will be appended to the command-line by the
+// container runtime, such as the image's `CMD`, and must be passed through to the program.
+func rewriteDlvCommandLine(commandLine []string, spec dlvSpec, trailing []string) []string {
+	// Assumes that commandLine[0] is the Go binary
+	newCommandLine := append(spec.args(), commandLine[0])
+	if len(commandLine) > 1 || len(trailing) > 0 {
+		// delve requires `--` to separate the program's arguments from its own
+		newCommandLine = append(newCommandLine, "--")
+	}
+	return append(newCommandLine, commandLine[1:]...)
+}
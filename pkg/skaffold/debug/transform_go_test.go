@@ -0,0 +1,277 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDlvTransformer_IsApplicable(t *testing.T) {
+	tests := []struct {
+		description string
+		source      imageConfiguration
+		result      bool
+	}{
+		{
+			description: "GOTRACEBACK",
+			source:      imageConfiguration{env: map[string]string{"GOTRACEBACK": "all"}},
+			result:      true,
+		},
+		{
+			description: "KO_DATA_PATH",
+			source:      imageConfiguration{env: map[string]string{"KO_DATA_PATH": "/var/run/ko"}},
+			result:      true,
+		},
+		{
+			description: "GODEBUG",
+			source:      imageConfiguration{env: map[string]string{"GODEBUG": "gctrace=1"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint dlv",
+			source:      imageConfiguration{entrypoint: []string{"dlv", "exec", "app"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint /go/bin/dlv",
+			source:      imageConfiguration{entrypoint: []string{"/go/bin/dlv", "exec", "app"}},
+			result:      true,
+		},
+		{
+			description: "no entrypoint, args dlv",
+			source:      imageConfiguration{arguments: []string{"dlv", "exec", "app"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint /bin/sh",
+			source:      imageConfiguration{entrypoint: []string{"/bin/sh"}, arguments: []string{"dlv", "exec", "app"}},
+			result:      false,
+		},
+		{
+			description: "nothing",
+			source:      imageConfiguration{},
+			result:      false,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, dlvTransformer{}.IsApplicable(test.source))
+		})
+	}
+}
+
+func TestExtractDlvSpec(t *testing.T) {
+	tests := []struct {
+		in     []string
+		result *dlvSpec
+	}{
+		{[]string{"dlv"}, &dlvSpec{port: 56268, apiVersion: 1}},
+		{[]string{"dlv", "debug"}, &dlvSpec{mode: "debug", port: 56268, apiVersion: 1}},
+		{[]string{"dlv", "exec", "--headless", "--listen=:4000", "app"}, &dlvSpec{mode: "exec", headless: true, port: 4000, apiVersion: 1}},
+		{[]string{"dlv", "exec", "--listen=localhost:4000", "--api-version=2", "--continue", "--accept-multiclient", "app"}, &dlvSpec{mode: "exec", continueOnStart: true, multiclient: true, host: "localhost", port: 4000, apiVersion: 2}},
+		{[]string{"dlv", "exec", "app", "--", "--listen=:4000"}, &dlvSpec{mode: "exec", port: 56268, apiVersion: 1}},
+		{[]string{"dlv", "exec", "--listen=:foo", "app"}, nil},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.in[len(test.in)-1], func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, extractDlvSpec(test.in), cmp.AllowUnexported(dlvSpec{}))
+		})
+	}
+}
+
+func TestRewriteDlvCommandLine(t *testing.T) {
+	spec := newDlvSpec(56268)
+	tests := []struct {
+		description string
+		in          []string
+		trailing    []string
+		result      []string
+	}{
+		{"no arguments", []string{"app"}, nil, []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "app"}},
+		{"arguments", []string{"app", "--flag"}, nil, []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "app", "--", "--flag"}},
+		{"trailing arguments", []string{"app"}, []string{"--flag"}, []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "app", "--"}},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, rewriteDlvCommandLine(test.in, spec, test.trailing))
+		})
+	}
+}
+
+func TestDlvTransformerApply(t *testing.T) {
+	tests := []struct {
+		description   string
+		containerSpec v1.Container
+		configuration imageConfiguration
+		result        v1.Container
+		debugConfig   map[string]interface{}
+		supportImage  string
+	}{
+		{
+			description:   "empty",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{},
+			result:        v1.Container{},
+		},
+		{
+			description:   "entrypoint and cmd",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{entrypoint: []string{"/app"}, arguments: []string{"--flag"}, workingDir: "/work"},
+			result: v1.Container{
+				Command: []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "/app", "--"},
+				Args:    []string{"--flag"},
+				Ports:   []v1.ContainerPort{{Name: "dlv", ContainerPort: 56268}},
+			},
+			debugConfig:  map[string]interface{}{"runtime": "go", "dlv": int32(56268), "workingDir": "/work"},
+			supportImage: "go",
+		},
+		{
+			description:   "args only",
+			containerSpec: v1.Container{Ports: []v1.ContainerPort{{Name: "http-server", ContainerPort: 8080}}},
+			configuration: imageConfiguration{arguments: []string{"/app", "--flag"}},
+			result: v1.Container{
+				Args:  []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "/app", "--", "--flag"},
+				Ports: []v1.ContainerPort{{Name: "http-server", ContainerPort: 8080}, {Name: "dlv", ContainerPort: 56268}},
+			},
+			debugConfig:  map[string]interface{}{"runtime": "go", "dlv": int32(56268)},
+			supportImage: "go",
+		},
+		{
+			description:   "existing dlv command",
+			containerSpec: v1.Container{Command: []string{"dlv", "exec", "--headless", "--listen=:4000", "/app"}},
+			configuration: imageConfiguration{entrypoint: []string{"dlv", "exec", "--headless", "--listen=:4000", "/app"}},
+			result: v1.Container{
+				Command: []string{"dlv", "exec", "--headless", "--listen=:4000", "/app"},
+				Ports:   []v1.ContainerPort{{Name: "dlv", ContainerPort: 4000}},
+			},
+			debugConfig: map[string]interface{}{"runtime": "go", "dlv": int32(4000)},
+		},
+		{
+			description:   "existing dlv entrypoint with arguments in cmd",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{entrypoint: []string{"/go/bin/dlv"}, arguments: []string{"exec", "--headless", "--listen=:4000", "/app"}},
+			result: v1.Container{
+				Ports: []v1.ContainerPort{{Name: "dlv", ContainerPort: 4000}},
+			},
+			debugConfig: map[string]interface{}{"runtime": "go", "dlv": int32(4000)},
+		},
+	}
+	var identity portAllocator = func(port int32) int32 {
+		return port
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			debugConfig, supportImage := dlvTransformer{}.Apply(&test.containerSpec, test.configuration, identity)
+
+			t.CheckDeepEqual(test.result, test.containerSpec)
+			t.CheckDeepEqual(test.debugConfig, debugConfig)
+			t.CheckDeepEqual(test.supportImage, supportImage)
+		})
+	}
+}
+
+func TestTransformManifestGo(t *testing.T) {
+	tests := []struct {
+		description string
+		in          runtime.Object
+		transformed bool
+		out         runtime.Object
+	}{
+		{
+			"Pod with Go container",
+			&v1.Pod{
+				Spec: v1.PodSpec{Containers: []v1.Container{
+					{
+						Name:       "test",
+						Command:    []string{"/app", "--flag"},
+						WorkingDir: "/work",
+					},
+				}}},
+			true,
+			&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"debug.cloud.google.com/config": `{"test":{"dlv":56268,"runtime":"go","workingDir":"/work"}}`},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:         "test",
+							Command:      []string{"/dbg/go/bin/dlv", "exec", "--headless", "--continue", "--accept-multiclient", "--listen=:56268", "--api-version=2", "/app", "--", "--flag"},
+							WorkingDir:   "/work",
+							Ports:        []v1.ContainerPort{{Name: "dlv", ContainerPort: 56268}},
+							VolumeMounts: []v1.VolumeMount{{Name: "debugging-support-files", MountPath: "/dbg"}},
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name:         "install-go-support",
+							Image:        "gcr.io/gcp-dev-tools/duct-tape/go",
+							VolumeMounts: []v1.VolumeMount{{Name: "debugging-support-files", MountPath: "/dbg"}},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name:         "debugging-support-files",
+							VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+						},
+					},
+				}},
+		},
+		{
+			"Pod with existing dlv command",
+			&v1.Pod{
+				Spec: v1.PodSpec{Containers: []v1.Container{
+					{
+						Name:    "test",
+						Command: []string{"dlv", "exec", "--headless", "--listen=:4000", "/app"},
+					},
+				}}},
+			true,
+			&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"debug.cloud.google.com/config": `{"test":{"dlv":4000,"runtime":"go"}}`},
+				},
+				Spec: v1.PodSpec{Containers: []v1.Container{
+					{
+						Name:    "test",
+						Command: []string{"dlv", "exec", "--headless", "--listen=:4000", "/app"},
+						Ports:   []v1.ContainerPort{{Name: "dlv", ContainerPort: 4000}},
+					},
+				}}},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			value := test.in.DeepCopyObject()
+
+			retriever := func(image string) (imageConfiguration, error) {
+				return imageConfiguration{env: map[string]string{"GOTRACEBACK": "all"}}, nil
+			}
+			result := transformManifest(value, retriever)
+
+			t.CheckDeepEqual(test.transformed, result)
+			t.CheckDeepEqual(test.out, value)
+		})
+	}
+}
@@ -36,6 +36,10 @@ const (
 	defaultJdwpPort = 5005
 )
 
+func (t jdwpTransformer) Runtime() string {
+	return "jvm"
+}
+
 func (t jdwpTransformer) IsApplicable(config imageConfiguration) bool {
 	if _, found := config.env["JAVA_TOOL_OPTIONS"]; found {
 		return true
@@ -65,7 +69,7 @@ type jdwpSpec struct {
 
 // Apply configures a container definition for JVM debugging.
 // Returns a simple map describing the debug configuration details.
-func (t jdwpTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) map[string]interface{} {
+func (t jdwpTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) (map[string]interface{}, string) {
 	logrus.Infof("Configuring [%s] for JVM debugging", container.Name)
 	// try to find existing JAVA_TOOL_OPTIONS or jdwp command argument
 	// todo: find existing containerPort "jdwp" and use port. But what if it conflicts with jdwp spec?
@@ -93,7 +97,7 @@ func (t jdwpTransformer) Apply(container *v1.Container, config imageConfiguratio
 	return map[string]interface{}{
 		"runtime": "jvm",
 		"jdwp":    port,
-	}
+	}, ""
 }
 
 func retrieveJdwpSpec(config imageConfiguration) *jdwpSpec {
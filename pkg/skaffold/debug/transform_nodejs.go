@@ -55,6 +55,10 @@ func isLaunchingNpm(args []string) bool {
 	return args[0] == "npm" || strings.HasSuffix(args[0], "/npm")
 }
 
+func (t nodeTransformer) Runtime() string {
+	return "nodejs"
+}
+
 func (t nodeTransformer) IsApplicable(config imageConfiguration) bool {
 	if _, found := config.env["NODE_VERSION"]; found {
 		return true
@@ -69,7 +73,7 @@ func (t nodeTransformer) IsApplicable(config imageConfiguration) bool {
 
 // Apply configures a container definition for NodeJS Chrome V8 Inspector.
 // Returns a simple map describing the debug configuration details.
-func (t nodeTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) map[string]interface{} {
+func (t nodeTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) (map[string]interface{}, string) {
 	logrus.Infof("Configuring [%s] for node.js debugging", container.Name)
 
 	// try to find existing `--inspect` command
@@ -93,7 +97,7 @@ func (t nodeTransformer) Apply(container *v1.Container, config imageConfiguratio
 
 		default:
 			logrus.Warnf("Skipping [%s] as does not appear to invoke node", container.Name)
-			return nil
+			return nil, ""
 		}
 	}
 
@@ -106,7 +110,7 @@ func (t nodeTransformer) Apply(container *v1.Container, config imageConfiguratio
 	return map[string]interface{}{
 		"runtime":  "nodejs",
 		"devtools": spec.port,
-	}
+	}, ""
 }
 
 func retrieveNodeInspectSpec(config imageConfiguration) *inspectSpec {
@@ -0,0 +1,242 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+)
+
+type pythonTransformer struct{}
+
+func init() {
+	containerTransforms = append(containerTransforms, pythonTransformer{})
+}
+
+const (
+	// the default port for both debugpy and ptvsd
+	defaultPtvsdPort = 5678
+)
+
+// pythonLauncher matches python interpreters like `python`, `python3` and `python3.7`
+var pythonLauncher = regexp.MustCompile(`^python[0-9.]*$`)
+
+// ptvsdSpec captures the useful debugpy/ptvsd options
+type ptvsdSpec struct {
+	module string
+	host   string
+	port   int32
+}
+
+// isLaunchingPython determines if the arguments seems to be invoking the python interpreter
+func isLaunchingPython(args []string) bool {
+	return len(args) > 0 && pythonLauncher.MatchString(filepath.Base(args[0]))
+}
+
+// isLaunchingPythonModule determines if the arguments seems to be invoking a python-based
+// server script like `gunicorn` or `flask`, returning the corresponding module name
+func isLaunchingPythonModule(args []string) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+	switch base := filepath.Base(args[0]); base {
+	case "gunicorn", "flask":
+		return base, true
+	}
+	return "", false
+}
+
+func isLaunchingPythonApp(args []string) bool {
+	_, isModule := isLaunchingPythonModule(args)
+	return isModule || isLaunchingPython(args)
+}
+
+func (t pythonTransformer) Runtime() string {
+	return "python"
+}
+
+func (t pythonTransformer) IsApplicable(config imageConfiguration) bool {
+	if _, found := config.env["PYTHON_VERSION"]; found {
+		return true
+	}
+	if _, found := config.env["PYTHONPATH"]; found {
+		return true
+	}
+	if len(config.entrypoint) > 0 {
+		return isLaunchingPythonApp(config.entrypoint)
+	}
+	return isLaunchingPythonApp(config.arguments)
+}
+
+// Apply configures a container definition for Python debugging with debugpy.
+// Returns a simple map describing the debug configuration details and
+// the name of the support image providing the debugpy module.
+func (t pythonTransformer) Apply(container *v1.Container, config imageConfiguration, portAlloc portAllocator) (map[string]interface{}, string) {
+	logrus.Infof("Configuring [%s] for Python debugging", container.Name)
+
+	// try to find an existing `-m debugpy` or `-m ptvsd` command
+	spec := retrievePtvsdSpec(config)
+	supportImage := ""
+
+	if spec == nil {
+		spec = &ptvsdSpec{module: "debugpy", host: "0.0.0.0", port: portAlloc(defaultPtvsdPort)}
+		switch {
+		case len(config.entrypoint) > 0 && isLaunchingPythonApp(config.entrypoint):
+			container.Command = rewritePythonCommandLine(config.entrypoint, *spec)
+			// overriding the command causes the image's CMD to be ignored
+			if len(container.Args) == 0 {
+				container.Args = config.arguments
+			}
+
+		case len(config.entrypoint) == 0 && isLaunchingPythonApp(config.arguments):
+			container.Args = rewritePythonCommandLine(config.arguments, *spec)
+
+		default:
+			logrus.Warnf("Skipping [%s] as does not appear to invoke python", container.Name)
+			return nil, ""
+		}
+		supportImage = "python"
+		setPythonPath(container, config)
+	}
+
+	ptvsdPort := v1.ContainerPort{
+		Name:          "dap",
+		ContainerPort: spec.port,
+	}
+	container.Ports = append(container.Ports, ptvsdPort)
+
+	configuration := map[string]interface{}{
+		"runtime": "python",
+		"dap":     spec.port,
+	}
+	if config.workingDir != "" {
+		configuration["workingDir"] = config.workingDir
+	}
+	return configuration, supportImage
+}
+
+// setPythonPath makes the debugpy module installed by the support image importable
+func setPythonPath(container *v1.Container, config imageConfiguration) {
+	supportPath := debuggingSupportMountPath + "/python"
+	for i := range container.Env {
+		if container.Env[i].Name == "PYTHONPATH" {
+			container.Env[i].Value = container.Env[i].Value + ":" + supportPath
+			return
+		}
+	}
+	if existing, found := config.env["PYTHONPATH"]; found && existing != "" {
+		supportPath = existing + ":" + supportPath
+	}
+	container.Env = append(container.Env, v1.EnvVar{Name: "PYTHONPATH", Value: supportPath})
+}
+
+// retrievePtvsdSpec returns the debugger configuration if the container already launches debugpy or ptvsd
+func retrievePtvsdSpec(config imageConfiguration) *ptvsdSpec {
+	if isLaunchingPython(config.entrypoint) {
+		return extractPtvsdSpec(config.entrypoint)
+	}
+	if len(config.entrypoint) == 0 && isLaunchingPython(config.arguments) {
+		return extractPtvsdSpec(config.arguments)
+	}
+	return nil
+}
+
+// extractPtvsdSpec parses a python command-line of the form `python -m debugpy --listen host:port ...`
+// or `python -m ptvsd --host host --port port ...`
+func extractPtvsdSpec(args []string) *ptvsdSpec {
+	index := pythonOptionsEnd(args)
+	if index+1 >= len(args) || args[index] != "-m" || (args[index+1] != "debugpy" && args[index+1] != "ptvsd") {
+		return nil
+	}
+	spec := ptvsdSpec{module: args[index+1], port: defaultPtvsdPort}
+	for i := index + 2; i < len(args)-1; i++ {
+		var err error
+		switch args[i] {
+		case "--listen":
+			address := args[i+1]
+			if split := strings.SplitN(address, ":", 2); len(split) == 2 {
+				spec.host = split[0]
+				address = split[1]
+			}
+			err = spec.setPort(address)
+		case "--host":
+			spec.host = args[i+1]
+		case "--port":
+			err = spec.setPort(args[i+1])
+		case "-m", "-c":
+			// start of the program being debugged
+			return &spec
+		default:
+			continue
+		}
+		if err != nil {
+			logrus.Errorf("Invalid %s port \"%s\": %s\n", spec.module, args[i+1], err)
+			return nil
+		}
+		i++
+	}
+	return &spec
+}
+
+func (spec *ptvsdSpec) setPort(port string) error {
+	p, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return err
+	}
+	spec.port = int32(p)
+	return nil
+}
+
+// pythonOptionsEnd returns the index of the first argument following the interpreter's own options,
+// which must precede any `-m` module that is to be run
+func pythonOptionsEnd(args []string) int {
+	for i := 1; i < len(args); i++ {
+		switch {
+		case args[i] == "-m" || args[i] == "-c" || !strings.HasPrefix(args[i], "-"):
+			return i
+		case args[i] == "-W" || args[i] == "-X":
+			// these options take a value
+			i++
+		}
+	}
+	return len(args)
+}
+
+// rewritePythonCommandLine rewrites a python, gunicorn or flask command-line to run under debugpy
+func rewritePythonCommandLine(commandLine []string, spec ptvsdSpec) []string {
+	address := strconv.FormatInt(int64(spec.port), 10)
+	if spec.host != "" {
+		address = spec.host + ":" + address
+	}
+	debugger := []string{"-m", spec.module, "--listen", address}
+	if module, isModule := isLaunchingPythonModule(commandLine); isModule {
+		// run the server script as a module under the debugger
+		newCommandLine := append([]string{"python"}, debugger...)
+		newCommandLine = append(newCommandLine, "-m", module)
+		return append(newCommandLine, commandLine[1:]...)
+	}
+	// keep the interpreter's options as they must precede the debugger module
+	index := pythonOptionsEnd(commandLine)
+	newCommandLine := append([]string{}, commandLine[:index]...)
+	newCommandLine = append(newCommandLine, debugger...)
+	return append(newCommandLine, commandLine[index:]...)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPythonTransformer_IsApplicable(t *testing.T) {
+	tests := []struct {
+		description string
+		source      imageConfiguration
+		result      bool
+	}{
+		{
+			description: "PYTHON_VERSION",
+			source:      imageConfiguration{env: map[string]string{"PYTHON_VERSION": "3.7.4"}},
+			result:      true,
+		},
+		{
+			description: "PYTHONPATH",
+			source:      imageConfiguration{env: map[string]string{"PYTHONPATH": "/app"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint python",
+			source:      imageConfiguration{entrypoint: []string{"python", "app.py"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint /usr/bin/python3.7",
+			source:      imageConfiguration{entrypoint: []string{"/usr/bin/python3.7", "app.py"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint gunicorn",
+			source:      imageConfiguration{entrypoint: []string{"gunicorn", "app:app"}},
+			result:      true,
+		},
+		{
+			description: "no entrypoint, args flask",
+			source:      imageConfiguration{arguments: []string{"flask", "run"}},
+			result:      true,
+		},
+		{
+			description: "entrypoint pythonista",
+			source:      imageConfiguration{entrypoint: []string{"pythonista"}},
+			result:      false,
+		},
+		{
+			description: "entrypoint /bin/sh",
+			source:      imageConfiguration{entrypoint: []string{"/bin/sh"}, arguments: []string{"python", "app.py"}},
+			result:      false,
+		},
+		{
+			description: "nothing",
+			source:      imageConfiguration{},
+			result:      false,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, pythonTransformer{}.IsApplicable(test.source))
+		})
+	}
+}
+
+func TestExtractPtvsdSpec(t *testing.T) {
+	tests := []struct {
+		in     []string
+		result *ptvsdSpec
+	}{
+		{[]string{"python", "app.py"}, nil},
+		{[]string{"python", "-m", "flask", "run"}, nil},
+		{[]string{"python", "-m", "debugpy", "app.py"}, &ptvsdSpec{module: "debugpy", port: 5678}},
+		{[]string{"python", "-u", "-m", "debugpy", "--listen", "0.0.0.0:6000", "app.py"}, &ptvsdSpec{module: "debugpy", host: "0.0.0.0", port: 6000}},
+		{[]string{"python", "-m", "debugpy", "--listen", "6000", "-m", "app", "--listen", "7000"}, &ptvsdSpec{module: "debugpy", port: 6000}},
+		{[]string{"python3", "-m", "ptvsd", "--host", "localhost", "--port", "6000", "app.py"}, &ptvsdSpec{module: "ptvsd", host: "localhost", port: 6000}},
+		{[]string{"python", "-m", "debugpy", "--listen", "foo", "app.py"}, nil},
+	}
+	for _, test := range tests {
+		testutil.Run(t, strings.Join(test.in, " "), func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, extractPtvsdSpec(test.in), cmp.AllowUnexported(ptvsdSpec{}))
+		})
+	}
+}
+
+func TestRewritePythonCommandLine(t *testing.T) {
+	spec := ptvsdSpec{module: "debugpy", host: "0.0.0.0", port: 5678}
+	tests := []struct {
+		in     []string
+		result []string
+	}{
+		{[]string{"python", "app.py"}, []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:5678", "app.py"}},
+		{[]string{"python", "-u", "-W", "ignore", "app.py", "-v"}, []string{"python", "-u", "-W", "ignore", "-m", "debugpy", "--listen", "0.0.0.0:5678", "app.py", "-v"}},
+		{[]string{"python3", "-m", "app"}, []string{"python3", "-m", "debugpy", "--listen", "0.0.0.0:5678", "-m", "app"}},
+		{[]string{"gunicorn", "-b", ":8080", "app:app"}, []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:5678", "-m", "gunicorn", "-b", ":8080", "app:app"}},
+		{[]string{"/usr/local/bin/flask", "run"}, []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:5678", "-m", "flask", "run"}},
+	}
+	for _, test := range tests {
+		testutil.Run(t, strings.Join(test.in, " "), func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, rewritePythonCommandLine(test.in, spec))
+		})
+	}
+}
+
+func TestPythonTransformerApply(t *testing.T) {
+	tests := []struct {
+		description   string
+		containerSpec v1.Container
+		configuration imageConfiguration
+		result        v1.Container
+		supportImage  string
+	}{
+		{
+			description:   "empty",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{},
+			result:        v1.Container{},
+		},
+		{
+			description:   "entrypoint and cmd",
+			containerSpec: v1.Container{},
+			configuration: imageConfiguration{entrypoint: []string{"python"}, arguments: []string{"app.py"}, env: map[string]string{"PYTHONPATH": "/app"}},
+			result: v1.Container{
+				Command: []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:5678"},
+				Args:    []string{"app.py"},
+				Env:     []v1.EnvVar{{Name: "PYTHONPATH", Value: "/app:/dbg/python"}},
+				Ports:   []v1.ContainerPort{{Name: "dap", ContainerPort: 5678}},
+			},
+			supportImage: "python",
+		},
+		{
+			description:   "args gunicorn with PYTHONPATH in manifest",
+			containerSpec: v1.Container{Env: []v1.EnvVar{{Name: "PYTHONPATH", Value: "/src"}}},
+			configuration: imageConfiguration{arguments: []string{"gunicorn", "app:app"}},
+			result: v1.Container{
+				Args:  []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:5678", "-m", "gunicorn", "app:app"},
+				Env:   []v1.EnvVar{{Name: "PYTHONPATH", Value: "/src:/dbg/python"}},
+				Ports: []v1.ContainerPort{{Name: "dap", ContainerPort: 5678}},
+			},
+			supportImage: "python",
+		},
+		{
+			description:   "existing ptvsd",
+			containerSpec: v1.Container{Command: []string{"python", "-m", "ptvsd", "--port", "6000", "app.py"}},
+			configuration: imageConfiguration{entrypoint: []string{"python", "-m", "ptvsd", "--port", "6000", "app.py"}},
+			result: v1.Container{
+				Command: []string{"python", "-m", "ptvsd", "--port", "6000", "app.py"},
+				Ports:   []v1.ContainerPort{{Name: "dap", ContainerPort: 6000}},
+			},
+		},
+	}
+	var identity portAllocator = func(port int32) int32 {
+		return port
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			_, supportImage := pythonTransformer{}.Apply(&test.containerSpec, test.configuration, identity)
+
+			t.CheckDeepEqual(test.result, test.containerSpec)
+			t.CheckDeepEqual(test.supportImage, supportImage)
+		})
+	}
+}
+
+func TestTransformManifestPython(t *testing.T) {
+	tests := []struct {
+		description string
+		in          runtime.Object
+		transformed bool
+		out         runtime.Object
+	}{
+		{
+			"Pod with Python container",
+			&v1.Pod{
+				Spec: v1.PodSpec{Containers: []v1.Container{
+					{
+						Name:    "test",
+						Command: []string{"python", "app.py"},
+					},
+				}}},
+			true,
+			&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"debug.cloud.google.com/config": `{"test":{"dap":5678,"runtime":"python"}}`},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:         "test",
+							Command:      []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:5678", "app.py"},
+							Env:          []v1.EnvVar{{Name: "PYTHONPATH", Value: "/dbg/python"}},
+							Ports:        []v1.ContainerPort{{Name: "dap", ContainerPort: 5678}},
+							VolumeMounts: []v1.VolumeMount{{Name: "debugging-support-files", MountPath: "/dbg"}},
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name:         "install-python-support",
+							Image:        "gcr.io/gcp-dev-tools/duct-tape/python",
+							VolumeMounts: []v1.VolumeMount{{Name: "debugging-support-files", MountPath: "/dbg"}},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name:         "debugging-support-files",
+							VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+						},
+					},
+				}},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			value := test.in.DeepCopyObject()
+
+			retriever := func(image string) (imageConfiguration, error) {
+				return imageConfiguration{env: map[string]string{"PYTHON_VERSION": "3.7.4"}}, nil
+			}
+			result := transformManifest(value, retriever)
+
+			t.CheckDeepEqual(test.transformed, result)
+			t.CheckDeepEqual(test.out, value)
+		})
+	}
+}
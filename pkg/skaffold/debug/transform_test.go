@@ -132,3 +132,181 @@ func TestDescribe(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotatedRuntime(t *testing.T) {
+	tests := []struct {
+		description string
+		annotation  string
+		container   string
+		result      string
+	}{
+		{"no annotation", "", "app", ""},
+		{"single runtime", "jvm", "app", "jvm"},
+		{"container runtime", "app=nodejs, sidecar=go", "app", "nodejs"},
+		{"other container", "sidecar=go", "app", ""},
+		{"default and container runtime", "go,app=python", "app", "python"},
+		{"default for other container", "go,app=python", "sidecar", "go"},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, annotatedRuntime(test.annotation, test.container))
+		})
+	}
+}
+
+func TestTransformContainer(t *testing.T) {
+	tests := []struct {
+		description string
+		container   v1.Container
+		config      imageConfiguration
+		runtime     string
+		shouldErr   bool
+		result      v1.Container
+	}{
+		{
+			description: "unknown runtime",
+			container:   v1.Container{Command: []string{"app"}},
+			config:      imageConfiguration{},
+			shouldErr:   true,
+			result:      v1.Container{Command: []string{"app"}},
+		},
+		{
+			description: "runtime selected by annotation",
+			container:   v1.Container{Command: []string{"app"}},
+			config:      imageConfiguration{},
+			runtime:     "jvm",
+			result: v1.Container{
+				Command: []string{"app"},
+				Env:     []v1.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: "-agentlib:jdwp=transport=dt_socket,server=y,address=5005,suspend=n,quiet=y"}},
+				Ports:   []v1.ContainerPort{{Name: "jdwp", ContainerPort: 5005}},
+			},
+		},
+		{
+			description: "runtime selected by artifact",
+			container:   v1.Container{Command: []string{"app"}},
+			config:      imageConfiguration{runtimeType: "jvm", labels: map[string]string{"debug.cloud.google.com/runtime": "nodejs"}},
+			result: v1.Container{
+				Command: []string{"app"},
+				Env:     []v1.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: "-agentlib:jdwp=transport=dt_socket,server=y,address=5005,suspend=n,quiet=y"}},
+				Ports:   []v1.ContainerPort{{Name: "jdwp", ContainerPort: 5005}},
+			},
+		},
+		{
+			description: "runtime selected by image label",
+			container:   v1.Container{Command: []string{"app"}},
+			config:      imageConfiguration{labels: map[string]string{"debug.cloud.google.com/runtime": "jvm"}},
+			result: v1.Container{
+				Command: []string{"app"},
+				Env:     []v1.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: "-agentlib:jdwp=transport=dt_socket,server=y,address=5005,suspend=n,quiet=y"}},
+				Ports:   []v1.ContainerPort{{Name: "jdwp", ContainerPort: 5005}},
+			},
+		},
+		{
+			description: "invalid runtime",
+			container:   v1.Container{Command: []string{"app"}},
+			config:      imageConfiguration{},
+			runtime:     "cobol",
+			shouldErr:   true,
+			result:      v1.Container{Command: []string{"app"}},
+		},
+		{
+			description: "java in sh -c",
+			container:   v1.Container{Command: []string{"sh", "-c", "exec java -jar app.jar"}},
+			config:      imageConfiguration{},
+			result: v1.Container{
+				Command: []string{"sh", "-c", "exec java -jar app.jar"},
+				Env:     []v1.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: "-agentlib:jdwp=transport=dt_socket,server=y,address=5005,suspend=n,quiet=y"}},
+				Ports:   []v1.ContainerPort{{Name: "jdwp", ContainerPort: 5005}},
+			},
+		},
+		{
+			description: "node in sh -c from image",
+			container:   v1.Container{},
+			config:      imageConfiguration{entrypoint: []string{"/bin/sh", "-c"}, arguments: []string{"node --max-old-space-size=$HEAP \"my app.js\""}},
+			result: v1.Container{
+				Args:  []string{`node --inspect=9229 --max-old-space-size=$HEAP "my app.js"`},
+				Ports: []v1.ContainerPort{{Name: "devtools", ContainerPort: 9229}},
+			},
+		},
+		{
+			description: "node in sh -c in image entrypoint",
+			container:   v1.Container{},
+			config:      imageConfiguration{entrypoint: []string{"/bin/sh", "-c", "node app.js", "sh"}, arguments: []string{"extra"}},
+			result: v1.Container{
+				Command: []string{"/bin/sh", "-c", "node --inspect=9229 app.js", "sh"},
+				Args:    []string{"extra"},
+				Ports:   []v1.ContainerPort{{Name: "devtools", ContainerPort: 9229}},
+			},
+		},
+		{
+			description: "node with docker-entrypoint.sh",
+			container:   v1.Container{},
+			config:      imageConfiguration{entrypoint: []string{"docker-entrypoint.sh"}, arguments: []string{"node", "app.js"}, env: map[string]string{"NODE_VERSION": "10"}},
+			result: v1.Container{
+				Args:  []string{"node", "--inspect=9229", "app.js"},
+				Ports: []v1.ContainerPort{{Name: "devtools", ContainerPort: 9229}},
+			},
+		},
+		{
+			description: "complex sh -c script is not unwrapped",
+			container:   v1.Container{Command: []string{"sh", "-c", "cd /app && node app.js"}},
+			config:      imageConfiguration{},
+			shouldErr:   true,
+			result:      v1.Container{Command: []string{"sh", "-c", "cd /app && node app.js"}},
+		},
+	}
+	var identity portAllocator = func(port int32) int32 {
+		return port
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			retriever := func(string) (imageConfiguration, error) {
+				if test.config.env == nil {
+					test.config.env = map[string]string{}
+				}
+				return test.config, nil
+			}
+			_, _, err := transformContainer(&test.container, retriever, identity, test.runtime)
+
+			t.CheckError(test.shouldErr, err)
+			t.CheckDeepEqual(test.result, test.container)
+		})
+	}
+}
+
+func TestExtendProbeTimeouts(t *testing.T) {
+	tests := []struct {
+		description string
+		in          v1.Container
+		result      v1.Container
+	}{
+		{
+			description: "no probes",
+			in:          v1.Container{},
+			result:      v1.Container{},
+		},
+		{
+			description: "short probe timeouts",
+			in: v1.Container{
+				LivenessProbe:  &v1.Probe{TimeoutSeconds: 1, PeriodSeconds: 10},
+				ReadinessProbe: &v1.Probe{},
+			},
+			result: v1.Container{
+				LivenessProbe:  &v1.Probe{TimeoutSeconds: 600, PeriodSeconds: 10},
+				ReadinessProbe: &v1.Probe{TimeoutSeconds: 600},
+			},
+		},
+		{
+			description: "long probe timeout",
+			in:          v1.Container{LivenessProbe: &v1.Probe{TimeoutSeconds: 900}},
+			result:      v1.Container{LivenessProbe: &v1.Probe{TimeoutSeconds: 900}},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			extendProbeTimeouts(&test.in)
+
+			t.CheckDeepEqual(test.result, test.in)
+		})
+	}
+}
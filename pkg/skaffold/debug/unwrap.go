@@ -0,0 +1,208 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"path/filepath"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// shellMetacharacters are characters that make a `sh -c` script more than a simple command
+const shellMetacharacters = ";&|<>`()\n"
+
+// wrappedCommandLine describes a container command-line that launches the actual
+// program indirectly, through `sh -c "..."` or an entrypoint script like `docker-entrypoint.sh`.
+type wrappedCommandLine struct {
+	// commandLine is the container's entrypoint followed by its arguments
+	commandLine []string
+	// entrypointLength is the number of leading elements of commandLine that form the entrypoint
+	entrypointLength int
+	// index is the position of the wrapped program in commandLine
+	index int
+	// script is true if the wrapped program is the shell script at commandLine[index]
+	script bool
+	// exec is true if the script replaces the shell with `exec`
+	exec bool
+	// sources are the words of the script as they were written
+	sources map[string]string
+}
+
+// isShell determines if the argument seems to be a POSIX shell
+func isShell(arg string) bool {
+	switch filepath.Base(arg) {
+	case "sh", "bash", "ash", "dash":
+		return true
+	}
+	return false
+}
+
+// isEntrypointScript determines if the argument seems to be a wrapper script that
+// executes its arguments, like the `docker-entrypoint.sh` of the official language images
+func isEntrypointScript(arg string) bool {
+	base := filepath.Base(arg)
+	return base == "entrypoint.sh" || strings.HasSuffix(base, "-entrypoint.sh")
+}
+
+// unwrapCommandLine looks for a program wrapped by `sh -c` or an entrypoint script.
+// It returns the wrapper and an image configuration whose arguments are the wrapped
+// program's command-line, or `nil` if the command-line is not wrapped.
+func unwrapCommandLine(config imageConfiguration) (*wrappedCommandLine, imageConfiguration) {
+	commandLine := append(append([]string{}, config.entrypoint...), config.arguments...)
+	wrapper := wrappedCommandLine{commandLine: commandLine, entrypointLength: len(config.entrypoint)}
+
+	var program []string
+	switch {
+	case len(commandLine) > 2 && isShell(commandLine[0]) && commandLine[1] == "-c":
+		words, sources, ok := shSplit(commandLine[2])
+		if !ok || len(words) == 0 {
+			return nil, config
+		}
+		wrapper.sources = sources
+		if words[0] == "exec" {
+			words = words[1:]
+			wrapper.exec = true
+		}
+		program = words
+		wrapper.index = 2
+		wrapper.script = true
+
+	case len(config.entrypoint) > 0 && len(commandLine) > 1 && isEntrypointScript(commandLine[0]):
+		program = commandLine[1:]
+		wrapper.index = 1
+
+	default:
+		return nil, config
+	}
+	if len(program) == 0 {
+		return nil, config
+	}
+
+	unwrapped := config
+	unwrapped.entrypoint = nil
+	unwrapped.arguments = program
+	return &wrapper, unwrapped
+}
+
+// rewrap places the rewritten program command-line back within the wrapper,
+// updating the container's command and arguments. The container's command
+// is only overridden when the wrapped program is part of the entrypoint.
+func (w *wrappedCommandLine) rewrap(container *v1.Container, program []string) {
+	commandLine := append([]string{}, w.commandLine[:w.index]...)
+	if w.script {
+		script := shJoin(program, w.sources)
+		if w.exec {
+			script = "exec " + script
+		}
+		commandLine = append(commandLine, script)
+		commandLine = append(commandLine, w.commandLine[w.index+1:]...)
+	} else {
+		commandLine = append(commandLine, program...)
+	}
+
+	if w.index < w.entrypointLength {
+		container.Command = commandLine[:w.entrypointLength]
+	}
+	if len(commandLine) > w.entrypointLength {
+		container.Args = commandLine[w.entrypointLength:]
+	}
+}
+
+// shSplit splits a simple shell command into words, honouring quotes and escapes.
+// It also returns the source of each word, for the words to be quoted again as they were.
+// Returns false if the command uses shell features other than quoting.
+func shSplit(command string) ([]string, map[string]string, bool) {
+	var words []string
+	sources := map[string]string{}
+	var word strings.Builder
+	inWord := false
+	start := 0
+	var quote rune
+	escaped := false
+
+	startWord := func(i int) {
+		if !inWord {
+			start = i
+			inWord = true
+		}
+	}
+	endWord := func(end int) {
+		if _, found := sources[word.String()]; !found {
+			sources[word.String()] = command[start:end]
+		}
+		words = append(words, word.String())
+		word.Reset()
+		inWord = false
+	}
+
+	for i, c := range command {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			startWord(i)
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			startWord(i)
+			quote = c
+		case c == ' ' || c == '\t':
+			if inWord {
+				endWord(i)
+			}
+		case strings.ContainsRune(shellMetacharacters, c):
+			return nil, nil, false
+		default:
+			startWord(i)
+			word.WriteRune(c)
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, nil, false
+	}
+	if inWord {
+		endWord(len(command))
+	}
+	return words, sources, true
+}
+
+// shJoin joins words into a shell command, quoting them where required. The words that have
+// a source are written as they were, so that single-quoted `$`, backticks and `\` stay literal.
+// Other words are double-quoted so that variable references are still expanded.
+func shJoin(words []string, sources map[string]string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		if source, found := sources[word]; found {
+			quoted[i] = source
+			continue
+		}
+		if word != "" && !strings.ContainsAny(word, " \t'\"\\"+shellMetacharacters) {
+			quoted[i] = word
+			continue
+		}
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`").Replace(word)
+		quoted[i] = `"` + escaped + `"`
+	}
+	return strings.Join(quoted, " ")
+}
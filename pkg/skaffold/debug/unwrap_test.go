@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestShSplit(t *testing.T) {
+	tests := []struct {
+		in     string
+		result []string
+		ok     bool
+	}{
+		{"", nil, true},
+		{"java -jar app.jar", []string{"java", "-jar", "app.jar"}, true},
+		{"  node\t app.js ", []string{"node", "app.js"}, true},
+		{`node "my app.js" 'it''s' a\ b ""`, []string{"node", "my app.js", "its", "a b", ""}, true},
+		{`echo "a \"quoted\" word"`, []string{"echo", `a "quoted" word`}, true},
+		{"java -Dport=$PORT -jar app.jar", []string{"java", "-Dport=$PORT", "-jar", "app.jar"}, true},
+		{"echo 'a && b'", []string{"echo", "a && b"}, true},
+		{"cd /app && node app.js", nil, false},
+		{"node app.js | tee log", nil, false},
+		{"node $(cat args)", nil, false},
+		{`node "app.js`, nil, false},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.in, func(t *testutil.T) {
+			result, _, ok := shSplit(test.in)
+
+			t.CheckDeepEqual(test.ok, ok)
+			t.CheckDeepEqual(test.result, result)
+		})
+	}
+}
+
+func TestShJoin(t *testing.T) {
+	tests := []struct {
+		in     []string
+		result string
+	}{
+		{[]string{"java", "-jar", "app.jar"}, "java -jar app.jar"},
+		{[]string{"node", "my app.js", ""}, `node "my app.js" ""`},
+		{[]string{"echo", `a "quoted" \word`}, `echo "a \"quoted\" \\word"`},
+		{[]string{"java", "-Dport=$PORT"}, "java -Dport=$PORT"},
+		{[]string{"echo", "a;b"}, `echo "a;b"`},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.result, func(t *testutil.T) {
+			t.CheckDeepEqual(test.result, shJoin(test.in, nil))
+
+			split, _, _ := shSplit(test.result)
+			t.CheckDeepEqual(test.in, split)
+		})
+	}
+}
+
+func TestShJoinKeepsQuoting(t *testing.T) {
+	tests := []struct {
+		description string
+		script      string
+		result      string
+	}{
+		{"single-quoted variable", `echo '$HOME'`, `echo '$HOME' --flag`},
+		{"escaped variable", `echo \$HOME`, `echo \$HOME --flag`},
+		{"double-quoted variable", `echo "$HOME"`, `echo "$HOME" --flag`},
+		{"single-quoted backslash and backtick", "echo 'a\\b `c`'", "echo 'a\\b `c`' --flag"},
+		{"mixed quotes", `echo '$A'"$B"`, `echo '$A'"$B" --flag`},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			words, sources, ok := shSplit(test.script)
+			t.CheckDeepEqual(true, ok)
+
+			t.CheckDeepEqual(test.result, shJoin(append(words, "--flag"), sources))
+		})
+	}
+}
+
+func TestUnwrapCommandLine(t *testing.T) {
+	tests := []struct {
+		description string
+		config      imageConfiguration
+		wrapped     bool
+		program     []string
+	}{
+		{"not wrapped", imageConfiguration{entrypoint: []string{"java", "-jar", "app.jar"}}, false, nil},
+		{"sh without -c", imageConfiguration{entrypoint: []string{"sh", "start.sh"}}, false, nil},
+		{"sh -c", imageConfiguration{entrypoint: []string{"sh", "-c", "java -jar app.jar"}}, true, []string{"java", "-jar", "app.jar"}},
+		{"bash -c in arguments", imageConfiguration{arguments: []string{"/bin/bash", "-c", "exec node app.js"}}, true, []string{"node", "app.js"}},
+		{"sh -c split across entrypoint and arguments", imageConfiguration{entrypoint: []string{"sh", "-c"}, arguments: []string{"node app.js"}}, true, []string{"node", "app.js"}},
+		{"complex script", imageConfiguration{entrypoint: []string{"sh", "-c", "npm install; npm start"}}, false, nil},
+		{"docker-entrypoint.sh", imageConfiguration{entrypoint: []string{"docker-entrypoint.sh"}, arguments: []string{"node", "app.js"}}, true, []string{"node", "app.js"}},
+		{"entrypoint script without arguments", imageConfiguration{entrypoint: []string{"/usr/local/bin/app-entrypoint.sh"}}, false, nil},
+		{"entrypoint script only in arguments", imageConfiguration{arguments: []string{"entrypoint.sh", "node", "app.js"}}, false, nil},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			wrapper, unwrapped := unwrapCommandLine(test.config)
+
+			t.CheckDeepEqual(test.wrapped, wrapper != nil)
+			if test.wrapped {
+				t.CheckDeepEqual([]string(nil), unwrapped.entrypoint)
+				t.CheckDeepEqual(test.program, unwrapped.arguments)
+			} else {
+				t.CheckDeepEqual(test.config, unwrapped, cmp.AllowUnexported(imageConfiguration{}))
+			}
+		})
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	tests := []struct {
+		description string
+		container   v1.Container
+		config      imageConfiguration
+		program     []string
+		result      v1.Container
+	}{
+		{
+			description: "sh -c in command",
+			container:   v1.Container{Command: []string{"sh", "-c", "exec node app.js"}},
+			config:      imageConfiguration{entrypoint: []string{"sh", "-c", "exec node app.js"}},
+			program:     []string{"node", "--inspect", "app.js"},
+			result:      v1.Container{Command: []string{"sh", "-c", "exec node --inspect app.js"}},
+		},
+		{
+			description: "sh -c in args",
+			container:   v1.Container{Args: []string{"sh", "-c", "node app.js"}},
+			config:      imageConfiguration{arguments: []string{"sh", "-c", "node app.js"}},
+			program:     []string{"node", "--inspect", "app.js"},
+			result:      v1.Container{Args: []string{"sh", "-c", "node --inspect app.js"}},
+		},
+		{
+			description: "entrypoint script keeps command",
+			container:   v1.Container{Command: []string{"docker-entrypoint.sh"}},
+			config:      imageConfiguration{entrypoint: []string{"docker-entrypoint.sh"}, arguments: []string{"node", "app.js"}},
+			program:     []string{"node", "--inspect", "app.js"},
+			result:      v1.Container{Command: []string{"docker-entrypoint.sh"}, Args: []string{"node", "--inspect", "app.js"}},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			wrapper, _ := unwrapCommandLine(test.config)
+			wrapper.rewrap(&test.container, test.program)
+
+			t.CheckDeepEqual(test.result, test.container)
+		})
+	}
+}
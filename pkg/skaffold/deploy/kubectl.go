@@ -38,6 +38,7 @@ type KubectlDeployer struct {
 
 	workingDir         string
 	kubectl            kubectl.CLI
+	artifacts          []*latest.Artifact
 	defaultRepo        string
 	insecureRegistries map[string]bool
 }
@@ -54,6 +55,7 @@ func NewKubectlDeployer(runCtx *runcontext.RunContext) *KubectlDeployer {
 			Flags:       runCtx.Cfg.Deploy.KubectlDeploy.Flags,
 			ForceDeploy: runCtx.Opts.ForceDeploy(),
 		},
+		artifacts:          runCtx.Cfg.Build.Artifacts,
 		defaultRepo:        runCtx.DefaultRepo,
 		insecureRegistries: runCtx.InsecureRegistries,
 	}
@@ -65,7 +67,7 @@ func (k *KubectlDeployer) Labels() map[string]string {
 	}
 }
 
-type ManifestTransform func(l kubectl.ManifestList, builds []build.Artifact, insecureRegistries map[string]bool) (kubectl.ManifestList, error)
+type ManifestTransform func(l kubectl.ManifestList, builds []build.Artifact, artifacts []*latest.Artifact, insecureRegistries map[string]bool) (kubectl.ManifestList, error)
 
 // Transforms are applied to manifests
 var manifestTransforms []ManifestTransform
@@ -108,7 +110,7 @@ func (k *KubectlDeployer) Deploy(ctx context.Context, out io.Writer, builds []bu
 	}
 
 	for _, transform := range manifestTransforms {
-		manifests, err = transform(manifests, builds, k.insecureRegistries)
+		manifests, err = transform(manifests, builds, k.artifacts, k.insecureRegistries)
 		if err != nil {
 			return errors.Wrap(err, "unable to transform manifests")
 		}
@@ -64,6 +64,7 @@ type KustomizeDeployer struct {
 	*latest.KustomizeDeploy
 
 	kubectl            kubectl.CLI
+	artifacts          []*latest.Artifact
 	defaultRepo        string
 	insecureRegistries map[string]bool
 }
@@ -77,6 +78,7 @@ func NewKustomizeDeployer(runCtx *runcontext.RunContext) *KustomizeDeployer {
 			Flags:       runCtx.Cfg.Deploy.KustomizeDeploy.Flags,
 			ForceDeploy: runCtx.Opts.ForceDeploy(),
 		},
+		artifacts:          runCtx.Cfg.Build.Artifacts,
 		defaultRepo:        runCtx.DefaultRepo,
 		insecureRegistries: runCtx.InsecureRegistries,
 	}
@@ -121,7 +123,7 @@ func (k *KustomizeDeployer) Deploy(ctx context.Context, out io.Writer, builds []
 	}
 
 	for _, transform := range manifestTransforms {
-		manifests, err = transform(manifests, builds, k.insecureRegistries)
+		manifests, err = transform(manifests, builds, k.artifacts, k.insecureRegistries)
 		if err != nil {
 			return errors.Wrap(err, "unable to transform manifests")
 		}
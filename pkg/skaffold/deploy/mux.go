@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+)
+
+// DeployerMux forwards every call to a list of deployers, in order.
+// It's used when configurations with different deployers are merged.
+type DeployerMux []Deployer
+
+func (m DeployerMux) Labels() map[string]string {
+	labellers := make([]Labeller, len(m))
+	for i, d := range m {
+		labellers[i] = d
+	}
+	return merge(labellers...)
+}
+
+func (m DeployerMux) Deploy(ctx context.Context, out io.Writer, builds []build.Artifact, labellers []Labeller) error {
+	for _, d := range m {
+		if err := d.Deploy(ctx, out, builds, labellers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m DeployerMux) Dependencies() ([]string, error) {
+	seen := map[string]bool{}
+	var deps []string
+	for _, d := range m {
+		result, err := d.Dependencies()
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range result {
+			if !seen[dep] {
+				seen[dep] = true
+				deps = append(deps, dep)
+			}
+		}
+	}
+	return deps, nil
+}
+
+func (m DeployerMux) Cleanup(ctx context.Context, out io.Writer) error {
+	for _, d := range m {
+		if err := d.Cleanup(ctx, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
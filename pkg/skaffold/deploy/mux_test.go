@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+type fakeDeployer struct {
+	name      string
+	deps      []string
+	deployErr error
+	calls     *[]string
+}
+
+func (f *fakeDeployer) Labels() map[string]string {
+	return map[string]string{"skaffold.dev/" + f.name: "true"}
+}
+
+func (f *fakeDeployer) Deploy(context.Context, io.Writer, []build.Artifact, []Labeller) error {
+	*f.calls = append(*f.calls, "deploy "+f.name)
+	return f.deployErr
+}
+
+func (f *fakeDeployer) Dependencies() ([]string, error) {
+	return f.deps, nil
+}
+
+func (f *fakeDeployer) Cleanup(context.Context, io.Writer) error {
+	*f.calls = append(*f.calls, "cleanup "+f.name)
+	return nil
+}
+
+func TestDeployerMux(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		var calls []string
+		mux := DeployerMux{
+			&fakeDeployer{name: "helm", deps: []string{"chart/values.yaml", "k8s/shared.yaml"}, calls: &calls},
+			&fakeDeployer{name: "kubectl", deps: []string{"k8s/shared.yaml", "k8s/app.yaml"}, calls: &calls},
+		}
+
+		deps, err := mux.Dependencies()
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]string{"chart/values.yaml", "k8s/shared.yaml", "k8s/app.yaml"}, deps)
+		t.CheckDeepEqual(map[string]string{"skaffold.dev/helm": "true", "skaffold.dev/kubectl": "true"}, mux.Labels())
+
+		t.CheckNoError(mux.Deploy(context.Background(), ioutil.Discard, nil, nil))
+		t.CheckNoError(mux.Cleanup(context.Background(), ioutil.Discard))
+		t.CheckDeepEqual([]string{"deploy helm", "deploy kubectl", "cleanup helm", "cleanup kubectl"}, calls)
+	})
+}
+
+func TestDeployerMuxStopsOnError(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		var calls []string
+		mux := DeployerMux{
+			&fakeDeployer{name: "helm", deployErr: errors.New("failed"), calls: &calls},
+			&fakeDeployer{name: "kubectl", calls: &calls},
+		}
+
+		err := mux.Deploy(context.Background(), ioutil.Discard, nil, nil)
+
+		t.CheckError(true, err)
+		t.CheckDeepEqual([]string{"deploy helm"}, calls)
+	})
+}
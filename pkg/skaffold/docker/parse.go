@@ -22,6 +22,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -364,3 +365,69 @@ func resolveDir(cwd, targetDir string) string {
 	}
 	return path.Clean(path.Join(cwd, targetDir))
 }
+
+// ExposedPort is a port exposed by a Dockerfile.
+type ExposedPort struct {
+	Port     int32
+	Protocol string
+}
+
+// ExposedPorts lists the ports exposed with `EXPOSE` by the last stage of a Dockerfile.
+// Ports exposed by the base image and port ranges are ignored.
+func ExposedPorts(dockerfilePath string) ([]ExposedPort, error) {
+	f, err := os.Open(dockerfilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening dockerfile: %s", dockerfilePath)
+	}
+	defer f.Close()
+
+	res, err := parser.Parse(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing dockerfile %s", dockerfilePath)
+	}
+
+	slex := shell.NewLex('\\')
+	var ports []ExposedPort
+	var envs []string
+	for _, node := range res.AST.Children {
+		switch node.Value {
+		case command.From:
+			ports, envs = nil, nil
+		case command.Env:
+			for node := node.Next; node != nil && node.Next != nil; node = node.Next.Next {
+				envs = append(envs, fmt.Sprintf("%s=%s", node.Value, node.Next.Value))
+			}
+		case command.Expose:
+			for node := node.Next; node != nil; node = node.Next {
+				value, err := slex.ProcessWord(node.Value, envs)
+				if err != nil {
+					return nil, errors.Wrap(err, "processing word")
+				}
+
+				port, protocol := value, "TCP"
+				if i := strings.Index(value, "/"); i >= 0 {
+					port, protocol = value[:i], strings.ToUpper(value[i+1:])
+				}
+				number, err := strconv.ParseInt(port, 10, 32)
+				if err != nil {
+					logrus.Debugf("ignoring exposed port %s of %s", value, dockerfilePath)
+					continue
+				}
+				exposed := ExposedPort{Port: int32(number), Protocol: protocol}
+				if !containsPort(ports, exposed) {
+					ports = append(ports, exposed)
+				}
+			}
+		}
+	}
+	return ports, nil
+}
+
+func containsPort(ports []ExposedPort, port ExposedPort) bool {
+	for _, p := range ports {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestExposedPorts(t *testing.T) {
+	tests := []struct {
+		description string
+		dockerfile  string
+		expected    []ExposedPort
+	}{
+		{
+			description: "no port",
+			dockerfile:  "FROM scratch",
+		},
+		{
+			description: "ports and protocols",
+			dockerfile: `FROM scratch
+ENV PORT=8080
+EXPOSE $PORT 53/udp
+EXPOSE 9000-9010 9090/tcp`,
+			expected: []ExposedPort{
+				{Port: 8080, Protocol: "TCP"},
+				{Port: 53, Protocol: "UDP"},
+				{Port: 9090, Protocol: "TCP"},
+			},
+		},
+		{
+			description: "last stage only",
+			dockerfile: `FROM golang AS builder
+EXPOSE 1234
+FROM scratch
+EXPOSE 80`,
+			expected: []ExposedPort{{Port: 80, Protocol: "TCP"}},
+		},
+		{
+			description: "environment of the last stage only",
+			dockerfile: `FROM golang AS builder
+ENV PORT=1234
+FROM scratch
+EXPOSE $PORT 80`,
+			expected: []ExposedPort{{Port: 80, Protocol: "TCP"}},
+		},
+		{
+			description: "duplicate ports",
+			dockerfile: `FROM scratch
+EXPOSE 80 80/tcp 80/udp
+EXPOSE 80`,
+			expected: []ExposedPort{
+				{Port: 80, Protocol: "TCP"},
+				{Port: 80, Protocol: "UDP"},
+			},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			tmpDir := t.NewTempDir().Write("Dockerfile", test.dockerfile)
+
+			ports, err := ExposedPorts(tmpDir.Path("Dockerfile"))
+
+			t.CheckErrorAndDeepEqual(false, err, test.expected, ports)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
@@ -33,6 +34,10 @@ const (
 	InProgress = "In Progress"
 	Complete   = "Complete"
 	Failed     = "Failed"
+	Started    = "Started"
+	Terminated = "Terminated"
+	Hit        = "Hit"
+	Miss       = "Miss"
 )
 
 var (
@@ -48,6 +53,9 @@ type eventHandler struct {
 	stateLock sync.Mutex
 
 	listeners []listener
+
+	// inFlight counts the events that are being handled asynchronously
+	inFlight sync.WaitGroup
 }
 
 type listener struct {
@@ -141,7 +149,21 @@ func emptyState(build *latest.BuildConfig) proto.State {
 		DeployState: &proto.DeployState{
 			Status: NotStarted,
 		},
-		ForwardedPorts: make(map[string]*proto.PortEvent),
+		TestState: &proto.TestState{
+			Artifacts: map[string]string{},
+		},
+		FileSyncState: &proto.FileSyncState{
+			Artifacts: map[string]string{},
+		},
+		CacheState: &proto.CacheState{
+			Artifacts: map[string]string{},
+		},
+		ConfigReloadState: &proto.ConfigReloadState{
+			Status: NotStarted,
+		},
+		ForwardedPorts:      make(map[string]*proto.PortEvent),
+		DebuggingContainers: make(map[string]*proto.DebuggingContainerEvent),
+		FileChanges:         make(map[string]*proto.FileChangeEvent),
 	}
 }
 
@@ -200,8 +222,170 @@ func PortForwarded(localPort, remotePort int32, podName, containerName, namespac
 	})
 }
 
+// DebuggingContainerStarted notifies that a container configured for debugging has started.
+func DebuggingContainerStarted(podName, containerName, namespace, runtime, workingDir, debugPortName string, debugPort int32) {
+	handler.handleDebuggingContainerEvent(&proto.DebuggingContainerEvent{
+		Status:        Started,
+		PodName:       podName,
+		ContainerName: containerName,
+		Namespace:     namespace,
+		Runtime:       runtime,
+		WorkingDir:    workingDir,
+		DebugPortName: debugPortName,
+		DebugPort:     debugPort,
+	})
+}
+
+// DebuggingContainerTerminated notifies that a container configured for debugging has terminated.
+func DebuggingContainerTerminated(podName, containerName, namespace, runtime, workingDir, debugPortName string, debugPort int32) {
+	handler.handleDebuggingContainerEvent(&proto.DebuggingContainerEvent{
+		Status:        Terminated,
+		PodName:       podName,
+		ContainerName: containerName,
+		Namespace:     namespace,
+		Runtime:       runtime,
+		WorkingDir:    workingDir,
+		DebugPortName: debugPortName,
+		DebugPort:     debugPort,
+	})
+}
+
+// DevLoopIterationStarted notifies that the dev loop starts applying a new set of changes.
+// The events that follow are numbered with this iteration, the first run being iteration 0.
+func DevLoopIterationStarted() {
+	handler.stateLock.Lock()
+	handler.state.DevLoopIteration++
+	handler.stateLock.Unlock()
+}
+
+// TestInProgress notifies that the tests of an artifact have been started.
+func TestInProgress(imageName string) {
+	handler.handleTestEvent(&proto.TestEvent{Artifact: imageName, Status: InProgress})
+}
+
+// TestFailed notifies that the tests of an artifact have failed.
+func TestFailed(imageName string, err error, duration time.Duration) {
+	handler.handleTestEvent(&proto.TestEvent{Artifact: imageName, Status: Failed, Err: err.Error(), DurationMs: milliseconds(duration)})
+}
+
+// TestComplete notifies that the tests of an artifact have passed.
+func TestComplete(imageName string, duration time.Duration) {
+	handler.handleTestEvent(&proto.TestEvent{Artifact: imageName, Status: Complete, DurationMs: milliseconds(duration)})
+}
+
+// FileSyncInProgress notifies that files are being synced to the containers of an artifact.
+func FileSyncInProgress(imageName string, fileCount int) {
+	handler.handleFileSyncEvent(&proto.FileSyncEvent{Artifact: imageName, Status: InProgress, FileCount: int32(fileCount)})
+}
+
+// FileSyncFailed notifies that files could not be synced.
+func FileSyncFailed(imageName string, fileCount int, err error, duration time.Duration) {
+	handler.handleFileSyncEvent(&proto.FileSyncEvent{Artifact: imageName, Status: Failed, FileCount: int32(fileCount), Err: err.Error(), DurationMs: milliseconds(duration)})
+}
+
+// FileSyncComplete notifies that files have been synced.
+func FileSyncComplete(imageName string, fileCount int, duration time.Duration) {
+	handler.handleFileSyncEvent(&proto.FileSyncEvent{Artifact: imageName, Status: Complete, FileCount: int32(fileCount), DurationMs: milliseconds(duration)})
+}
+
+// FilesChanged notifies that the files of a watched component have changed.
+func FilesChanged(component string, added, modified, deleted []string) {
+	handler.handleFileChangeEvent(&proto.FileChangeEvent{Component: component, Added: added, Modified: modified, Deleted: deleted})
+}
+
+// CacheHit notifies that an artifact was found in the cache and doesn't need to be built.
+func CacheHit(imageName, tag string, duration time.Duration) {
+	handler.handleCacheEvent(&proto.CacheEvent{Artifact: imageName, Status: Hit, Tag: tag, DurationMs: milliseconds(duration)})
+}
+
+// CacheMiss notifies that an artifact was not found in the cache and needs to be built.
+func CacheMiss(imageName string, duration time.Duration) {
+	handler.handleCacheEvent(&proto.CacheEvent{Artifact: imageName, Status: Miss, DurationMs: milliseconds(duration)})
+}
+
+// ConfigReloadInProgress notifies that the skaffold configuration is being reloaded.
+func ConfigReloadInProgress() {
+	handler.handleConfigReloadEvent(&proto.ConfigReloadEvent{Status: InProgress})
+}
+
+// ConfigReloadFailed notifies that the skaffold configuration could not be reloaded.
+func ConfigReloadFailed(err error, duration time.Duration) {
+	handler.handleConfigReloadEvent(&proto.ConfigReloadEvent{Status: Failed, Err: err.Error(), DurationMs: milliseconds(duration)})
+}
+
+// ConfigReloadComplete notifies that the skaffold configuration has been reloaded.
+func ConfigReloadComplete(duration time.Duration) {
+	handler.handleConfigReloadEvent(&proto.ConfigReloadEvent{Status: Complete, DurationMs: milliseconds(duration)})
+}
+
+func milliseconds(duration time.Duration) int64 {
+	return int64(duration / time.Millisecond)
+}
+
+// iteration returns the current dev loop iteration. It is read when an event
+// is created because events are handled asynchronously.
+func (ev *eventHandler) iteration() int32 {
+	ev.stateLock.Lock()
+	defer ev.stateLock.Unlock()
+
+	return ev.state.DevLoopIteration
+}
+
+func (ev *eventHandler) handleTestEvent(e *proto.TestEvent) {
+	e.Iteration = ev.iteration()
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_TestEvent{
+			TestEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleFileSyncEvent(e *proto.FileSyncEvent) {
+	e.Iteration = ev.iteration()
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_FileSyncEvent{
+			FileSyncEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleFileChangeEvent(e *proto.FileChangeEvent) {
+	e.Iteration = ev.iteration()
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_FileChangeEvent{
+			FileChangeEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleCacheEvent(e *proto.CacheEvent) {
+	e.Iteration = ev.iteration()
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_CacheEvent{
+			CacheEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleConfigReloadEvent(e *proto.ConfigReloadEvent) {
+	e.Iteration = ev.iteration()
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_ConfigReloadEvent{
+			ConfigReloadEvent: e,
+		},
+	})
+}
+
+func (ev *eventHandler) handleDebuggingContainerEvent(e *proto.DebuggingContainerEvent) {
+	ev.handleAsync(&proto.Event{
+		EventType: &proto.Event_DebuggingContainerEvent{
+			DebuggingContainerEvent: e,
+		},
+	})
+}
+
 func (ev *eventHandler) handleDeployEvent(e *proto.DeployEvent) {
-	go ev.handle(&proto.Event{
+	ev.handleAsync(&proto.Event{
 		EventType: &proto.Event_DeployEvent{
 			DeployEvent: e,
 		},
@@ -209,7 +393,7 @@ func (ev *eventHandler) handleDeployEvent(e *proto.DeployEvent) {
 }
 
 func (ev *eventHandler) handleBuildEvent(e *proto.BuildEvent) {
-	go ev.handle(&proto.Event{
+	ev.handleAsync(&proto.Event{
 		EventType: &proto.Event_BuildEvent{
 			BuildEvent: e,
 		},
@@ -229,13 +413,30 @@ func LogSkaffoldMetadata(info *version.Info) {
 	})
 }
 
+// handleAsync handles the event in a new goroutine. Use `wait` to make sure it's been logged.
+func (ev *eventHandler) handleAsync(event *proto.Event) {
+	ev.inFlight.Add(1)
+	go func() {
+		defer ev.inFlight.Done()
+		ev.handle(event)
+	}()
+}
+
+// wait waits for the events being handled asynchronously to be logged.
+func (ev *eventHandler) wait() {
+	ev.inFlight.Wait()
+}
+
 func (ev *eventHandler) handle(event *proto.Event) {
-	logEntry := &proto.LogEntry{
+	ev.handleLogEntry(&proto.LogEntry{
 		Timestamp: ptypes.TimestampNow(),
 		Event:     event,
-	}
+	})
+}
 
-	switch e := event.GetEventType().(type) {
+// handleLogEntry updates the state with the entry's event and logs the entry.
+func (ev *eventHandler) handleLogEntry(logEntry *proto.LogEntry) {
+	switch e := logEntry.Event.GetEventType().(type) {
 	case *proto.Event_BuildEvent:
 		be := e.BuildEvent
 		ev.stateLock.Lock()
@@ -270,8 +471,97 @@ func (ev *eventHandler) handle(event *proto.Event) {
 		pe := e.PortEvent
 		ev.stateLock.Lock()
 		ev.state.ForwardedPorts[pe.ContainerName] = pe
+		for _, dc := range ev.state.DebuggingContainers {
+			if dc.Namespace == pe.Namespace && dc.PodName == pe.PodName && dc.ContainerName == pe.ContainerName && dc.DebugPortName == pe.PortName {
+				dc.LocalPort = pe.LocalPort
+			}
+		}
 		ev.stateLock.Unlock()
 		logEntry.Entry = fmt.Sprintf("Forwarding container %s to local port %d", pe.ContainerName, pe.LocalPort)
+	case *proto.Event_DebuggingContainerEvent:
+		de := e.DebuggingContainerEvent
+		key := fmt.Sprintf("%s/%s/%s", de.Namespace, de.PodName, de.ContainerName)
+		ev.stateLock.Lock()
+		switch de.Status {
+		case Started:
+			dc := *de
+			for _, pe := range ev.state.ForwardedPorts {
+				if pe.Namespace == dc.Namespace && pe.PodName == dc.PodName && pe.ContainerName == dc.ContainerName && pe.PortName == dc.DebugPortName {
+					dc.LocalPort = pe.LocalPort
+				}
+			}
+			ev.state.DebuggingContainers[key] = &dc
+		case Terminated:
+			delete(ev.state.DebuggingContainers, key)
+		}
+		ev.stateLock.Unlock()
+		switch de.Status {
+		case Started:
+			logEntry.Entry = fmt.Sprintf("Debuggable container started %s/%s (%s)", de.PodName, de.ContainerName, de.Runtime)
+		case Terminated:
+			logEntry.Entry = fmt.Sprintf("Debuggable container terminated %s/%s (%s)", de.PodName, de.ContainerName, de.Runtime)
+		default:
+		}
+	case *proto.Event_TestEvent:
+		te := e.TestEvent
+		ev.stateLock.Lock()
+		ev.state.TestState.Artifacts[te.Artifact] = te.Status
+		ev.stateLock.Unlock()
+		switch te.Status {
+		case InProgress:
+			logEntry.Entry = fmt.Sprintf("Tests started for artifact %s", te.Artifact)
+		case Complete:
+			logEntry.Entry = fmt.Sprintf("Tests passed for artifact %s", te.Artifact)
+		case Failed:
+			logEntry.Entry = fmt.Sprintf("Tests failed for artifact %s", te.Artifact)
+		default:
+		}
+	case *proto.Event_FileSyncEvent:
+		fe := e.FileSyncEvent
+		ev.stateLock.Lock()
+		ev.state.FileSyncState.Artifacts[fe.Artifact] = fe.Status
+		ev.stateLock.Unlock()
+		switch fe.Status {
+		case InProgress:
+			logEntry.Entry = fmt.Sprintf("File sync started for %d files for %s", fe.FileCount, fe.Artifact)
+		case Complete:
+			logEntry.Entry = fmt.Sprintf("File sync succeeded for %d files for %s", fe.FileCount, fe.Artifact)
+		case Failed:
+			logEntry.Entry = fmt.Sprintf("File sync failed for %d files for %s", fe.FileCount, fe.Artifact)
+		default:
+		}
+	case *proto.Event_FileChangeEvent:
+		fe := e.FileChangeEvent
+		ev.stateLock.Lock()
+		ev.state.FileChanges[fe.Component] = fe
+		ev.stateLock.Unlock()
+		logEntry.Entry = fmt.Sprintf("Files changed for %s: %d added, %d modified, %d deleted", fe.Component, len(fe.Added), len(fe.Modified), len(fe.Deleted))
+	case *proto.Event_CacheEvent:
+		ce := e.CacheEvent
+		ev.stateLock.Lock()
+		ev.state.CacheState.Artifacts[ce.Artifact] = ce.Status
+		ev.stateLock.Unlock()
+		switch ce.Status {
+		case Hit:
+			logEntry.Entry = fmt.Sprintf("Found artifact %s in cache", ce.Artifact)
+		case Miss:
+			logEntry.Entry = fmt.Sprintf("Artifact %s not found in cache", ce.Artifact)
+		default:
+		}
+	case *proto.Event_ConfigReloadEvent:
+		ce := e.ConfigReloadEvent
+		ev.stateLock.Lock()
+		ev.state.ConfigReloadState.Status = ce.Status
+		ev.stateLock.Unlock()
+		switch ce.Status {
+		case InProgress:
+			logEntry.Entry = "Configuration reload started"
+		case Complete:
+			logEntry.Entry = "Configuration reloaded"
+		case Failed:
+			logEntry.Entry = "Configuration reload failed"
+		default:
+		}
 	default:
 		return
 	}
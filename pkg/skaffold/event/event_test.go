@@ -182,3 +182,129 @@ func wait(t *testing.T, condition func() bool) {
 		}
 	}
 }
+
+func TestDebuggingContainer(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	found := func() bool {
+		return handler.getState().DebuggingContainers["ns/pod/container"] != nil
+	}
+
+	wait(t, func() bool { return !found() })
+	DebuggingContainerStarted("pod", "container", "ns", "go", "/app", "dlv", 56268)
+	wait(t, found)
+	DebuggingContainerTerminated("pod", "container", "ns", "go", "/app", "dlv", 56268)
+	wait(t, func() bool { return !found() })
+}
+
+func TestDebuggingContainerLocalPort(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	started := &proto.Event{EventType: &proto.Event_DebuggingContainerEvent{DebuggingContainerEvent: &proto.DebuggingContainerEvent{
+		Status: Started, PodName: "pod", ContainerName: "container", Namespace: "ns", Runtime: "go", DebugPortName: "dlv", DebugPort: 56268,
+	}}}
+	forwarded := &proto.Event{EventType: &proto.Event_PortEvent{PortEvent: &proto.PortEvent{
+		LocalPort: 56269, RemotePort: 56268, PodName: "pod", ContainerName: "container", Namespace: "ns", PortName: "dlv",
+	}}}
+
+	handler.handle(started)
+	testutil.CheckDeepEqual(t, int32(0), handler.getState().DebuggingContainers["ns/pod/container"].LocalPort)
+
+	handler.handle(forwarded)
+	testutil.CheckDeepEqual(t, int32(56269), handler.getState().DebuggingContainers["ns/pod/container"].LocalPort)
+
+	// a container restarting after its port is already forwarded
+	handler.state = emptyState(nil)
+	handler.handle(forwarded)
+	handler.handle(started)
+	testutil.CheckDeepEqual(t, int32(56269), handler.getState().DebuggingContainers["ns/pod/container"].LocalPort)
+}
+
+func TestTestEvents(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	wait(t, func() bool { return handler.getState().TestState.Artifacts["img"] == "" })
+	TestInProgress("img")
+	wait(t, func() bool { return handler.getState().TestState.Artifacts["img"] == InProgress })
+	TestFailed("img", errors.New("BUG"), time.Second)
+	wait(t, func() bool { return handler.getState().TestState.Artifacts["img"] == Failed })
+	TestComplete("img", time.Second)
+	wait(t, func() bool { return handler.getState().TestState.Artifacts["img"] == Complete })
+}
+
+func TestFileSyncEvents(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	wait(t, func() bool { return handler.getState().FileSyncState.Artifacts["img"] == "" })
+	FileSyncInProgress("img", 2)
+	wait(t, func() bool { return handler.getState().FileSyncState.Artifacts["img"] == InProgress })
+	FileSyncFailed("img", 2, errors.New("BUG"), time.Second)
+	wait(t, func() bool { return handler.getState().FileSyncState.Artifacts["img"] == Failed })
+	FileSyncComplete("img", 2, time.Second)
+	wait(t, func() bool { return handler.getState().FileSyncState.Artifacts["img"] == Complete })
+}
+
+func TestCacheEvents(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	CacheHit("img1", "img1:hash", time.Second)
+	CacheMiss("img2", time.Second)
+	wait(t, func() bool { return handler.getState().CacheState.Artifacts["img1"] == Hit })
+	wait(t, func() bool { return handler.getState().CacheState.Artifacts["img2"] == Miss })
+}
+
+func TestConfigReloadEvents(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	wait(t, func() bool { return handler.getState().ConfigReloadState.Status == NotStarted })
+	ConfigReloadInProgress()
+	wait(t, func() bool { return handler.getState().ConfigReloadState.Status == InProgress })
+	ConfigReloadFailed(errors.New("BUG"), time.Second)
+	wait(t, func() bool { return handler.getState().ConfigReloadState.Status == Failed })
+	ConfigReloadComplete(time.Second)
+	wait(t, func() bool { return handler.getState().ConfigReloadState.Status == Complete })
+}
+
+func TestDevLoopIteration(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	FilesChanged("img", []string{"added"}, nil, nil)
+	wait(t, func() bool { return handler.getState().FileChanges["img"] != nil })
+	testutil.CheckDeepEqual(t, int32(0), handler.getState().FileChanges["img"].Iteration)
+
+	DevLoopIterationStarted()
+	FilesChanged("img", nil, []string{"modified"}, nil)
+	wait(t, func() bool { return len(handler.getState().FileChanges["img"].GetModified()) == 1 })
+
+	state := handler.getState()
+	testutil.CheckDeepEqual(t, int32(1), state.DevLoopIteration)
+	testutil.CheckDeepEqual(t, int32(1), state.FileChanges["img"].Iteration)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server/proto"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// maxLogEntrySize is the maximum size of a saved event, in bytes
+const maxLogEntrySize = 1024 * 1024
+
+var saveOnce sync.Once
+
+// SaveEventsToFile writes each event, starting with those already logged, as a line of JSON
+// to the given file. The events are only saved once, even if the runner is created again.
+// The returned function waits for the pending events to be saved and closes the file.
+func SaveEventsToFile(path string) (func() error, error) {
+	closeFile := func() error { return nil }
+	var err error
+	saveOnce.Do(func() {
+		closeFile, err = handler.saveEventsToFile(path)
+	})
+	return closeFile, err
+}
+
+func (ev *eventHandler) saveEventsToFile(path string) (func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating event log file")
+	}
+
+	closed := false
+	marshaler := jsonpb.Marshaler{}
+	ev.addListener(func(entry *proto.LogEntry) error {
+		if closed {
+			return nil
+		}
+		// The file is not buffered so that the events are saved even if skaffold is killed.
+		if err := writeLogEntry(f, marshaler, entry); err != nil {
+			logrus.Warnf("Unable to save event to %s: %s", path, err)
+		}
+		return nil
+	})
+
+	return func() error {
+		ev.wait()
+
+		// The listeners are called with the lock held.
+		ev.logLock.Lock()
+		defer ev.logLock.Unlock()
+
+		if closed {
+			return nil
+		}
+		closed = true
+		return errors.Wrap(f.Close(), "closing event log file")
+	}, nil
+}
+
+// addListener calls the callback for the events already logged, then for each new event.
+// Unlike `forEachEvent`, it doesn't wait for the callback to fail.
+func (ev *eventHandler) addListener(callback func(*proto.LogEntry) error) {
+	ev.logLock.Lock()
+	defer ev.logLock.Unlock()
+
+	for i := range ev.eventLog {
+		callback(&ev.eventLog[i])
+	}
+	ev.listeners = append(ev.listeners, listener{
+		callback: callback,
+		errors:   make(chan error, 1),
+	})
+}
+
+func writeLogEntry(out io.Writer, marshaler jsonpb.Marshaler, entry *proto.LogEntry) error {
+	line, err := marshaler.MarshalToString(entry)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, line)
+	return err
+}
+
+// Replay reads events saved by SaveEventsToFile and logs them again with their original
+// timestamps, updating the state as they are replayed. It returns the number of events read.
+func Replay(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLogEntrySize)
+
+	count := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry proto.LogEntry
+		if err := jsonpb.UnmarshalString(line, &entry); err != nil {
+			return count, errors.Wrapf(err, "reading event %d", count+1)
+		}
+		handler.replay(&entry)
+		count++
+	}
+
+	return count, errors.Wrap(scanner.Err(), "reading events")
+}
+
+func (ev *eventHandler) replay(entry *proto.LogEntry) {
+	switch entry.Event.GetEventType().(type) {
+	case nil, *proto.Event_MetaEvent:
+		// these events don't change the state
+		ev.logEvent(*entry)
+	default:
+		ev.handleLogEntry(entry)
+	}
+}
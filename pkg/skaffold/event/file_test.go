@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server/proto"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestSaveAndReplayEvents(t *testing.T) {
+	defer func() { handler = nil }()
+
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+	handler.logEvent(proto.LogEntry{Entry: "before saving"})
+
+	closeFile, err := handler.saveEventsToFile(tmpDir.Path("events.json"))
+	testutil.CheckError(t, false, err)
+	defer closeFile()
+
+	handler.handle(&proto.Event{EventType: &proto.Event_BuildEvent{BuildEvent: &proto.BuildEvent{Artifact: "img", Status: Complete}}})
+	handler.handle(&proto.Event{EventType: &proto.Event_DeployEvent{DeployEvent: &proto.DeployEvent{Status: Failed, Err: "BUG"}}})
+	saved := handler.eventLog
+
+	// Replay into a new event log
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+	f, err := os.Open(tmpDir.Path("events.json"))
+	testutil.CheckError(t, false, err)
+	defer f.Close()
+
+	count, err := Replay(f)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, 3, count)
+	testutil.CheckDeepEqual(t, saved, handler.eventLog)
+	testutil.CheckDeepEqual(t, Complete, handler.getState().BuildState.Artifacts["img"])
+	testutil.CheckDeepEqual(t, Failed, handler.getState().DeployState.Status)
+}
+
+func TestSaveEventsBeforeShutdown(t *testing.T) {
+	defer func() { handler = nil }()
+
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	closeFile, err := handler.saveEventsToFile(tmpDir.Path("events.json"))
+	testutil.CheckError(t, false, err)
+
+	handler.handleBuildEvent(&proto.BuildEvent{Artifact: "img", Status: Complete})
+	err = closeFile()
+	testutil.CheckError(t, false, err)
+
+	// Events logged after the file is closed are not saved
+	handler.handle(&proto.Event{EventType: &proto.Event_DeployEvent{DeployEvent: &proto.DeployEvent{Status: Complete}}})
+
+	content, err := ioutil.ReadFile(tmpDir.Path("events.json"))
+	testutil.CheckError(t, false, err)
+	testutil.CheckDeepEqual(t, 1, strings.Count(string(content), "\n"))
+	testutil.CheckContains(t, "Build completed for artifact img", string(content))
+}
+
+func TestReplayInvalidEvent(t *testing.T) {
+	defer func() { handler = nil }()
+
+	handler = &eventHandler{
+		state: emptyState(nil),
+	}
+
+	count, err := Replay(strings.NewReader("{\"entry\":\"valid\"}\n\nnot json\n"))
+
+	testutil.CheckErrorAndDeepEqual(t, true, err, 1, count)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/helm"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kustomize"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// analysis is what `skaffold init --analyze` prints.
+type analysis struct {
+	Dockerfiles []string           `json:"dockerfiles,omitempty" yaml:"dockerfiles,omitempty"`
+	Builders    []analyzedBuilder  `json:"builders,omitempty" yaml:"builders,omitempty"`
+	Deployers   []analyzedDeployer `json:"deployers,omitempty" yaml:"deployers,omitempty"`
+	Images      []string           `json:"images,omitempty" yaml:"images,omitempty"`
+}
+
+type analyzedBuilder struct {
+	Name        string `json:"name" yaml:"name,omitempty"`
+	Path        string `json:"path" yaml:"path,omitempty"`
+	Description string `json:"description" yaml:"description,omitempty"`
+	Image       string `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+type analyzedDeployer struct {
+	Name        string   `json:"name" yaml:"name,omitempty"`
+	Description string   `json:"description" yaml:"description,omitempty"`
+	Paths       []string `json:"paths" yaml:"paths,omitempty"`
+}
+
+// answers replace the prompts of `skaffold init`. They are the output of `--analyze`,
+// edited to set the image of each builder to use and to keep a single deployer.
+// Builders are found by description or, if it is not set, by path.
+type answers struct {
+	analysis `yaml:",inline"`
+
+	TagPolicy *latest.TagPolicy `yaml:"tagPolicy,omitempty"`
+	Profiles  []latest.Profile  `yaml:"profiles,omitempty"`
+}
+
+// readAnswers reads a JSON or YAML answers file, or stdin if the path is `-`.
+func readAnswers(path string) (*answers, error) {
+	var buf []byte
+	var err error
+	if path == "-" {
+		buf, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		buf, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading answers")
+	}
+
+	a := &answers{}
+	if err := yaml.UnmarshalStrict(buf, a); err != nil {
+		return nil, errors.Wrap(err, "parsing answers")
+	}
+	return a, nil
+}
+
+// resolveBuilders pairs the builders that have an image with the builders found in the sources.
+func (a *answers) resolveBuilders(builders []InitBuilder) ([]builderImagePair, error) {
+	var pairs []builderImagePair
+
+	for _, answer := range a.Builders {
+		if answer.Image == "" {
+			continue
+		}
+
+		builder, err := findBuilder(answer, builders)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, builderImagePair{Builder: builder, ImageName: answer.Image})
+	}
+	return pairs, nil
+}
+
+func findBuilder(answer analyzedBuilder, builders []InitBuilder) (InitBuilder, error) {
+	for _, b := range builders {
+		if answer.Description != "" && answer.Description == b.Describe() {
+			return b, nil
+		}
+		if answer.Description == "" && answer.Path != "" && filepath.Clean(answer.Path) == filepath.Clean(b.Path()) {
+			return b, nil
+		}
+	}
+
+	// like with --artifact, a Dockerfile that was not found while walking the sources is still used as is.
+	if (answer.Name == "" || answer.Name == "Docker") && answer.Path != "" {
+		return dockerBuilder{path: answer.Path}, nil
+	}
+	return nil, fmt.Errorf("no builder found for image %s: %s", answer.Image, describeAnswer(answer))
+}
+
+func describeAnswer(answer analyzedBuilder) string {
+	if answer.Description != "" {
+		return answer.Description
+	}
+	return fmt.Sprintf("%s (%s)", answer.Name, answer.Path)
+}
+
+// resolveDeployer returns the deployer selected by the answers or, if none is, the only deployer
+// found in the sources. It returns nil if the sources have nothing to deploy.
+func (a *answers) resolveDeployer(candidates []deployer) (Initializer, error) {
+	switch {
+	case len(a.Deployers) > 1:
+		return nil, errors.New("the answers must select a single deployer")
+	case len(a.Deployers) == 0 && len(candidates) > 1:
+		return nil, errors.New("the answers must select one of the deployers found in the sources")
+	case len(a.Deployers) == 0 && len(candidates) == 1:
+		return candidates[0].initializer, nil
+	case len(a.Deployers) == 0:
+		return nil, nil
+	}
+
+	answer := a.Deployers[0]
+	if len(answer.Paths) == 0 {
+		return nil, fmt.Errorf("the %s deployer must have one or more paths", answer.Name)
+	}
+
+	switch answer.Name {
+	case "kubectl":
+		return kubectl.New(answer.Paths)
+	case "helm":
+		return helm.New(answer.Paths)
+	case "kustomize":
+		if len(answer.Paths) != 1 {
+			return nil, errors.New("the kustomize deployer must have a single path")
+		}
+		return kustomize.New(answer.Paths[0])
+	default:
+		return nil, fmt.Errorf("unknown deployer %s, should be kubectl, helm or kustomize", answer.Name)
+	}
+}
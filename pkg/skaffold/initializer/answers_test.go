@@ -0,0 +1,245 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestReadAnswers(t *testing.T) {
+	tests := []struct {
+		description string
+		answers     string
+		expected    *answers
+		shouldErr   bool
+	}{
+		{
+			description: "json",
+			answers:     `{"builders":[{"name":"Docker","path":"Dockerfile","image":"web"}],"deployers":[{"name":"kubectl","paths":["k8s/web.yaml"]}],"tagPolicy":{"sha256":{}}}`,
+			expected: &answers{
+				analysis: analysis{
+					Builders:  []analyzedBuilder{{Name: "Docker", Path: "Dockerfile", Image: "web"}},
+					Deployers: []analyzedDeployer{{Name: "kubectl", Paths: []string{"k8s/web.yaml"}}},
+				},
+				TagPolicy: &latest.TagPolicy{ShaTagger: &latest.ShaTagger{}},
+			},
+		},
+		{
+			description: "yaml",
+			answers: `builders:
+- path: Dockerfile
+  image: web
+profiles:
+- name: dev
+`,
+			expected: &answers{
+				analysis: analysis{Builders: []analyzedBuilder{{Path: "Dockerfile", Image: "web"}}},
+				Profiles: []latest.Profile{{Name: "dev"}},
+			},
+		},
+		{
+			description: "unknown field",
+			answers:     `{"builder":[]}`,
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			tmpDir := t.NewTempDir().Write("answers", test.answers)
+
+			a, err := readAnswers(tmpDir.Path("answers"))
+
+			t.CheckErrorAndDeepEqual(test.shouldErr, err, test.expected, a, cmp.AllowUnexported(answers{}))
+		})
+	}
+}
+
+func TestResolveBuildersFromAnswers(t *testing.T) {
+	builders := []InitBuilder{
+		dockerBuilder{path: "Dockerfile"},
+		bazelBuilder{path: "BUILD", workspace: ".", target: "//:app.tar"},
+		bazelBuilder{path: "BUILD", workspace: ".", target: "//:worker.tar"},
+	}
+
+	tests := []struct {
+		description string
+		builders    []analyzedBuilder
+		expected    []builderImagePair
+		shouldErr   bool
+	}{
+		{
+			description: "by description or path",
+			builders: []analyzedBuilder{
+				{Name: "Bazel", Path: "BUILD", Description: "Bazel (//:worker.tar)", Image: "worker"},
+				{Path: "./Dockerfile", Image: "web"},
+				{Name: "Bazel", Path: "BUILD", Description: "Bazel (//:app.tar)"},
+			},
+			expected: []builderImagePair{
+				{Builder: bazelBuilder{path: "BUILD", workspace: ".", target: "//:worker.tar"}, ImageName: "worker"},
+				{Builder: dockerBuilder{path: "Dockerfile"}, ImageName: "web"},
+			},
+		},
+		{
+			description: "dockerfile not found while walking",
+			builders:    []analyzedBuilder{{Name: "Docker", Path: "web/Dockerfile.web", Image: "web"}},
+			expected:    []builderImagePair{{Builder: dockerBuilder{path: "web/Dockerfile.web"}, ImageName: "web"}},
+		},
+		{
+			description: "unknown builder",
+			builders:    []analyzedBuilder{{Name: "Jib Maven Plugin", Path: "pom.xml", Image: "java"}},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			a := &answers{analysis: analysis{Builders: test.builders}}
+
+			pairs, err := a.resolveBuilders(builders)
+
+			t.CheckErrorAndDeepEqual(test.shouldErr, err, test.expected, pairs, cmp.AllowUnexported(dockerBuilder{}, bazelBuilder{}))
+		})
+	}
+}
+
+func TestResolveDeployerFromAnswers(t *testing.T) {
+	one := []deployer{{name: "kubectl", initializer: fakeInitializer{"web"}}}
+	two := append(one, deployer{name: "helm", initializer: fakeInitializer{"web"}})
+
+	tests := []struct {
+		description string
+		deployers   []analyzedDeployer
+		candidates  []deployer
+		expected    Initializer
+		shouldErr   string
+	}{
+		{
+			description: "only candidate",
+			candidates:  one,
+			expected:    fakeInitializer{"web"},
+		},
+		{
+			description: "nothing to deploy",
+		},
+		{
+			description: "several candidates",
+			candidates:  two,
+			shouldErr:   "the answers must select one of the deployers",
+		},
+		{
+			description: "several deployers",
+			deployers:   []analyzedDeployer{{Name: "kubectl", Paths: []string{"a.yaml"}}, {Name: "helm", Paths: []string{"chart"}}},
+			shouldErr:   "the answers must select a single deployer",
+		},
+		{
+			description: "no paths",
+			deployers:   []analyzedDeployer{{Name: "helm"}},
+			shouldErr:   "the helm deployer must have one or more paths",
+		},
+		{
+			description: "several kustomizations",
+			deployers:   []analyzedDeployer{{Name: "kustomize", Paths: []string{"dev", "prod"}}},
+			shouldErr:   "the kustomize deployer must have a single path",
+		},
+		{
+			description: "unknown deployer",
+			deployers:   []analyzedDeployer{{Name: "kompose", Paths: []string{"docker-compose.yaml"}}},
+			shouldErr:   "unknown deployer kompose",
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			a := &answers{analysis: analysis{Deployers: test.deployers}}
+
+			k, err := a.resolveDeployer(test.candidates)
+
+			if test.shouldErr != "" {
+				t.CheckErrorContains(test.shouldErr, err)
+				return
+			}
+			t.CheckErrorAndDeepEqual(false, err, test.expected, k)
+		})
+	}
+}
+
+func TestDoInitWithAnalyzedAnswers(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("web/Dockerfile", "FROM nginx").
+			Write("worker/Dockerfile", "FROM busybox").
+			Write("k8s/web.yaml", `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  containers:
+  - name: web
+    image: gcr.io/project/web
+`).
+			Write("k8s/worker.yaml", `apiVersion: v1
+kind: Pod
+metadata:
+  name: worker
+spec:
+  containers:
+  - name: worker
+    image: gcr.io/project/worker
+`)
+		t.Chdir(tmpDir.Root())
+
+		// analyze
+		var out bytes.Buffer
+		err := DoInit(&out, Config{Analyze: true, Opts: &config.SkaffoldOptions{}})
+		t.CheckNoError(err)
+
+		// edit
+		var a answers
+		t.CheckNoError(json.Unmarshal(out.Bytes(), &a.analysis))
+		t.CheckDeepEqual([]analyzedDeployer{{
+			Name:        "kubectl",
+			Description: "kubectl (raw kubernetes manifests)",
+			Paths:       []string{"k8s/web.yaml", "k8s/worker.yaml"},
+		}}, a.Deployers)
+		a.Builders[0].Image = "gcr.io/project/web"
+		a.Deployers[0].Paths = []string{"k8s/web.yaml"}
+		a.TagPolicy = &latest.TagPolicy{ShaTagger: &latest.ShaTagger{}}
+		buf, err := yaml.Marshal(a)
+		t.CheckNoError(err)
+		tmpDir.Write("answers.yaml", string(buf))
+
+		// init
+		out.Reset()
+		err = DoInit(&out, Config{Answers: "answers.yaml", Opts: &config.SkaffoldOptions{ConfigurationFile: "skaffold.yaml"}})
+		t.CheckNoError(err)
+
+		content, err := ioutil.ReadFile("skaffold.yaml")
+		t.CheckNoError(err)
+		var cfg latest.SkaffoldConfig
+		t.CheckNoError(yaml.Unmarshal(content, &cfg))
+		t.CheckDeepEqual([]*latest.Artifact{{ImageName: "gcr.io/project/web", Workspace: "web"}}, cfg.Build.Artifacts)
+		t.CheckDeepEqual(latest.TagPolicy{ShaTagger: &latest.ShaTagger{}}, cfg.Build.TagPolicy)
+		t.CheckDeepEqual([]string{"k8s/web.yaml"}, cfg.Deploy.KubectlDeploy.Manifests)
+	})
+}
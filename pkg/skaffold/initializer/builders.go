@@ -0,0 +1,263 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/sirupsen/logrus"
+)
+
+// InitBuilder is a build definition found in the sources, that can build one image.
+type InitBuilder interface {
+	// Name returns the name of the builder, for example `Jib Maven Plugin`.
+	Name() string
+	// Describe returns the text offered to the user when choosing a builder for an image.
+	Describe() string
+	// Path returns the path to the build definition.
+	Path() string
+	// ConfiguredImage returns the image that the build definition builds, if it names one.
+	ConfiguredImage() string
+	// CreateArtifact creates the artifact that builds the given image.
+	CreateArtifact(image string) *latest.Artifact
+}
+
+var (
+	jibMavenImage     = regexp.MustCompile(`(?s)<to>\s*<image>([^<]+)</image>`)
+	jibGradleImage    = regexp.MustCompile(`(?s)to\s*(?:\{[^}]*\bimage|\.image)\s*=\s*['"]([^'"]+)['"]`)
+	bazelImageTargets = regexp.MustCompile(`(?s)\b(?:container|[a-z0-9]+)_image\s*\(\s*name\s*=\s*"([^"]+)"`)
+)
+
+// detectBuilders returns the build definitions found in a file.
+func detectBuilders(root, path string, validateDockerfile func(string) bool) []InitBuilder {
+	switch filepath.Base(path) {
+	case "pom.xml":
+		if content, found := readIfContains(path, "jib-maven-plugin"); found {
+			return []InitBuilder{jibBuilder{name: "Jib Maven Plugin", path: path, image: firstSubmatch(jibMavenImage, content)}}
+		}
+		return nil
+	case "build.gradle", "build.gradle.kts":
+		if content, found := readIfContains(path, "com.google.cloud.tools.jib"); found {
+			return []InitBuilder{jibBuilder{name: "Jib Gradle Plugin", path: path, image: firstSubmatch(jibGradleImage, content)}}
+		}
+		return nil
+	case "BUILD", "BUILD.bazel":
+		return bazelBuilders(root, path)
+	case "package.json", "go.mod":
+		if _, err := os.Stat(filepath.Join(filepath.Dir(path), customBuildScript)); err == nil {
+			return []InitBuilder{customBuilder{path: path}}
+		}
+		return nil
+	}
+
+	if validateDockerfile(path) {
+		logrus.Infof("existing dockerfile found: %s", path)
+		return []InitBuilder{dockerBuilder{path: path}}
+	}
+	return nil
+}
+
+// withoutRedundantCustomBuilders drops the `package.json` and `go.mod` projects
+// that are already built by another builder, or by a previous custom builder.
+func withoutRedundantCustomBuilders(builders []InitBuilder) []InitBuilder {
+	built := map[string]bool{}
+	for _, b := range builders {
+		if _, custom := b.(customBuilder); !custom {
+			built[filepath.Dir(b.Path())] = true
+		}
+	}
+
+	var filtered []InitBuilder
+	for _, b := range builders {
+		if _, custom := b.(customBuilder); custom {
+			dir := filepath.Dir(b.Path())
+			if built[dir] {
+				continue
+			}
+			built[dir] = true
+		}
+		filtered = append(filtered, b)
+	}
+	return filtered
+}
+
+// dockerBuilder builds an image from a Dockerfile.
+type dockerBuilder struct {
+	path string
+}
+
+func (b dockerBuilder) Name() string            { return "Docker" }
+func (b dockerBuilder) Describe() string        { return fmt.Sprintf("Docker (%s)", b.path) }
+func (b dockerBuilder) Path() string            { return b.path }
+func (b dockerBuilder) ConfiguredImage() string { return "" }
+
+func (b dockerBuilder) CreateArtifact(image string) *latest.Artifact {
+	a := newArtifact(image, b.path)
+	if dockerfile := filepath.Base(b.path); dockerfile != constants.DefaultDockerfilePath {
+		a.DockerArtifact = &latest.DockerArtifact{DockerfilePath: dockerfile}
+	}
+	return a
+}
+
+// jibBuilder builds an image from a Maven or Gradle project that uses the Jib plugin.
+type jibBuilder struct {
+	name  string
+	path  string
+	image string
+}
+
+func (b jibBuilder) Name() string            { return b.name }
+func (b jibBuilder) Describe() string        { return fmt.Sprintf("%s (%s)", b.name, b.path) }
+func (b jibBuilder) Path() string            { return b.path }
+func (b jibBuilder) ConfiguredImage() string { return b.image }
+
+func (b jibBuilder) CreateArtifact(image string) *latest.Artifact {
+	a := newArtifact(image, b.path)
+	if strings.HasPrefix(filepath.Base(b.path), "build.gradle") {
+		a.JibGradleArtifact = &latest.JibGradleArtifact{}
+	} else {
+		a.JibMavenArtifact = &latest.JibMavenArtifact{}
+	}
+	return a
+}
+
+// bazelBuilder builds an image from a container target of a Bazel workspace.
+type bazelBuilder struct {
+	path      string
+	workspace string
+	target    string
+}
+
+func (b bazelBuilder) Name() string            { return "Bazel" }
+func (b bazelBuilder) Describe() string        { return fmt.Sprintf("Bazel (%s)", b.target) }
+func (b bazelBuilder) Path() string            { return b.path }
+func (b bazelBuilder) ConfiguredImage() string { return "" }
+
+func (b bazelBuilder) CreateArtifact(image string) *latest.Artifact {
+	a := &latest.Artifact{ImageName: image}
+	if b.workspace != "." {
+		a.Workspace = b.workspace
+	}
+	a.BazelArtifact = &latest.BazelArtifact{BuildTarget: b.target}
+	return a
+}
+
+// bazelBuilders lists the container targets of a BUILD file, in the Bazel workspace it belongs to.
+func bazelBuilders(root, path string) []InitBuilder {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		logrus.Warnf("reading file %s: %s", path, err)
+		return nil
+	}
+
+	workspace, found := findBazelWorkspace(root, filepath.Dir(path))
+	if !found {
+		logrus.Debugf("%s is not part of a Bazel workspace", path)
+		return nil
+	}
+	pkg, err := filepath.Rel(workspace, filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+	pkg = strings.TrimPrefix(filepath.ToSlash(pkg), ".")
+
+	var builders []InitBuilder
+	for _, match := range bazelImageTargets.FindAllStringSubmatch(string(content), -1) {
+		builders = append(builders, bazelBuilder{
+			path:      path,
+			workspace: workspace,
+			target:    fmt.Sprintf("//%s:%s.tar", pkg, match[1]),
+		})
+	}
+	return builders
+}
+
+// findBazelWorkspace looks for the closest directory with a WORKSPACE file, up to the root directory.
+func findBazelWorkspace(root, dir string) (string, bool) {
+	root = filepath.Clean(root)
+	for {
+		for _, file := range []string{"WORKSPACE", "WORKSPACE.bazel"} {
+			if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+				return dir, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if dir == root || parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// customBuildScript is the script that builds the projects that skaffold doesn't build natively.
+const customBuildScript = "build.sh"
+
+// customBuilder builds an image with a build script, for projects that skaffold doesn't build natively.
+type customBuilder struct {
+	path string
+}
+
+func (b customBuilder) Name() string            { return "Custom" }
+func (b customBuilder) Describe() string        { return fmt.Sprintf("Custom build script (%s)", b.path) }
+func (b customBuilder) Path() string            { return b.path }
+func (b customBuilder) ConfiguredImage() string { return "" }
+
+func (b customBuilder) CreateArtifact(image string) *latest.Artifact {
+	dependencies := &latest.CustomDependencies{Paths: []string{"."}}
+	if filepath.Base(b.path) == "package.json" {
+		dependencies.Ignore = []string{"node_modules"}
+	}
+
+	a := newArtifact(image, b.path)
+	a.CustomArtifact = &latest.CustomArtifact{
+		BuildCommand: "./" + customBuildScript,
+		Dependencies: dependencies,
+	}
+	return a
+}
+
+// newArtifact creates an artifact whose workspace is the directory of a build definition.
+func newArtifact(image, path string) *latest.Artifact {
+	a := &latest.Artifact{ImageName: image}
+	if workspace := filepath.Dir(path); workspace != "." {
+		a.Workspace = workspace
+	}
+	return a
+}
+
+func readIfContains(path, text string) (string, bool) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		logrus.Warnf("reading file %s: %s", path, err)
+		return "", false
+	}
+	return string(content), strings.Contains(string(content), text)
+}
+
+func firstSubmatch(re *regexp.Regexp, content string) string {
+	if match := re.FindStringSubmatch(content); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
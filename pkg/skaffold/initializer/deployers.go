@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/helm"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kustomize"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// deployer is a way to deploy the sources, offered to the user.
+type deployer struct {
+	name        string
+	description string
+	paths       []string
+	initializer Initializer
+}
+
+// deployers lists the ways to deploy the kubernetes manifests, helm charts and kustomizations found in the sources.
+func deployers(potentialConfigs, charts, kustomizations []string) ([]deployer, error) {
+	var found []deployer
+
+	if len(potentialConfigs) > 0 {
+		if k, err := kubectl.New(potentialConfigs); err == nil {
+			found = append(found, deployer{
+				name:        "kubectl",
+				description: "kubectl (raw kubernetes manifests)",
+				paths:       k.GenerateDeployConfig().KubectlDeploy.Manifests,
+				initializer: k,
+			})
+		} else {
+			logrus.Infof("no valid kubernetes manifest: %s", err)
+		}
+	}
+
+	if len(charts) > 0 {
+		if h, err := helm.New(charts); err == nil {
+			var paths []string
+			for _, release := range h.GenerateDeployConfig().HelmDeploy.Releases {
+				paths = append(paths, release.ChartPath)
+			}
+			found = append(found, deployer{
+				name:        "helm",
+				description: fmt.Sprintf("helm (%s)", strings.Join(paths, ", ")),
+				paths:       paths,
+				initializer: h,
+			})
+		} else {
+			logrus.Infof("no valid helm chart: %s", err)
+		}
+	}
+
+	for _, overlay := range kustomize.Overlays(kustomizations) {
+		k, err := kustomize.New(overlay)
+		if err != nil {
+			logrus.Infof("invalid kustomization: %s", err)
+			continue
+		}
+		found = append(found, deployer{
+			name:        "kustomize",
+			description: fmt.Sprintf("kustomize (%s)", overlay),
+			paths:       []string{overlay},
+			initializer: k,
+		})
+	}
+
+	if len(found) == 0 {
+		return nil, errors.New("one or more valid kubernetes manifests, helm charts or kustomizations is required to run skaffold")
+	}
+	return found, nil
+}
+
+// allImages lists the images of every deployer, for the user to know everything that can be built.
+func allImages(deployers []deployer) []string {
+	var images []string
+	seen := map[string]bool{}
+	for _, d := range deployers {
+		for _, image := range d.initializer.GetImages() {
+			if !seen[image] {
+				seen[image] = true
+				images = append(images, image)
+			}
+		}
+	}
+	return images
+}
+
+// chooseDeployer prompts the user for the deployer to use, when several are possible.
+func chooseDeployer(deployers []deployer) Initializer {
+	if len(deployers) == 1 {
+		return deployers[0].initializer
+	}
+
+	var options []string
+	for _, d := range deployers {
+		options = append(options, d.description)
+	}
+
+	var selected string
+	prompt := &survey.Select{
+		Message:  "Choose the deployer",
+		Options:  options,
+		PageSize: 15,
+	}
+	survey.AskOne(prompt, &selected, nil)
+
+	for _, d := range deployers {
+		if d.description == selected {
+			return d.initializer
+		}
+	}
+	return deployers[0].initializer
+}
+
+// removeUnbuiltHelmValues removes the helm values that reference images which are not built,
+// since skaffold can only set the values of the images it builds.
+func removeUnbuiltHelmValues(deploy *latest.DeployConfig, artifacts []*latest.Artifact) {
+	if deploy.HelmDeploy == nil {
+		return
+	}
+
+	built := map[string]bool{}
+	for _, a := range artifacts {
+		built[a.ImageName] = true
+	}
+
+	for i := range deploy.HelmDeploy.Releases {
+		release := &deploy.HelmDeploy.Releases[i]
+		for key, image := range release.Values {
+			if !built[image] {
+				delete(release.Values, key)
+			}
+		}
+		if len(release.Values) == 0 {
+			release.Values = nil
+			release.ImageStrategy = latest.HelmImageStrategy{}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestDeployers(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("pod.yaml", `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  containers:
+  - name: app
+    image: app
+`).
+			Write("chart/Chart.yaml", "name: chart").
+			Write("chart/values.yaml", "image: chart").
+			Write("base/kustomization.yaml", "resources:\n- ../pod.yaml").
+			Write("dev/kustomization.yaml", "bases:\n- ../base")
+		t.Chdir(tmpDir.Root())
+
+		found, err := deployers([]string{"pod.yaml"}, []string{"chart"}, []string{"base", "dev"})
+
+		t.CheckNoError(err)
+		var descriptions []string
+		for _, d := range found {
+			descriptions = append(descriptions, d.description)
+		}
+		t.CheckDeepEqual([]string{"kubectl (raw kubernetes manifests)", "helm (chart)", "kustomize (dev)"}, descriptions)
+		t.CheckDeepEqual([]string{"app", "chart"}, allImages(found))
+	})
+}
+
+func TestDeployersNone(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().Write("invalid.yaml", "not: kubernetes")
+		t.Chdir(tmpDir.Root())
+
+		_, err := deployers([]string{"invalid.yaml"}, nil, nil)
+
+		t.CheckErrorContains("one or more valid kubernetes manifests, helm charts or kustomizations is required", err)
+	})
+}
+
+func TestRemoveUnbuiltHelmValues(t *testing.T) {
+	deploy := latest.DeployConfig{
+		DeployType: latest.DeployType{
+			HelmDeploy: &latest.HelmDeploy{
+				Releases: []latest.HelmRelease{
+					{Name: "web", Values: map[string]string{"image": "web", "redis.image": "redis"}},
+					{Name: "redis", Values: map[string]string{"image": "redis"}, ImageStrategy: latest.HelmImageStrategy{HelmImageConfig: latest.HelmImageConfig{
+						HelmConventionConfig: &latest.HelmConventionConfig{},
+					}}},
+				},
+			},
+		},
+	}
+
+	removeUnbuiltHelmValues(&deploy, []*latest.Artifact{{ImageName: "web"}})
+
+	testutil.CheckDeepEqual(t, []latest.HelmRelease{
+		{Name: "web", Values: map[string]string{"image": "web"}},
+		{Name: "redis"},
+	}, deploy.HelmDeploy.Releases)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ChartFile is the file that makes a directory a Helm chart.
+const ChartFile = "Chart.yaml"
+
+// Helm holds parameters to deploy charts with helm.
+type Helm struct {
+	releases []latest.HelmRelease
+	images   []string
+}
+
+// imageValue is a value of a chart that sets an image.
+type imageValue struct {
+	key   string
+	image string
+	// convention is true for values split into `repository` and `tag`.
+	convention bool
+}
+
+// New returns a Helm skaffold generator, with one release per chart.
+func New(charts []string) (*Helm, error) {
+	var releases []latest.HelmRelease
+	var images []string
+	seen := map[string]bool{}
+
+	for _, chart := range charts {
+		name, err := chartName(chart)
+		if err != nil {
+			logrus.Infof("invalid helm chart %s: %s", chart, err)
+			continue
+		}
+		logrus.Infof("found valid helm chart: %s", chart)
+
+		values, err := parseImageValues(filepath.Join(chart, "values.yaml"))
+		if err != nil {
+			logrus.Infof("invalid values of helm chart %s: %s", chart, err)
+		}
+
+		release := latest.HelmRelease{
+			Name:      name,
+			ChartPath: chart,
+		}
+		for _, v := range values {
+			if release.Values == nil {
+				release.Values = map[string]string{}
+			}
+			release.Values[v.key] = v.image
+			if v.convention {
+				release.ImageStrategy.HelmConventionConfig = &latest.HelmConventionConfig{}
+			}
+			if !seen[v.image] {
+				seen[v.image] = true
+				images = append(images, v.image)
+			}
+		}
+		releases = append(releases, release)
+	}
+	if len(releases) == 0 {
+		return nil, errors.New("one or more valid helm charts is required to deploy with helm")
+	}
+
+	return &Helm{
+		releases: releases,
+		images:   images,
+	}, nil
+}
+
+// GenerateDeployConfig implements the Initializer interface and generates
+// skaffold helm deployment config.
+func (h *Helm) GenerateDeployConfig() latest.DeployConfig {
+	return latest.DeployConfig{
+		DeployType: latest.DeployType{
+			HelmDeploy: &latest.HelmDeploy{
+				Releases: h.releases,
+			},
+		},
+	}
+}
+
+// GetImages implements the Initializer interface and lists all the
+// images set by the values of the charts.
+func (h *Helm) GetImages() []string {
+	return h.images
+}
+
+func chartName(chart string) (string, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(chart, ChartFile))
+	if err != nil {
+		return "", errors.Wrap(err, "reading chart file")
+	}
+
+	var content struct {
+		Name string `yaml:"name"`
+	}
+	if err := yaml.Unmarshal(buf, &content); err != nil {
+		return "", errors.Wrap(err, "parsing chart file")
+	}
+	if content.Name == "" {
+		return "", errors.New("chart has no name")
+	}
+	return content.Name, nil
+}
+
+// parseImageValues lists the `image` values of a chart. They are either
+// a full image name, or a map with a `repository` and a `tag`.
+func parseImageValues(path string) ([]imageValue, error) {
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading values file")
+	}
+
+	values := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(buf, &values); err != nil {
+		return nil, errors.Wrap(err, "parsing values file")
+	}
+	return findImageValues(values, ""), nil
+}
+
+func findImageValues(values map[interface{}]interface{}, prefix string) []imageValue {
+	var keys []string
+	for k := range values {
+		keys = append(keys, fmt.Sprintf("%v", k))
+	}
+	sort.Strings(keys)
+
+	var found []imageValue
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch v := values[k].(type) {
+		case string:
+			if k == "image" && v != "" {
+				found = append(found, imageValue{key: key, image: v})
+			}
+		case map[interface{}]interface{}:
+			if repository, ok := v["repository"].(string); k == "image" && ok && repository != "" {
+				found = append(found, imageValue{key: key, image: repository, convention: true})
+				continue
+			}
+			found = append(found, findImageValues(v, key)...)
+		}
+	}
+	return found
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestGenerateHelmPipeline(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("web/Chart.yaml", "name: web").
+			Write("web/values.yaml", `image: gcr.io/k8s-skaffold/web
+backend:
+  image: gcr.io/k8s-skaffold/backend
+`).
+			Write("app/Chart.yaml", "name: app").
+			Write("app/values.yaml", `image:
+  repository: gcr.io/k8s-skaffold/app
+  tag: latest
+`).
+			Write("invalid/Chart.yaml", "description: no name")
+
+		h, err := New([]string{tmpDir.Path("web"), tmpDir.Path("app"), tmpDir.Path("invalid")})
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]string{"gcr.io/k8s-skaffold/backend", "gcr.io/k8s-skaffold/web", "gcr.io/k8s-skaffold/app"}, h.GetImages())
+		t.CheckDeepEqual(latest.DeployConfig{
+			DeployType: latest.DeployType{
+				HelmDeploy: &latest.HelmDeploy{
+					Releases: []latest.HelmRelease{
+						{
+							Name:      "web",
+							ChartPath: tmpDir.Path("web"),
+							Values: map[string]string{
+								"image":         "gcr.io/k8s-skaffold/web",
+								"backend.image": "gcr.io/k8s-skaffold/backend",
+							},
+						},
+						{
+							Name:      "app",
+							ChartPath: tmpDir.Path("app"),
+							Values:    map[string]string{"image": "gcr.io/k8s-skaffold/app"},
+							ImageStrategy: latest.HelmImageStrategy{HelmImageConfig: latest.HelmImageConfig{
+								HelmConventionConfig: &latest.HelmConventionConfig{},
+							}},
+						},
+					},
+				},
+			},
+		}, h.GenerateDeployConfig())
+	})
+}
+
+func TestGenerateHelmPipelineNoChart(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().Write("invalid/Chart.yaml", "")
+
+		_, err := New([]string{tmpDir.Path("invalid")})
+
+		t.CheckError(true, err)
+	})
+}
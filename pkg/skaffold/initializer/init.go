@@ -29,9 +29,9 @@ import (
 
 	"github.com/GoogleContainerTools/skaffold/cmd/skaffold/app/tips"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
-	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
-	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/helm"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kustomize"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/defaults"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -41,9 +41,11 @@ import (
 	yaml "gopkg.in/yaml.v2"
 )
 
-// NoDockerfile allows users to specify they don't want to build
+// NoBuilder allows users to specify they don't want to build
 // an image we parse out from a kubernetes manifest
-const NoDockerfile = "None (image not built from these sources)"
+const NoBuilder = "None (image not built from these sources)"
+
+const noBuilderErr = "one or more valid builder configurations (Dockerfile, Jib, Bazel, or a package.json or go.mod project with a build.sh script) must be present to build images with skaffold; please provide at least one and try again or run `skaffold init --skip-build`"
 
 // Initializer is the Init API of skaffold and responsible for generating
 // skaffold configuration file.
@@ -61,7 +63,11 @@ type Config struct {
 	SkipBuild    bool
 	Force        bool
 	Analyze      bool
-	Opts         *config.SkaffoldOptions
+	// GenerateManifests generates a manifest for each artifact when the sources have none.
+	GenerateManifests bool
+	// Answers is a file, or `-` for stdin, with the answers to the prompts.
+	Answers string
+	Opts    *config.SkaffoldOptions
 }
 
 // DoInit executes the `skaffold init` flow.
@@ -77,48 +83,92 @@ func DoInit(out io.Writer, c Config) error {
 		}
 	}
 
-	potentialConfigs, dockerfiles, err := walk(rootDir, c.Force, docker.ValidateDockerfile)
+	found, err := walk(rootDir, c.Force, docker.ValidateDockerfile)
 	if err != nil {
 		return err
 	}
+	builders := found.builders
 
-	k, err := kubectl.New(potentialConfigs)
-	if err != nil {
+	candidates, err := deployers(found.potentialConfigs, found.charts, found.kustomizations)
+	if err != nil && !c.GenerateManifests && c.Answers == "" {
 		return err
 	}
-	images := k.GetImages()
 	if c.Analyze {
-		return printAnalyzeJSON(out, c.SkipBuild, dockerfiles, images)
+		return printAnalyzeJSON(out, c.SkipBuild, builders, candidates)
 	}
-	var pairs []dockerfilePair
-	// conditionally generate build artifacts
-	if !c.SkipBuild {
-		if len(dockerfiles) == 0 {
-			return errors.New("one or more valid Dockerfiles must be present to build images with skaffold; please provide at least one Dockerfile and try again or run `skaffold init --skip-build`")
+
+	var answers *answers
+	if c.Answers != "" {
+		if answers, err = readAnswers(c.Answers); err != nil {
+			return err
 		}
+	}
 
-		if c.CliArtifacts != nil {
-			pairs, err = processCliArtifacts(c.CliArtifacts)
-			if err != nil {
-				return errors.Wrap(err, "processing cli artifacts")
+	var k Initializer
+	var pairs []builderImagePair
+	switch {
+	case answers != nil:
+		if k, err = answers.resolveDeployer(candidates); err != nil {
+			return err
+		}
+		if !c.SkipBuild {
+			pairs, err = answers.resolveBuilders(builders)
+		}
+	case len(candidates) == 0:
+		if c.SkipBuild || len(builders) == 0 {
+			break
+		}
+		pairs, err = pairBuilders(c.CliArtifacts, builders, func() []builderImagePair {
+			return promptImageNames(builders)
+		})
+	default:
+		k = chooseDeployer(candidates)
+		// conditionally generate build artifacts
+		if !c.SkipBuild {
+			if len(builders) == 0 {
+				return errors.New(noBuilderErr)
 			}
-		} else {
-			pairs = resolveDockerfileImages(dockerfiles, images)
+
+			pairs, err = pairBuilders(c.CliArtifacts, builders, func() []builderImagePair {
+				return resolveBuilderImages(builders, k.GetImages())
+			})
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var manifests []generatedManifest
+	if k == nil {
+		// there is nothing to deploy: generate manifests for the artifacts
+		if !c.GenerateManifests {
+			return errors.New("one or more valid kubernetes manifests, helm charts or kustomizations is required to run skaffold")
+		}
+		if len(pairs) == 0 {
+			return errors.New("one or more artifacts are required to generate kubernetes manifests")
 		}
+		if k, manifests, err = generateManifests(pairs, c.Force); err != nil {
+			return err
+		}
+	} else if c.GenerateManifests {
+		logrus.Infof("existing deploy configurations found: not generating kubernetes manifests")
 	}
 
-	pipeline, err := generateSkaffoldConfig(k, pairs)
+	pipeline, err := generateSkaffoldConfig(k, pairs, answers)
 	if err != nil {
 		return err
 	}
 
 	if c.Opts.ConfigurationFile == "-" {
 		out.Write(pipeline)
-		return nil
+		return writeManifests(manifests)
 	}
 
-	if !c.Force {
+	if !c.Force && answers == nil {
 		fmt.Fprintln(out, string(pipeline))
+		for _, m := range manifests {
+			fmt.Fprintf(out, "---\n# %s\n%s", m.path, m.content)
+		}
 
 		reader := bufio.NewReader(os.Stdin)
 	confirmLoop:
@@ -140,6 +190,13 @@ func DoInit(out io.Writer, c Config) error {
 		}
 	}
 
+	if err := writeManifests(manifests); err != nil {
+		return err
+	}
+	for _, m := range manifests {
+		fmt.Fprintf(out, "Manifest %s was generated\n", m.path)
+	}
+
 	if err := ioutil.WriteFile(c.Opts.ConfigurationFile, pipeline, 0644); err != nil {
 		return errors.Wrap(err, "writing config to file")
 	}
@@ -150,94 +207,133 @@ func DoInit(out io.Writer, c Config) error {
 	return nil
 }
 
-func processCliArtifacts(artifacts []string) ([]dockerfilePair, error) {
-	var pairs []dockerfilePair
+// pairBuilders pairs builders and images as set by the --artifact flags or, if there are none, as resolved otherwise.
+func pairBuilders(cliArtifacts []string, builders []InitBuilder, resolve func() []builderImagePair) ([]builderImagePair, error) {
+	if cliArtifacts == nil {
+		return resolve(), nil
+	}
+
+	pairs, err := processCliArtifacts(cliArtifacts, builders)
+	if err != nil {
+		return nil, errors.Wrap(err, "processing cli artifacts")
+	}
+	return pairs, nil
+}
+
+func processCliArtifacts(artifacts []string, builders []InitBuilder) ([]builderImagePair, error) {
+	var pairs []builderImagePair
 	for _, artifact := range artifacts {
 		parts := strings.Split(artifact, "=")
 		if len(parts) != 2 {
 			return nil, fmt.Errorf("malformed artifact provided: %s", artifact)
 		}
-		pairs = append(pairs, dockerfilePair{
-			Dockerfile: parts[0],
-			ImageName:  parts[1],
+
+		// A Dockerfile that was not found while walking the sources is still used as is.
+		var builder InitBuilder = dockerBuilder{path: parts[0]}
+		for _, b := range builders {
+			if filepath.Clean(b.Path()) == filepath.Clean(parts[0]) {
+				builder = b
+				break
+			}
+		}
+		pairs = append(pairs, builderImagePair{
+			Builder:   builder,
+			ImageName: parts[1],
 		})
 	}
 	return pairs, nil
 }
 
 // For each image parsed from all k8s manifests, prompt the user for
-// the dockerfile that builds the referenced image
-func resolveDockerfileImages(dockerfiles []string, images []string) []dockerfilePair {
-	// if we only have 1 image and 1 dockerfile, don't bother prompting
-	if len(images) == 1 && len(dockerfiles) == 1 {
-		return []dockerfilePair{{
-			Dockerfile: dockerfiles[0],
-			ImageName:  images[0],
-		}}
-	}
-	pairs := []dockerfilePair{}
-	for {
-		if len(images) == 0 {
-			break
+// the builder that builds the referenced image
+func resolveBuilderImages(builders []InitBuilder, images []string) []builderImagePair {
+	pairs := []builderImagePair{}
+
+	// pair the builders that name the image they build, without prompting
+	var unresolved []string
+	for _, image := range images {
+		resolved := false
+		for i, b := range builders {
+			if b.ConfiguredImage() != "" && b.ConfiguredImage() == image {
+				pairs = append(pairs, builderImagePair{Builder: b, ImageName: image})
+				builders = append(builders[:i:i], builders[i+1:]...)
+				resolved = true
+				break
+			}
+		}
+		if !resolved {
+			unresolved = append(unresolved, image)
 		}
-		image := images[0]
-		pair := promptUserForDockerfile(image, dockerfiles)
-		if pair.Dockerfile != NoDockerfile {
+	}
+	images = unresolved
+
+	// if we only have 1 image and 1 builder, don't bother prompting
+	if len(images) == 1 && len(builders) == 1 {
+		return append(pairs, builderImagePair{
+			Builder:   builders[0],
+			ImageName: images[0],
+		})
+	}
+	for _, image := range images {
+		pair, found := promptUserForBuilder(image, builders)
+		if found {
 			pairs = append(pairs, pair)
-			dockerfiles = util.RemoveFromSlice(dockerfiles, pair.Dockerfile)
+			builders = removeBuilder(builders, pair.Builder)
 		}
-		images = util.RemoveFromSlice(images, pair.ImageName)
 	}
-	if len(dockerfiles) > 0 {
-		logrus.Warnf("unused dockerfiles found in repository: %v", dockerfiles)
+	if len(builders) > 0 {
+		var unused []string
+		for _, b := range builders {
+			unused = append(unused, b.Describe())
+		}
+		logrus.Warnf("unused builders found in repository: %v", unused)
 	}
 	return pairs
 }
 
-func promptUserForDockerfile(image string, dockerfiles []string) dockerfilePair {
-	var selectedDockerfile string
-	options := append(dockerfiles, NoDockerfile)
+func promptUserForBuilder(image string, builders []InitBuilder) (builderImagePair, bool) {
+	var options []string
+	for _, b := range builders {
+		options = append(options, b.Describe())
+	}
+	options = append(options, NoBuilder)
+
+	var selected string
 	prompt := &survey.Select{
-		Message:  fmt.Sprintf("Choose the dockerfile to build image %s", image),
+		Message:  fmt.Sprintf("Choose the builder to build image %s", image),
 		Options:  options,
 		PageSize: 15,
 	}
-	survey.AskOne(prompt, &selectedDockerfile, nil)
-	return dockerfilePair{
-		Dockerfile: selectedDockerfile,
-		ImageName:  image,
+	survey.AskOne(prompt, &selected, nil)
+
+	for _, b := range builders {
+		if b.Describe() == selected {
+			return builderImagePair{Builder: b, ImageName: image}, true
+		}
 	}
+	return builderImagePair{}, false
 }
 
-func processBuildArtifacts(pairs []dockerfilePair) latest.BuildConfig {
+func removeBuilder(builders []InitBuilder, builder InitBuilder) []InitBuilder {
+	var remaining []InitBuilder
+	for _, b := range builders {
+		if b != builder {
+			remaining = append(remaining, b)
+		}
+	}
+	return remaining
+}
+
+func processBuildArtifacts(pairs []builderImagePair) latest.BuildConfig {
 	var config latest.BuildConfig
 
-	if len(pairs) > 0 {
-		var artifacts []*latest.Artifact
-		for _, pair := range pairs {
-			workspace := filepath.Dir(pair.Dockerfile)
-			dockerfilePath := filepath.Base(pair.Dockerfile)
-			a := &latest.Artifact{
-				ImageName: pair.ImageName,
-			}
-			if workspace != "." {
-				a.Workspace = workspace
-			}
-			if dockerfilePath != constants.DefaultDockerfilePath {
-				a.ArtifactType = latest.ArtifactType{
-					DockerArtifact: &latest.DockerArtifact{
-						DockerfilePath: dockerfilePath,
-					},
-				}
-			}
-			artifacts = append(artifacts, a)
-		}
-		config.Artifacts = artifacts
+	for _, pair := range pairs {
+		config.Artifacts = append(config.Artifacts, pair.Builder.CreateArtifact(pair.ImageName))
 	}
 	return config
 }
 
-func generateSkaffoldConfig(k Initializer, dockerfilePairs []dockerfilePair) ([]byte, error) {
+func generateSkaffoldConfig(k Initializer, pairs []builderImagePair, answers *answers) ([]byte, error) {
 	// if we're here, the user has no skaffold yaml so we need to generate one
 	// if the user doesn't have any k8s yamls, generate one for each dockerfile
 	logrus.Info("generating skaffold config")
@@ -250,8 +346,15 @@ func generateSkaffoldConfig(k Initializer, dockerfilePairs []dockerfilePair) ([]
 		return nil, errors.Wrap(err, "generating default pipeline")
 	}
 
-	cfg.Build = processBuildArtifacts(dockerfilePairs)
+	cfg.Build = processBuildArtifacts(pairs)
 	cfg.Deploy = k.GenerateDeployConfig()
+	removeUnbuiltHelmValues(&cfg.Deploy, cfg.Build.Artifacts)
+	if answers != nil {
+		if answers.TagPolicy != nil {
+			cfg.Build.TagPolicy = *answers.TagPolicy
+		}
+		cfg.Profiles = answers.Profiles
+	}
 
 	pipelineStr, err := yaml.Marshal(cfg)
 	if err != nil {
@@ -261,17 +364,33 @@ func generateSkaffoldConfig(k Initializer, dockerfilePairs []dockerfilePair) ([]
 	return pipelineStr, nil
 }
 
-func printAnalyzeJSON(out io.Writer, skipBuild bool, dockerfiles, images []string) error {
-	if !skipBuild && len(dockerfiles) == 0 {
-		return errors.New("one or more valid Dockerfiles must be present to build images with skaffold; please provide at least one Dockerfile and try again or run `skaffold init --skip-build`")
+func printAnalyzeJSON(out io.Writer, skipBuild bool, builders []InitBuilder, deployers []deployer) error {
+	if !skipBuild && len(builders) == 0 {
+		return errors.New(noBuilderErr)
+	}
+
+	a := analysis{
+		Images: allImages(deployers),
 	}
-	a := struct {
-		Dockerfiles []string `json:"dockerfiles,omitempty"`
-		Images      []string `json:"images,omitempty"`
-	}{
-		Dockerfiles: dockerfiles,
-		Images:      images,
+	for _, b := range builders {
+		if _, isDocker := b.(dockerBuilder); isDocker {
+			a.Dockerfiles = append(a.Dockerfiles, b.Path())
+		}
+		a.Builders = append(a.Builders, analyzedBuilder{
+			Name:        b.Name(),
+			Path:        b.Path(),
+			Description: b.Describe(),
+			Image:       b.ConfiguredImage(),
+		})
+	}
+	for _, d := range deployers {
+		a.Deployers = append(a.Deployers, analyzedDeployer{
+			Name:        d.name,
+			Description: d.description,
+			Paths:       d.paths,
+		})
 	}
+
 	contents, err := json.Marshal(a)
 	if err != nil {
 		return errors.Wrap(err, "marshalling contents")
@@ -280,19 +399,41 @@ func printAnalyzeJSON(out io.Writer, skipBuild bool, dockerfiles, images []strin
 	return err
 }
 
-type dockerfilePair struct {
-	Dockerfile string
-	ImageName  string
+type builderImagePair struct {
+	Builder   InitBuilder
+	ImageName string
+}
+
+// sources are the files found by walking the sources that skaffold init can use.
+type sources struct {
+	potentialConfigs []string
+	builders         []InitBuilder
+	charts           []string
+	kustomizations   []string
 }
 
-func walk(dir string, force bool, validateDockerfile func(string) bool) ([]string, []string, error) {
-	var dockerfiles, potentialConfigs []string
+func walk(dir string, force bool, validateDockerfile func(string) bool) (*sources, error) {
+	found := &sources{}
+	chartDirs := map[string]bool{}
 	err := filepath.Walk(dir, func(path string, f os.FileInfo, e error) error {
-		if f.IsDir() && util.IsHiddenDir(f.Name()) {
-			logrus.Debugf("skip walking hidden dir %s", f.Name())
+		if f.IsDir() && (util.IsHiddenDir(f.Name()) || f.Name() == "node_modules") {
+			logrus.Debugf("skip walking dir %s", f.Name())
 			return filepath.SkipDir
 		}
-		if f.IsDir() || util.IsHiddenFile(f.Name()) {
+		// the templates of a chart are not valid manifests and its dependencies are deployed with it
+		inChart := chartDirs[filepath.Dir(path)]
+		if f.IsDir() {
+			if inChart && (f.Name() == "templates" || f.Name() == "charts") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, helm.ChartFile)); err == nil {
+				logrus.Infof("existing helm chart found: %s", path)
+				chartDirs[path] = true
+				found.charts = append(found.charts, path)
+			}
+			return nil
+		}
+		if util.IsHiddenFile(f.Name()) {
 			return nil
 		}
 		if IsSkaffoldConfig(path) {
@@ -302,19 +443,23 @@ func walk(dir string, force bool, validateDockerfile func(string) bool) ([]strin
 			logrus.Debugf("%s is a valid skaffold configuration: continuing since --force=true", path)
 			return nil
 		}
-		if IsSupportedKubernetesFileExtension(path) {
-			potentialConfigs = append(potentialConfigs, path)
+		if f.Name() == kustomize.KustomizationFile {
+			logrus.Infof("existing kustomization found: %s", path)
+			found.kustomizations = append(found.kustomizations, filepath.Dir(path))
 			return nil
 		}
-		// try and parse dockerfile
-		if validateDockerfile(path) {
-			logrus.Infof("existing dockerfile found: %s", path)
-			dockerfiles = append(dockerfiles, path)
+		if f.Name() != "package.json" && IsSupportedKubernetesFileExtension(path) {
+			if !inChart {
+				found.potentialConfigs = append(found.potentialConfigs, path)
+			}
+			return nil
 		}
+		found.builders = append(found.builders, detectBuilders(dir, path, validateDockerfile)...)
 		return nil
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	return potentialConfigs, dockerfiles, nil
+	found.builders = withoutRedundantCustomBuilders(found.builders)
+	return found, nil
 }
@@ -21,32 +21,44 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestPrintAnalyzeJSON(t *testing.T) {
 	tests := []struct {
 		description string
-		dockerfiles []string
-		images      []string
+		builders    []InitBuilder
+		deployers   []deployer
 		skipBuild   bool
 		shouldErr   bool
 		expected    string
 	}{
 		{
 			description: "dockerfile and image",
-			dockerfiles: []string{"Dockerfile", "Dockerfile_2"},
-			images:      []string{"image1", "image2"},
-			expected:    "{\"dockerfiles\":[\"Dockerfile\",\"Dockerfile_2\"],\"images\":[\"image1\",\"image2\"]}",
+			builders:    []InitBuilder{dockerBuilder{path: "Dockerfile"}, dockerBuilder{path: "Dockerfile_2"}},
+			deployers:   []deployer{{name: "kubectl", description: "kubectl (raw kubernetes manifests)", paths: []string{"pod.yaml"}, initializer: fakeInitializer{"image1", "image2"}}},
+			expected:    `{"dockerfiles":["Dockerfile","Dockerfile_2"],"builders":[{"name":"Docker","path":"Dockerfile","description":"Docker (Dockerfile)"},{"name":"Docker","path":"Dockerfile_2","description":"Docker (Dockerfile_2)"}],"deployers":[{"name":"kubectl","description":"kubectl (raw kubernetes manifests)","paths":["pod.yaml"]}],"images":["image1","image2"]}`,
+		},
+		{
+			description: "jib and bazel",
+			builders: []InitBuilder{
+				jibBuilder{name: "Jib Maven Plugin", path: "pom.xml", image: "image1"},
+				bazelBuilder{path: "BUILD", workspace: ".", target: "//:image2.tar"},
+			},
+			deployers: []deployer{{name: "kubectl", description: "kubectl (raw kubernetes manifests)", paths: []string{"pod.yaml"}, initializer: fakeInitializer{"image1", "image2"}}},
+			expected:  `{"builders":[{"name":"Jib Maven Plugin","path":"pom.xml","description":"Jib Maven Plugin (pom.xml)","image":"image1"},{"name":"Bazel","path":"BUILD","description":"Bazel (//:image2.tar)"}],"deployers":[{"name":"kubectl","description":"kubectl (raw kubernetes manifests)","paths":["pod.yaml"]}],"images":["image1","image2"]}`,
 		},
 		{
 			description: "no dockerfile, skip build",
-			images:      []string{"image1", "image2"},
+			deployers:   []deployer{{name: "kubectl", description: "kubectl (raw kubernetes manifests)", paths: []string{"pod.yaml"}, initializer: fakeInitializer{"image1", "image2"}}},
 			skipBuild:   true,
-			expected:    "{\"images\":[\"image1\",\"image2\"]}"},
+			expected:    `{"deployers":[{"name":"kubectl","description":"kubectl (raw kubernetes manifests)","paths":["pod.yaml"]}],"images":["image1","image2"]}`,
+		},
 		{
 			description: "no dockerfile",
-			images:      []string{"image1", "image2"},
+			deployers:   []deployer{{name: "kubectl", description: "kubectl (raw kubernetes manifests)", paths: []string{"pod.yaml"}, initializer: fakeInitializer{"image1", "image2"}}},
 			shouldErr:   true,
 		},
 		{
@@ -58,7 +70,7 @@ func TestPrintAnalyzeJSON(t *testing.T) {
 		testutil.Run(t, test.description, func(t *testutil.T) {
 			out := bytes.NewBuffer([]byte{})
 
-			err := printAnalyzeJSON(out, test.skipBuild, test.dockerfiles, test.images)
+			err := printAnalyzeJSON(out, test.skipBuild, test.builders, test.deployers)
 
 			t.CheckErrorAndDeepEqual(test.shouldErr, err, test.expected, out.String())
 		})
@@ -68,12 +80,14 @@ func TestPrintAnalyzeJSON(t *testing.T) {
 func TestWalk(t *testing.T) {
 	emptyFile := ""
 	tests := []struct {
-		description         string
-		filesWithContents   map[string]string
-		expectedConfigs     []string
-		expectedDockerfiles []string
-		force               bool
-		shouldErr           bool
+		description            string
+		filesWithContents      map[string]string
+		expectedConfigs        []string
+		expectedBuilders       []string
+		expectedCharts         []string
+		expectedKustomizations []string
+		force                  bool
+		shouldErr              bool
 	}{
 		{
 			description: "should return correct k8 configs and dockerfiles",
@@ -89,9 +103,9 @@ func TestWalk(t *testing.T) {
 				"config/test.yaml",
 				"k8pod.yml",
 			},
-			expectedDockerfiles: []string{
-				"Dockerfile",
-				"deploy/Dockerfile",
+			expectedBuilders: []string{
+				"Docker (Dockerfile)",
+				"Docker (deploy/Dockerfile)",
 			},
 			shouldErr: false,
 		},
@@ -108,8 +122,8 @@ func TestWalk(t *testing.T) {
 			expectedConfigs: []string{
 				"k8pod.yml",
 			},
-			expectedDockerfiles: []string{
-				"Dockerfile",
+			expectedBuilders: []string{
+				"Docker (Dockerfile)",
 			},
 			shouldErr: false,
 		},
@@ -131,9 +145,9 @@ deploy:
 				"config/test.yaml",
 				"k8pod.yml",
 			},
-			expectedDockerfiles: []string{
-				"Dockerfile",
-				"deploy/Dockerfile",
+			expectedBuilders: []string{
+				"Docker (Dockerfile)",
+				"Docker (deploy/Dockerfile)",
 			},
 			shouldErr: false,
 		},
@@ -150,10 +164,58 @@ kind: Config
 deploy:
   kustomize: {}`,
 			},
-			force:               false,
-			expectedConfigs:     nil,
-			expectedDockerfiles: nil,
-			shouldErr:           true,
+			force:            false,
+			expectedConfigs:  nil,
+			expectedBuilders: nil,
+			shouldErr:        true,
+		},
+		{
+			description: "should detect jib, bazel and custom builders",
+			filesWithContents: map[string]string{
+				"k8pod.yml":                        emptyFile,
+				"java/pom.xml":                     "<plugin><artifactId>jib-maven-plugin</artifactId><configuration><to><image>gcr.io/java</image></to></configuration></plugin>",
+				"kotlin/build.gradle":              "plugins { id 'com.google.cloud.tools.jib' version '1.3.0' }",
+				"maven/pom.xml":                    "<project></project>",
+				"bazel/WORKSPACE":                  emptyFile,
+				"bazel/BUILD":                      `container_image(name = "base")`,
+				"bazel/app/BUILD":                  "go_image(\n    name = \"app\",\n)\ngo_binary(name = \"bin\")",
+				"node/package.json":                "{}",
+				"node/build.sh":                    emptyFile,
+				"node/node_modules/x/package.json": "{}",
+				"web/package.json":                 "{}",
+				"go/go.mod":                        "module app",
+				"go/Dockerfile":                    emptyFile,
+			},
+			expectedConfigs: []string{
+				"k8pod.yml",
+			},
+			expectedBuilders: []string{
+				"Bazel (//:base.tar)",
+				"Bazel (//app:app.tar)",
+				"Docker (go/Dockerfile)",
+				"Jib Maven Plugin (java/pom.xml)",
+				"Jib Gradle Plugin (kotlin/build.gradle)",
+				"Custom build script (node/package.json)",
+			},
+		},
+		{
+			description: "should detect helm charts and kustomizations",
+			filesWithContents: map[string]string{
+				"k8pod.yml":                          emptyFile,
+				"charts/web/Chart.yaml":              "name: web",
+				"charts/web/values.yaml":             "image: web",
+				"charts/web/templates/pod.yaml":      emptyFile,
+				"charts/web/charts/redis/Chart.yaml": "name: redis",
+				"kustomize/base/kustomization.yaml":  emptyFile,
+				"kustomize/base/pod.yaml":            emptyFile,
+				"kustomize/dev/kustomization.yaml":   emptyFile,
+			},
+			expectedConfigs: []string{
+				"k8pod.yml",
+				"kustomize/base/pod.yaml",
+			},
+			expectedCharts:         []string{"charts/web"},
+			expectedKustomizations: []string{"kustomize/base", "kustomize/dev"},
 		},
 	}
 	for _, test := range tests {
@@ -163,15 +225,106 @@ deploy:
 				tmpDir.Write(file, contents)
 			}
 
-			potentialConfigs, dockerfiles, err := walk(tmpDir.Root(), test.force, testValidDocker)
+			t.Chdir(tmpDir.Root())
+
+			found, err := walk(".", test.force, testValidDocker)
 
 			t.CheckError(test.shouldErr, err)
-			t.CheckDeepEqual(tmpDir.Paths(test.expectedConfigs...), potentialConfigs)
-			t.CheckDeepEqual(tmpDir.Paths(test.expectedDockerfiles...), dockerfiles)
+			if found == nil {
+				found = &sources{}
+			}
+			t.CheckDeepEqual(test.expectedConfigs, found.potentialConfigs)
+			t.CheckDeepEqual(test.expectedBuilders, describe(found.builders))
+			t.CheckDeepEqual(test.expectedCharts, found.charts)
+			t.CheckDeepEqual(test.expectedKustomizations, found.kustomizations)
+		})
+	}
+}
+
+func TestResolveBuilderImages(t *testing.T) {
+	tests := []struct {
+		description string
+		builders    []InitBuilder
+		images      []string
+		expected    []builderImagePair
+	}{
+		{
+			description: "one builder and one image",
+			builders:    []InitBuilder{dockerBuilder{path: "Dockerfile"}},
+			images:      []string{"image"},
+			expected:    []builderImagePair{{Builder: dockerBuilder{path: "Dockerfile"}, ImageName: "image"}},
+		},
+		{
+			description: "builders that name their image",
+			builders: []InitBuilder{
+				jibBuilder{name: "Jib Maven Plugin", path: "java/pom.xml", image: "java"},
+				jibBuilder{name: "Jib Gradle Plugin", path: "kotlin/build.gradle", image: "kotlin"},
+				dockerBuilder{path: "Dockerfile"},
+			},
+			images: []string{"kotlin", "java", "image"},
+			expected: []builderImagePair{
+				{Builder: jibBuilder{name: "Jib Gradle Plugin", path: "kotlin/build.gradle", image: "kotlin"}, ImageName: "kotlin"},
+				{Builder: jibBuilder{name: "Jib Maven Plugin", path: "java/pom.xml", image: "java"}, ImageName: "java"},
+				{Builder: dockerBuilder{path: "Dockerfile"}, ImageName: "image"},
+			},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			pairs := resolveBuilderImages(test.builders, test.images)
+
+			t.CheckDeepEqual(test.expected, pairs, cmp.AllowUnexported(dockerBuilder{}, jibBuilder{}))
 		})
 	}
 }
 
+func TestProcessCliArtifacts(t *testing.T) {
+	builders := []InitBuilder{jibBuilder{name: "Jib Maven Plugin", path: "java/pom.xml"}}
+
+	pairs, err := processCliArtifacts([]string{"java/pom.xml=java", "web/Dockerfile.web=web"}, builders)
+
+	testutil.CheckErrorAndDeepEqual(t, false, err, []builderImagePair{
+		{Builder: jibBuilder{name: "Jib Maven Plugin", path: "java/pom.xml"}, ImageName: "java"},
+		{Builder: dockerBuilder{path: "web/Dockerfile.web"}, ImageName: "web"},
+	}, pairs, cmp.AllowUnexported(dockerBuilder{}, jibBuilder{}))
+}
+
+func TestProcessBuildArtifacts(t *testing.T) {
+	config := processBuildArtifacts([]builderImagePair{
+		{Builder: dockerBuilder{path: "Dockerfile"}, ImageName: "docker"},
+		{Builder: dockerBuilder{path: "web/Dockerfile.web"}, ImageName: "web"},
+		{Builder: jibBuilder{name: "Jib Maven Plugin", path: "pom.xml"}, ImageName: "maven"},
+		{Builder: jibBuilder{name: "Jib Gradle Plugin", path: "kotlin/build.gradle.kts"}, ImageName: "gradle"},
+		{Builder: bazelBuilder{path: "bazel/app/BUILD", workspace: "bazel", target: "//app:app.tar"}, ImageName: "bazel"},
+		{Builder: customBuilder{path: "node/package.json"}, ImageName: "node"},
+	})
+
+	testutil.CheckDeepEqual(t, []*latest.Artifact{
+		{ImageName: "docker"},
+		{ImageName: "web", Workspace: "web", ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{DockerfilePath: "Dockerfile.web"}}},
+		{ImageName: "maven", ArtifactType: latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{}}},
+		{ImageName: "gradle", Workspace: "kotlin", ArtifactType: latest.ArtifactType{JibGradleArtifact: &latest.JibGradleArtifact{}}},
+		{ImageName: "bazel", Workspace: "bazel", ArtifactType: latest.ArtifactType{BazelArtifact: &latest.BazelArtifact{BuildTarget: "//app:app.tar"}}},
+		{ImageName: "node", Workspace: "node", ArtifactType: latest.ArtifactType{CustomArtifact: &latest.CustomArtifact{
+			BuildCommand: "./build.sh",
+			Dependencies: &latest.CustomDependencies{Paths: []string{"."}, Ignore: []string{"node_modules"}},
+		}}},
+	}, config.Artifacts)
+}
+
+func describe(builders []InitBuilder) []string {
+	var descriptions []string
+	for _, b := range builders {
+		descriptions = append(descriptions, b.Describe())
+	}
+	return descriptions
+}
+
+type fakeInitializer []string
+
+func (f fakeInitializer) GenerateDeployConfig() latest.DeployConfig { return latest.DeployConfig{} }
+func (f fakeInitializer) GetImages() []string                       { return f }
+
 func testValidDocker(path string) bool {
 	return strings.HasSuffix(path, "Dockerfile")
 }
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/pkg/errors"
+)
+
+// ManifestsDir is the directory where generated manifests are written.
+const ManifestsDir = "k8s"
+
+var manifestTemplate = template.Must(template.New("manifest").Funcs(template.FuncMap{"lower": strings.ToLower}).Parse(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.Name}}
+  labels:
+    app: {{.Name}}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: {{.Name}}
+  template:
+    metadata:
+      labels:
+        app: {{.Name}}
+    spec:
+      containers:
+      - name: {{.Name}}
+        image: {{.Image}}
+{{- if .Ports}}
+        ports:
+{{- range .Ports}}
+        - containerPort: {{.Port}}
+          protocol: {{.Protocol}}
+{{- end}}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{.Name}}
+  labels:
+    app: {{.Name}}
+spec:
+  selector:
+    app: {{.Name}}
+  ports:
+{{- range .Ports}}
+  - name: {{lower .Protocol}}-{{.Port}}
+    port: {{.Port}}
+    targetPort: {{.Port}}
+    protocol: {{.Protocol}}
+{{- end}}
+{{- end}}
+`))
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// NewGenerated returns a Kubectl skaffold generator for manifests that are generated by skaffold init.
+func NewGenerated(manifests, images []string) *Kubectl {
+	return &Kubectl{
+		configs: manifests,
+		images:  images,
+	}
+}
+
+// ManifestName returns a valid kubernetes name for the resources that run an image.
+func ManifestName(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.IndexAny(name, ":@"); i >= 0 {
+		name = name[:i]
+	}
+
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
+
+// GenerateManifest generates a Deployment that runs an image and,
+// if the image exposes ports, a Service for those ports.
+func GenerateManifest(name, image string, ports []docker.ExposedPort) ([]byte, error) {
+	var buf bytes.Buffer
+	err := manifestTemplate.Execute(&buf, struct {
+		Name  string
+		Image string
+		Ports []docker.ExposedPort
+	}{
+		Name:  name,
+		Image: image,
+		Ports: ports,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "generating manifest")
+	}
+	return buf.Bytes(), nil
+}
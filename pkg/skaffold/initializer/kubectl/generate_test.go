@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestManifestName(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "app", expected: "app"},
+		{image: "gcr.io/k8s-skaffold/leeroy_web:v1", expected: "leeroy-web"},
+		{image: "localhost:5000/App@sha256:abc", expected: "app"},
+		{image: "__", expected: "app"},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.image, func(t *testutil.T) {
+			t.CheckDeepEqual(test.expected, ManifestName(test.image))
+		})
+	}
+}
+
+func TestGenerateManifest(t *testing.T) {
+	tests := []struct {
+		description string
+		ports       []docker.ExposedPort
+		expected    string
+	}{
+		{
+			description: "deployment only",
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: gcr.io/k8s-skaffold/web
+`,
+		},
+		{
+			description: "deployment and service",
+			ports:       []docker.ExposedPort{{Port: 8080, Protocol: "TCP"}, {Port: 53, Protocol: "UDP"}},
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: gcr.io/k8s-skaffold/web
+        ports:
+        - containerPort: 8080
+          protocol: TCP
+        - containerPort: 53
+          protocol: UDP
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  selector:
+    app: web
+  ports:
+  - name: tcp-8080
+    port: 8080
+    targetPort: 8080
+    protocol: TCP
+  - name: udp-53
+    port: 53
+    targetPort: 53
+    protocol: UDP
+`,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			manifest, err := GenerateManifest("web", "gcr.io/k8s-skaffold/web", test.ports)
+
+			t.CheckErrorAndDeepEqual(false, err, test.expected, string(manifest))
+		})
+	}
+}
+
+func TestGeneratedManifestIsValid(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		manifest, err := GenerateManifest("web", "gcr.io/k8s-skaffold/web", []docker.ExposedPort{{Port: 80, Protocol: "TCP"}})
+		t.CheckNoError(err)
+		tmpDir := t.NewTempDir().Write("web.yaml", string(manifest))
+
+		images, err := ParseImagesFromKubernetesYaml(tmpDir.Path("web.yaml"))
+
+		t.CheckErrorAndDeepEqual(false, err, []string{"gcr.io/k8s-skaffold/web"}, images)
+	})
+}
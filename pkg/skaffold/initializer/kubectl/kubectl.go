@@ -43,7 +43,7 @@ type Kubectl struct {
 func New(potentialConfigs []string) (*Kubectl, error) {
 	var k8sConfigs, images []string
 	for _, file := range potentialConfigs {
-		imgs, err := parseImagesFromKubernetesYaml(file)
+		imgs, err := ParseImagesFromKubernetesYaml(file)
 		if err == nil {
 			logrus.Infof("found valid k8s yaml: %s", file)
 			k8sConfigs = append(k8sConfigs, file)
@@ -79,10 +79,10 @@ func (k *Kubectl) GetImages() []string {
 	return k.images
 }
 
-// parseImagesFromKubernetesYaml attempts to parse k8s objects from a yaml file
+// ParseImagesFromKubernetesYaml attempts to parse k8s objects from a yaml file
 // if successful, it will return the images referenced in the k8s config
 // so they can be built by the generated skaffold yaml
-func parseImagesFromKubernetesYaml(filepath string) ([]string, error) {
+func ParseImagesFromKubernetesYaml(filepath string) ([]string, error) {
 	f, err := os.Open(filepath)
 	if err != nil {
 		return nil, errors.Wrap(err, "opening config file")
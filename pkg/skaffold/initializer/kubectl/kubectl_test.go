@@ -86,7 +86,7 @@ spec:
 			tmpDir := t.NewTempDir().
 				Write("deployment.yaml", test.contents)
 
-			images, err := parseImagesFromKubernetesYaml(tmpDir.Path("deployment.yaml"))
+			images, err := ParseImagesFromKubernetesYaml(tmpDir.Path("deployment.yaml"))
 
 			t.CheckErrorAndDeepEqual(test.shouldErr, err, test.images, images)
 		})
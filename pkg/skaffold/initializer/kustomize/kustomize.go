@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// KustomizationFile is the file that makes a directory a kustomization.
+const KustomizationFile = "kustomization.yaml"
+
+// Kustomize holds parameters to deploy with kustomize.
+type Kustomize struct {
+	path   string
+	images []string
+}
+
+// kustomization is the part of a kustomization.yaml file that references images.
+type kustomization struct {
+	Bases     []string `yaml:"bases"`
+	Resources []string `yaml:"resources"`
+	Images    []struct {
+		Name    string `yaml:"name"`
+		NewName string `yaml:"newName"`
+	} `yaml:"images"`
+}
+
+// New returns a Kustomize skaffold generator for the given kustomization directory.
+func New(path string) (*Kustomize, error) {
+	images, err := parseImages(path, map[string]bool{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading kustomization %s", path)
+	}
+
+	return &Kustomize{
+		path:   path,
+		images: images,
+	}, nil
+}
+
+// Overlays filters out the kustomizations that are only used as bases of other kustomizations.
+func Overlays(paths []string) []string {
+	bases := map[string]bool{}
+	for _, path := range paths {
+		content, err := readKustomization(path)
+		if err != nil {
+			continue
+		}
+		for _, base := range append(content.Bases, content.Resources...) {
+			bases[filepath.Clean(filepath.Join(path, base))] = true
+		}
+	}
+
+	var overlays []string
+	for _, path := range paths {
+		if !bases[filepath.Clean(path)] {
+			overlays = append(overlays, path)
+		}
+	}
+	return overlays
+}
+
+// GenerateDeployConfig implements the Initializer interface and generates
+// skaffold kustomize deployment config.
+func (k *Kustomize) GenerateDeployConfig() latest.DeployConfig {
+	kustomize := &latest.KustomizeDeploy{}
+	if k.path != "." {
+		kustomize.KustomizePath = k.path
+	}
+
+	return latest.DeployConfig{
+		DeployType: latest.DeployType{
+			KustomizeDeploy: kustomize,
+		},
+	}
+}
+
+// GetImages implements the Initializer interface and lists all the
+// images present in the kustomization and its bases.
+func (k *Kustomize) GetImages() []string {
+	return k.images
+}
+
+// parseImages lists the images referenced by a kustomization, its resources and its bases.
+func parseImages(dir string, visited map[string]bool) ([]string, error) {
+	if visited[dir] {
+		return nil, nil
+	}
+	visited[dir] = true
+
+	content, err := readKustomization(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+	for _, resource := range append(content.Bases, content.Resources...) {
+		path := filepath.Join(dir, resource)
+
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			baseImages, err := parseImages(path, visited)
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, baseImages...)
+			continue
+		}
+
+		resourceImages, err := kubectl.ParseImagesFromKubernetesYaml(path)
+		if err != nil {
+			logrus.Infof("invalid k8s yaml %s: %s", path, err)
+			continue
+		}
+		images = append(images, resourceImages...)
+	}
+	// the images of the resources can be renamed by the kustomization
+	for _, image := range content.Images {
+		for i := range images {
+			if images[i] == image.Name && image.NewName != "" {
+				images[i] = image.NewName
+			}
+		}
+	}
+
+	return unique(images), nil
+}
+
+func readKustomization(dir string) (*kustomization, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(dir, KustomizationFile))
+	if err != nil {
+		return nil, err
+	}
+
+	content := &kustomization{}
+	if err := yaml.Unmarshal(buf, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func unique(images []string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, image := range images {
+		if !seen[image] {
+			seen[image] = true
+			result = append(result, image)
+		}
+	}
+	return result
+}
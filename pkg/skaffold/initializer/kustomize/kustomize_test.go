@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestGenerateKustomizePipeline(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("base/kustomization.yaml", `resources:
+- pod.yaml
+`).
+			Write("base/pod.yaml", `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  containers:
+  - name: app
+    image: app
+  - name: sidecar
+    image: gcr.io/k8s-skaffold/sidecar
+`).
+			Write("dev/kustomization.yaml", `bases:
+- ../base
+images:
+- name: app
+  newName: gcr.io/k8s-skaffold/app
+`)
+		t.Chdir(tmpDir.Root())
+
+		k, err := New("dev")
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]string{"gcr.io/k8s-skaffold/app", "gcr.io/k8s-skaffold/sidecar"}, k.GetImages())
+		t.CheckDeepEqual(latest.DeployConfig{
+			DeployType: latest.DeployType{
+				KustomizeDeploy: &latest.KustomizeDeploy{KustomizePath: "dev"},
+			},
+		}, k.GenerateDeployConfig())
+	})
+}
+
+func TestOverlays(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("base/kustomization.yaml", "").
+			Write("dev/kustomization.yaml", "bases:\n- ../base").
+			Write("prod/kustomization.yaml", "resources:\n- ../base")
+		t.Chdir(tmpDir.Root())
+
+		overlays := Overlays([]string{"base", "dev", "prod"})
+
+		t.CheckDeepEqual([]string{"dev", "prod"}, overlays)
+	})
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kubectl"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// generatedManifest is a kubernetes manifest generated for an artifact.
+type generatedManifest struct {
+	path    string
+	content []byte
+}
+
+// promptImageNames asks the user for the name of the image built by each builder.
+func promptImageNames(builders []InitBuilder) []builderImagePair {
+	var pairs []builderImagePair
+	for _, b := range builders {
+		image := defaultImageName(b)
+		prompt := &survey.Input{
+			Message: fmt.Sprintf("Choose the image name to build with %s", b.Describe()),
+			Default: image,
+		}
+		survey.AskOne(prompt, &image, nil)
+
+		pairs = append(pairs, builderImagePair{Builder: b, ImageName: image})
+	}
+	return pairs
+}
+
+// defaultImageName is the image name configured by a builder or, by default, the name of its directory.
+func defaultImageName(b InitBuilder) string {
+	if image := b.ConfiguredImage(); image != "" {
+		return image
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(b.Path()))
+	if err != nil {
+		return kubectl.ManifestName(b.Path())
+	}
+	return kubectl.ManifestName(filepath.Base(dir))
+}
+
+// generateManifests generates a Deployment and a Service for each artifact, in the `k8s` directory.
+// The ports of the Service are the ones exposed by the Dockerfile.
+func generateManifests(pairs []builderImagePair, force bool) (Initializer, []generatedManifest, error) {
+	var manifests []generatedManifest
+	var paths, images []string
+	names := map[string]bool{}
+
+	for _, pair := range pairs {
+		var ports []docker.ExposedPort
+		if b, ok := pair.Builder.(dockerBuilder); ok {
+			var err error
+			if ports, err = docker.ExposedPorts(b.path); err != nil {
+				logrus.Warnf("listing the ports exposed by %s: %s", b.path, err)
+			}
+		}
+
+		name := kubectl.ManifestName(pair.ImageName)
+		for i := 2; names[name]; i++ {
+			name = fmt.Sprintf("%s-%d", kubectl.ManifestName(pair.ImageName), i)
+		}
+		names[name] = true
+
+		path := filepath.Join(kubectl.ManifestsDir, name+".yaml")
+		if _, err := os.Stat(path); err == nil && !force {
+			return nil, nil, fmt.Errorf("pre-existing %s found", path)
+		}
+
+		content, err := kubectl.GenerateManifest(name, pair.ImageName, ports)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		manifests = append(manifests, generatedManifest{path: path, content: content})
+		paths = append(paths, path)
+		images = append(images, pair.ImageName)
+	}
+
+	return kubectl.NewGenerated(paths, images), manifests, nil
+}
+
+func writeManifests(manifests []generatedManifest) error {
+	for _, m := range manifests {
+		if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+			return errors.Wrap(err, "creating manifests directory")
+		}
+		if err := ioutil.WriteFile(m.path, m.content, 0644); err != nil {
+			return errors.Wrap(err, "writing manifest")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initializer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/initializer/kubectl"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestGenerateManifests(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("web/Dockerfile", "FROM nginx\nEXPOSE 80").
+			Write("java/pom.xml", "")
+		t.Chdir(tmpDir.Root())
+
+		k, manifests, err := generateManifests([]builderImagePair{
+			{Builder: dockerBuilder{path: "web/Dockerfile"}, ImageName: "gcr.io/project/web"},
+			{Builder: jibBuilder{name: "Jib Maven Plugin", path: "java/pom.xml"}, ImageName: "web"},
+		}, false)
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]string{"gcr.io/project/web", "web"}, k.GetImages())
+		t.CheckDeepEqual(latest.DeployConfig{
+			DeployType: latest.DeployType{KubectlDeploy: &latest.KubectlDeploy{Manifests: []string{"k8s/web.yaml", "k8s/web-2.yaml"}}},
+		}, k.GenerateDeployConfig())
+
+		webManifest, _ := kubectl.GenerateManifest("web", "gcr.io/project/web", []docker.ExposedPort{{Port: 80, Protocol: "TCP"}})
+		t.CheckDeepEqual("k8s/web.yaml", manifests[0].path)
+		t.CheckDeepEqual(string(webManifest), string(manifests[0].content))
+	})
+}
+
+func TestGenerateManifestsPreExisting(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().Write("k8s/web.yaml", "")
+		t.Chdir(tmpDir.Root())
+		pairs := []builderImagePair{{Builder: dockerBuilder{path: "Dockerfile"}, ImageName: "web"}}
+
+		_, _, err := generateManifests(pairs, false)
+		t.CheckErrorContains("pre-existing k8s/web.yaml found", err)
+
+		_, _, err = generateManifests(pairs, true)
+		t.CheckNoError(err)
+	})
+}
+
+func TestDoInitGenerateManifests(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().Write("Dockerfile", "FROM nginx\nEXPOSE 80")
+		t.Chdir(tmpDir.Root())
+
+		var out bytes.Buffer
+		err := DoInit(&out, Config{
+			CliArtifacts:      []string{"Dockerfile=gcr.io/project/web"},
+			GenerateManifests: true,
+			Opts:              &config.SkaffoldOptions{ConfigurationFile: "-"},
+		})
+
+		t.CheckNoError(err)
+		t.CheckContains("manifests:\n    - k8s/web.yaml", out.String())
+		manifest, err := ioutil.ReadFile(tmpDir.Path("k8s/web.yaml"))
+		t.CheckNoError(err)
+		t.CheckContains("image: gcr.io/project/web", string(manifest))
+	})
+}
@@ -18,6 +18,7 @@ package kubernetes
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	v1 "k8s.io/api/core/v1"
@@ -45,7 +46,9 @@ type ColorPicker interface {
 }
 
 type colorPicker struct {
+	sync.Mutex
 	imageColors map[string]color.Color
+	next        int
 }
 
 // NewColorPicker creates a new ColorPicker. For each artifact, a color will be selected
@@ -61,21 +64,34 @@ func NewColorPicker(baseImageNames []string) ColorPicker {
 
 	return &colorPicker{
 		imageColors: imageColors,
+		next:        len(baseImageNames),
 	}
 }
 
 // Pick will return the color that was associated with pod when `NewColorPicker` was called.
-// If no color was associated with the pod, the none color will be returned, which will
-// write with no formatting.
+// Pods running none of these images, such as pods that Skaffold didn't build, are given the
+// next color, keyed by their first image. If the pod has no container, the none color will
+// be returned, which will write with no formatting.
 func (p *colorPicker) Pick(pod *v1.Pod) color.Color {
+	p.Lock()
+	defer p.Unlock()
+
 	for _, container := range pod.Spec.Containers {
 		if c, present := p.imageColors[stripTag(container.Image)]; present {
 			return c
 		}
 	}
 
-	// If no mapping is found, don't add any color formatting
-	return color.None
+	// If no container is found, don't add any color formatting
+	if len(pod.Spec.Containers) == 0 {
+		return color.None
+	}
+
+	c := colorCodes[p.next%len(colorCodes)]
+	p.imageColors[stripTag(pod.Spec.Containers[0].Image)] = c
+	p.next++
+
+	return c
 }
 
 func stripTag(image string) string {
@@ -68,6 +68,28 @@ func TestColorPicker(t *testing.T) {
 			},
 			expectedColor: colorCodes[1],
 		},
+		{
+			description: "not built by skaffold",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{Image: "redis:5"},
+					},
+				},
+			},
+			expectedColor: colorCodes[2],
+		},
+		{
+			description: "same image not built by skaffold",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{Image: "redis:6"},
+					},
+				},
+			},
+			expectedColor: colorCodes[2],
+		},
 	}
 
 	picker := NewColorPicker([]string{"image", "second"})
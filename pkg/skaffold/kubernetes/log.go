@@ -21,7 +21,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
@@ -45,20 +48,40 @@ type LogAggregator struct {
 	podSelector PodSelector
 	namespaces  []string
 	colorPicker ColorPicker
+	json        bool
+	dir         string
 
+	filterLock        sync.RWMutex
+	filter            *LogFilter
 	muted             int32
 	startTime         time.Time
 	cancel            context.CancelFunc
 	trackedContainers trackedContainers
 }
 
+// LogOptions configures how the logs are printed.
+type LogOptions struct {
+	// Filter chooses the containers whose logs are printed.
+	Filter *LogFilter
+
+	// JSON prints log lines that are JSON objects with their level coloured.
+	JSON bool
+
+	// Dir, when set, is a directory where the logs of each container
+	// are also written to a separate file.
+	Dir string
+}
+
 // NewLogAggregator creates a new LogAggregator for a given output.
-func NewLogAggregator(out io.Writer, baseImageNames []string, podSelector PodSelector, namespaces []string) *LogAggregator {
+func NewLogAggregator(out io.Writer, baseImageNames []string, podSelector PodSelector, namespaces []string, opts LogOptions) *LogAggregator {
 	return &LogAggregator{
 		output:      out,
 		podSelector: podSelector,
 		namespaces:  namespaces,
 		colorPicker: NewColorPicker(baseImageNames),
+		json:        opts.JSON,
+		dir:         opts.Dir,
+		filter:      opts.Filter,
 		trackedContainers: trackedContainers{
 			ids: map[string]bool{},
 		},
@@ -72,6 +95,13 @@ func (a *LogAggregator) Start(ctx context.Context) error {
 	a.cancel = cancel
 	a.startTime = time.Now()
 
+	if a.dir != "" {
+		if err := os.MkdirAll(a.dir, 0755); err != nil {
+			cancel()
+			return errors.Wrapf(err, "creating log directory %s", a.dir)
+		}
+	}
+
 	aggregate := make(chan watch.Event)
 	stopWatchers, err := AggregatePodWatcher(a.namespaces, aggregate)
 	if err != nil {
@@ -158,16 +188,43 @@ func (a *LogAggregator) streamContainerLogs(ctx context.Context, pod *v1.Pod, co
 	cmd.Stdout = tw
 	go util.RunCmd(cmd)
 
-	color := a.colorPicker.Pick(pod)
-	prefix := prefix(pod, container)
+	stream := logStream{
+		pod:       pod,
+		container: container.Name,
+		header:    prefix(pod, container),
+		color:     a.colorPicker.Pick(pod),
+	}
+
+	if a.dir != "" {
+		path := filepath.Join(a.dir, fmt.Sprintf("%s_%s.log", pod.Name, container.Name))
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			logrus.Warnf("Unable to write logs to %s: %s", path, err)
+		} else {
+			stream.file = file
+		}
+	}
+
 	go func() {
-		if err := a.streamRequest(ctx, color, prefix, tr); err != nil {
+		if err := a.streamRequest(ctx, stream, tr); err != nil {
 			logrus.Errorf("streaming request %s", err)
 		}
+		if stream.file != nil {
+			stream.file.Close()
+		}
 		a.trackedContainers.remove(container.ContainerID)
 	}()
 }
 
+// logStream describes where the logs of a container are printed.
+type logStream struct {
+	pod       *v1.Pod
+	container string
+	header    string
+	color     color.Color
+	file      *os.File
+}
+
 func prefix(pod *v1.Pod, container v1.ContainerStatus) string {
 	if pod.Name != container.Name {
 		return fmt.Sprintf("[%s %s]", pod.Name, container.Name)
@@ -175,12 +232,16 @@ func prefix(pod *v1.Pod, container v1.ContainerStatus) string {
 	return fmt.Sprintf("[%s]", container.Name)
 }
 
-func (a *LogAggregator) streamRequest(ctx context.Context, headerColor color.Color, header string, rc io.Reader) error {
+func (a *LogAggregator) streamRequest(ctx context.Context, stream logStream, rc io.Reader) error {
+	// The filter is only evaluated again when it's changed.
+	lastFilter := a.Filter()
+	show := lastFilter.Show(stream.pod, stream.container)
+
 	r := bufio.NewReader(rc)
 	for {
 		select {
 		case <-ctx.Done():
-			logrus.Infof("%s interrupted", header)
+			logrus.Infof("%s interrupted", stream.header)
 			return nil
 		default:
 		}
@@ -194,21 +255,56 @@ func (a *LogAggregator) streamRequest(ctx context.Context, headerColor color.Col
 			return errors.Wrap(err, "reading bytes from log stream")
 		}
 
-		if a.IsMuted() {
+		if stream.file != nil {
+			if _, err := stream.file.Write(line); err != nil {
+				return errors.Wrap(err, "writing pod log to file")
+			}
+		}
+
+		if filter := a.Filter(); filter != lastFilter {
+			lastFilter = filter
+			show = filter.Show(stream.pod, stream.container)
+		}
+		if !show || a.IsMuted() {
 			continue
 		}
 
-		if _, err := headerColor.Fprintf(a.output, "%s ", header); err != nil {
+		if _, err := stream.color.Fprintf(a.output, "%s ", stream.header); err != nil {
 			return errors.Wrap(err, "writing pod prefix header to out")
 		}
-		if _, err := fmt.Fprint(a.output, string(line)); err != nil {
+		if err := a.printLine(line); err != nil {
 			return errors.Wrap(err, "writing pod log to out")
 		}
 	}
-	logrus.Infof("%s exited", header)
+	logrus.Infof("%s exited", stream.header)
 	return nil
 }
 
+func (a *LogAggregator) printLine(line []byte) error {
+	if a.json {
+		if parsed, ok := parseStructuredLine(line); ok {
+			return parsed.print(a.output)
+		}
+	}
+
+	_, err := fmt.Fprint(a.output, string(line))
+	return err
+}
+
+// SetFilter changes the filter that chooses the containers whose logs are printed.
+func (a *LogAggregator) SetFilter(filter *LogFilter) {
+	a.filterLock.Lock()
+	a.filter = filter
+	a.filterLock.Unlock()
+}
+
+// Filter returns the filter that chooses the containers whose logs are printed.
+func (a *LogAggregator) Filter() *LogFilter {
+	a.filterLock.RLock()
+	defer a.filterLock.RUnlock()
+	return a.filter
+}
+
 // Mute mutes the logs.
 func (a *LogAggregator) Mute() {
 	atomic.StoreInt32(&a.muted, 1)
@@ -291,3 +387,50 @@ func (l *ImageList) Select(pod *v1.Pod) bool {
 
 	return false
 }
+
+// LabelSelector implements PodSelector based on a list of label selectors.
+type LabelSelector struct {
+	selectors []labels.Selector
+}
+
+// NewLabelSelector creates a LabelSelector from selectors such as `app=redis`.
+func NewLabelSelector(selectors []string) (*LabelSelector, error) {
+	parsed := make([]labels.Selector, len(selectors))
+
+	for i, selector := range selectors {
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing label selector %s", selector)
+		}
+		parsed[i] = s
+	}
+
+	return &LabelSelector{
+		selectors: parsed,
+	}, nil
+}
+
+// Select returns true if the pod's labels match one of the selectors.
+func (s *LabelSelector) Select(pod *v1.Pod) bool {
+	for _, selector := range s.selectors {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PodSelectors implements PodSelector by combining other selectors.
+type PodSelectors []PodSelector
+
+// Select returns true if one of the selectors selects the pod.
+func (s PodSelectors) Select(pod *v1.Pod) bool {
+	for _, selector := range s {
+		if selector.Select(pod) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// LogFilter decides which containers have their logs printed.
+// A filter is a regular expression, optionally prefixed with `image=`,
+// `pod=` or `container=` to match only against that field. Without prefix,
+// it matches if any of the three fields matches.
+type LogFilter struct {
+	include []logRule
+	exclude []logRule
+}
+
+type logRule struct {
+	field string
+	re    *regexp.Regexp
+}
+
+// NewLogFilter creates a LogFilter. A container is shown if it matches one of
+// the include filters, or if there are none, and if it matches none of the exclude filters.
+func NewLogFilter(include, exclude []string) (*LogFilter, error) {
+	includeRules, err := parseLogRules(include)
+	if err != nil {
+		return nil, err
+	}
+	excludeRules, err := parseLogRules(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogFilter{
+		include: includeRules,
+		exclude: excludeRules,
+	}, nil
+}
+
+func parseLogRules(filters []string) ([]logRule, error) {
+	var rules []logRule
+
+	for _, filter := range filters {
+		field, expr := "", filter
+		if i := strings.Index(filter, "="); i > 0 {
+			switch filter[:i] {
+			case "image", "pod", "container":
+				field, expr = filter[:i], filter[i+1:]
+			}
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid log filter %s", filter)
+		}
+
+		rules = append(rules, logRule{field: field, re: re})
+	}
+
+	return rules, nil
+}
+
+// Show returns true if the logs of the given container should be printed.
+func (f *LogFilter) Show(pod *v1.Pod, container string) bool {
+	if f == nil {
+		return true
+	}
+
+	image := containerImage(pod, container)
+
+	if len(f.include) > 0 && !matchAny(f.include, pod.Name, container, image) {
+		return false
+	}
+	return !matchAny(f.exclude, pod.Name, container, image)
+}
+
+func matchAny(rules []logRule, pod, container, image string) bool {
+	for _, rule := range rules {
+		if rule.matches(pod, container, image) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r logRule) matches(pod, container, image string) bool {
+	switch r.field {
+	case "image":
+		return r.re.MatchString(image)
+	case "pod":
+		return r.re.MatchString(pod)
+	case "container":
+		return r.re.MatchString(container)
+	default:
+		return r.re.MatchString(image) || r.re.MatchString(pod) || r.re.MatchString(container)
+	}
+}
+
+func containerImage(pod *v1.Pod, name string) string {
+	for _, container := range append(pod.Spec.Containers, pod.Spec.InitContainers...) {
+		if container.Name == name {
+			return container.Image
+		}
+	}
+	return ""
+}
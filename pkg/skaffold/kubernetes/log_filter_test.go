@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLogFilter(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-7d4f"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init", Image: "busybox"}},
+			Containers: []v1.Container{
+				{Name: "web", Image: "gcr.io/project/web:v1"},
+				{Name: "proxy", Image: "envoy"},
+			},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		include     []string
+		exclude     []string
+		expected    map[string]bool
+	}{
+		{
+			description: "no filter",
+			expected:    map[string]bool{"init": true, "web": true, "proxy": true},
+		},
+		{
+			description: "include by image",
+			include:     []string{"image=gcr.io/project/"},
+			expected:    map[string]bool{"init": false, "web": true, "proxy": false},
+		},
+		{
+			description: "include by container",
+			include:     []string{"container=^(init|proxy)$"},
+			expected:    map[string]bool{"init": true, "web": false, "proxy": true},
+		},
+		{
+			description: "include by pod",
+			include:     []string{"pod=^db-"},
+			expected:    map[string]bool{"init": false, "web": false, "proxy": false},
+		},
+		{
+			description: "exclude matches any field",
+			exclude:     []string{"envoy", "init"},
+			expected:    map[string]bool{"init": false, "web": true, "proxy": false},
+		},
+		{
+			description: "exclude wins over include",
+			include:     []string{"pod=web"},
+			exclude:     []string{"container=proxy"},
+			expected:    map[string]bool{"init": true, "web": true, "proxy": false},
+		},
+		{
+			description: "unknown prefix is part of the regexp",
+			include:     []string{"name=web"},
+			expected:    map[string]bool{"init": false, "web": false, "proxy": false},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			filter, err := NewLogFilter(test.include, test.exclude)
+			t.CheckNoError(err)
+
+			shown := map[string]bool{}
+			for _, container := range []string{"init", "web", "proxy"} {
+				shown[container] = filter.Show(pod, container)
+			}
+
+			t.CheckDeepEqual(test.expected, shown)
+		})
+	}
+}
+
+func TestLogFilterInvalid(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		_, err := NewLogFilter(nil, []string{"image=("})
+
+		t.CheckErrorContains("invalid log filter image=(", err)
+	})
+}
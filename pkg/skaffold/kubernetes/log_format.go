@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+)
+
+var (
+	levelKeys   = []string{"level", "severity", "lvl"}
+	messageKeys = []string{"msg", "message"}
+	timeKeys    = []string{"time", "timestamp", "ts"}
+)
+
+// structuredLine is a log line that was printed as a JSON object.
+type structuredLine struct {
+	level   string
+	message string
+	fields  []string
+}
+
+// parseStructuredLine parses a log line that is a JSON object.
+// It returns false for any other line.
+func parseStructuredLine(line []byte) (*structuredLine, bool) {
+	line = bytes.TrimSpace(line)
+	if !bytes.HasPrefix(line, []byte("{")) {
+		return nil, false
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(line, &values); err != nil {
+		return nil, false
+	}
+
+	parsed := &structuredLine{
+		level:   popString(values, levelKeys),
+		message: popString(values, messageKeys),
+	}
+	popString(values, timeKeys)
+
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		parsed.fields = append(parsed.fields, fmt.Sprintf("%s=%s", key, formatValue(values[key])))
+	}
+
+	return parsed, true
+}
+
+// popString removes the first of the given keys found in the values
+// and returns its value as a string.
+func popString(values map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		if value, present := values[key]; present {
+			delete(values, key)
+			if s, ok := value.(string); ok {
+				return s
+			}
+			return formatValue(value)
+		}
+	}
+	return ""
+}
+
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		if strings.ContainsAny(s, " \t\n\"") {
+			return strconv.Quote(s)
+		}
+		return s
+	}
+
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(buf)
+}
+
+// print prints the line with its level coloured.
+func (l *structuredLine) print(out io.Writer) error {
+	if l.level != "" {
+		if _, err := levelColor(l.level).Fprintf(out, "%-5s ", strings.ToUpper(l.level)); err != nil {
+			return err
+		}
+	}
+
+	line := strings.Join(append([]string{l.message}, l.fields...), " ")
+	_, err := fmt.Fprintln(out, strings.TrimSpace(line))
+	return err
+}
+
+func levelColor(level string) color.Color {
+	switch strings.ToLower(level) {
+	case "error", "fatal", "panic", "critical", "alert", "emergency":
+		return color.Red
+	case "warn", "warning":
+		return color.Yellow
+	case "info", "notice":
+		return color.Green
+	default:
+		return color.None
+	}
+}
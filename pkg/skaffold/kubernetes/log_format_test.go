@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseStructuredLine(t *testing.T) {
+	var tests = []struct {
+		description string
+		line        string
+		expected    *structuredLine
+	}{
+		{
+			description: "not json",
+			line:        "Listening on port 8080\n",
+		},
+		{
+			description: "json array",
+			line:        "[1, 2]\n",
+		},
+		{
+			description: "invalid json",
+			line:        "{not json}\n",
+		},
+		{
+			description: "logrus",
+			line:        `{"level":"info","msg":"Listening","port":8080,"time":"2019-06-01T10:00:00Z"}` + "\n",
+			expected: &structuredLine{
+				level:   "info",
+				message: "Listening",
+				fields:  []string{"port=8080"},
+			},
+		},
+		{
+			description: "stackdriver",
+			line:        `{"severity":"ERROR","message":"request failed","path":"/api v1","labels":{"a":"b"}}`,
+			expected: &structuredLine{
+				level:   "ERROR",
+				message: "request failed",
+				fields:  []string{`labels={"a":"b"}`, `path="/api v1"`},
+			},
+		},
+		{
+			description: "no level",
+			line:        `{"msg":"started"}`,
+			expected: &structuredLine{
+				message: "started",
+			},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			parsed, ok := parseStructuredLine([]byte(test.line))
+
+			t.CheckDeepEqual(test.expected != nil, ok)
+			if test.expected != nil {
+				t.CheckDeepEqual(*test.expected, *parsed, cmp.AllowUnexported(structuredLine{}))
+			}
+		})
+	}
+}
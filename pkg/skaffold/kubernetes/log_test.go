@@ -17,10 +17,17 @@ limitations under the License.
 package kubernetes
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestSinceSeconds(t *testing.T) {
@@ -49,3 +56,133 @@ func TestSinceSeconds(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamRequest(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "web", Image: "web"}},
+		},
+	}
+	logs := "plain line\n" + `{"level":"warn","msg":"disk almost full","time":"2019-06-01T10:00:00Z","used":0.93}` + "\n"
+
+	var tests = []struct {
+		description string
+		filter      []string
+		json        bool
+		muted       bool
+		expected    string
+	}{
+		{
+			description: "raw",
+			expected:    "[pod web] plain line\n" + `[pod web] {"level":"warn","msg":"disk almost full","time":"2019-06-01T10:00:00Z","used":0.93}` + "\n",
+		},
+		{
+			description: "json",
+			json:        true,
+			expected:    "[pod web] plain line\n[pod web] WARN  disk almost full used=0.93\n",
+		},
+		{
+			description: "excluded",
+			filter:      []string{"image=web"},
+		},
+		{
+			description: "muted",
+			muted:       true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			tmpDir := t.NewTempDir()
+			filter, err := NewLogFilter(nil, test.filter)
+			t.CheckNoError(err)
+
+			var out bytes.Buffer
+			logger := NewLogAggregator(&out, nil, nil, nil, LogOptions{Filter: filter, JSON: test.json})
+			if test.muted {
+				logger.Mute()
+			}
+
+			file, err := ioutil.TempFile(tmpDir.Root(), "log")
+			t.CheckNoError(err)
+			defer file.Close()
+
+			err = logger.streamRequest(context.Background(), logStream{
+				pod:       pod,
+				container: "web",
+				header:    "[pod web]",
+				color:     color.None,
+				file:      file,
+			}, strings.NewReader(logs))
+			t.CheckNoError(err)
+
+			written, err := ioutil.ReadFile(file.Name())
+			t.CheckNoError(err)
+			t.CheckDeepEqual(test.expected, out.String())
+			t.CheckDeepEqual(logs, string(written))
+		})
+	}
+}
+
+func TestLabelSelector(t *testing.T) {
+	var tests = []struct {
+		description string
+		selectors   []string
+		labels      map[string]string
+		shouldErr   bool
+		expected    bool
+	}{
+		{
+			description: "equality",
+			selectors:   []string{"app=redis"},
+			labels:      map[string]string{"app": "redis", "tier": "backend"},
+			expected:    true,
+		},
+		{
+			description: "set based",
+			selectors:   []string{"app=web", "release in (db, cache)"},
+			labels:      map[string]string{"release": "cache"},
+			expected:    true,
+		},
+		{
+			description: "no match",
+			selectors:   []string{"app=redis"},
+			labels:      map[string]string{"app": "web"},
+		},
+		{
+			description: "no selector",
+			labels:      map[string]string{"app": "web"},
+		},
+		{
+			description: "invalid selector",
+			selectors:   []string{"app in redis"},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			selector, err := NewLabelSelector(test.selectors)
+			t.CheckError(test.shouldErr, err)
+			if test.shouldErr {
+				return
+			}
+
+			pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: test.labels}}
+			t.CheckDeepEqual(test.expected, selector.Select(pod))
+		})
+	}
+}
+
+func TestPodSelectors(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		images := NewImageList()
+		images.Add("web:v1")
+		labels, err := NewLabelSelector([]string{"app=redis"})
+		t.CheckNoError(err)
+		selector := PodSelectors{images, labels}
+
+		t.CheckDeepEqual(true, selector.Select(&v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Image: "web:v1"}}}}))
+		t.CheckDeepEqual(true, selector.Select(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "redis"}}}))
+		t.CheckDeepEqual(false, selector.Select(&v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Image: "db"}}}}))
+	})
+}
@@ -17,16 +17,14 @@ limitations under the License.
 package kubernetes
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"os/exec"
 	"strconv"
 	"sync"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
-	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -35,30 +33,52 @@ import (
 )
 
 // PortForwarder is responsible for selecting pods satisfying a certain condition and port-forwarding the exposed
-// container ports within those pods. It also tracks and manages the port-forward connections.
+// container ports within those pods. When resources are declared in the `portForward` section of the configuration,
+// only those resources are forwarded. It also tracks and manages the port-forward connections.
 type PortForwarder struct {
 	Forwarder
 
 	output      io.Writer
 	podSelector PodSelector
 	namespaces  []string
+	resources   []*resourceForward
 
 	// forwardedPods is a map of portForwardEntry.key() (string) -> portForwardEntry
 	forwardedPods map[string]*portForwardEntry
+	lock          sync.Mutex
 
 	// forwardedPorts serves as a synchronized set of ports we've forwarded.
 	forwardedPorts *sync.Map
+
+	// portNames, if set, restricts the container ports forwarded to those with one of these names
+	portNames []string
+	// onDisconnect, if set, is called when the last local connection to a forwarded port is closed
+	onDisconnect func()
+
+	cancel context.CancelFunc
 }
 
 type portForwardEntry struct {
 	resourceVersion int
-	podName         string
 	namespace       string
 	containerName   string
 	portName        string
-	port            int32
 	localPort       int32
 
+	// podName, port and image change when the forward reconnects to another pod
+	podLock sync.Mutex
+	podName string
+	port    int32
+	image   string
+
+	// connections is the number of open local connections
+	connections connectionCount
+
+	// resolve finds the pod and the port to forward to when the connection
+	// to the current pod is lost. When nil, the same pod is forwarded again
+	// until it is gone.
+	resolve func(current string) (*v1.Pod, int32, error)
+
 	cancel context.CancelFunc
 }
 
@@ -68,66 +88,52 @@ type Forwarder interface {
 	Terminate(*portForwardEntry)
 }
 
-type kubectlForwarder struct{}
-
 var (
 	// For testing
 	retrieveAvailablePort = util.GetAvailablePort
 )
 
-// Forward port-forwards a pod using kubectl port-forward
-// It returns an error only if the process fails or was terminated by a signal other than SIGTERM
-func (*kubectlForwarder) Forward(parentCtx context.Context, pfe *portForwardEntry) error {
-	logrus.Debugf("Port forwarding %s", pfe)
-
-	ctx, cancel := context.WithCancel(parentCtx)
-	pfe.cancel = cancel
-
-	cmd := exec.CommandContext(ctx, "kubectl", "port-forward", pfe.podName, fmt.Sprintf("%d:%d", pfe.localPort, pfe.port), "--namespace", pfe.namespace)
-	buf := &bytes.Buffer{}
-	cmd.Stdout = buf
-	cmd.Stderr = buf
-
-	if err := cmd.Start(); err != nil {
-		if errors.Cause(err) == context.Canceled {
-			return nil
-		}
-		return errors.Wrapf(err, "port forwarding pod: %s/%s, port: %d to local port: %d, err: %s", pfe.namespace, pfe.podName, pfe.port, pfe.localPort, buf.String())
-	}
-
-	event.PortForwarded(pfe.localPort, pfe.port, pfe.podName, pfe.containerName, pfe.namespace, pfe.portName)
-
-	go cmd.Wait()
-
-	return nil
-}
-
-// Terminate terminates an existing kubectl port-forward command using SIGTERM
-func (*kubectlForwarder) Terminate(p *portForwardEntry) {
-	logrus.Debugf("Terminating port-forward %s", p)
-
-	if p.cancel != nil {
-		p.cancel()
-	}
-}
-
 // NewPortForwarder returns a struct that tracks and port-forwards pods as they are created and modified
-func NewPortForwarder(out io.Writer, podSelector PodSelector, namespaces []string) *PortForwarder {
+func NewPortForwarder(out io.Writer, podSelector PodSelector, namespaces []string, resources []*latest.PortForwardResource) *PortForwarder {
 	return &PortForwarder{
-		Forwarder:      &kubectlForwarder{},
+		Forwarder:      &clientForwarder{output: out},
 		output:         out,
 		podSelector:    podSelector,
 		namespaces:     namespaces,
+		resources:      newResourceForwards(resources, namespaces),
 		forwardedPods:  make(map[string]*portForwardEntry),
 		forwardedPorts: &sync.Map{},
 	}
 }
 
-// Stop terminates all kubectl port-forward commands.
+// ForwardOnly restricts the forwarded container ports to those with one of the given names.
+func (p *PortForwarder) ForwardOnly(portNames []string) {
+	p.portNames = portNames
+}
+
+// OnDisconnect registers a function that is called when the last local connection
+// to a forwarded port is closed. It must be called before the PortForwarder is started.
+func (p *PortForwarder) OnDisconnect(onDisconnect func()) {
+	p.onDisconnect = onDisconnect
+}
+
+// Stop stops watching pods and terminates all kubectl port-forward commands.
 func (p *PortForwarder) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
 	for _, entry := range p.forwardedPods {
 		p.Terminate(entry)
 	}
+	for _, r := range p.resources {
+		if r.entry != nil {
+			p.Terminate(r.entry)
+		}
+	}
 }
 
 // Start begins a pod watcher that port forwards any pods involving containers with exposed ports.
@@ -139,6 +145,7 @@ func (p *PortForwarder) Start(ctx context.Context) error {
 		stopWatchers()
 		return errors.Wrap(err, "initializing pod watcher")
 	}
+	ctx, p.cancel = context.WithCancel(ctx)
 
 	go func() {
 		defer stopWatchers()
@@ -162,6 +169,11 @@ func (p *PortForwarder) Start(ctx context.Context) error {
 				if !ok {
 					continue
 				}
+				// Declared resources are forwarded to another pod when their pod is deleted.
+				if len(p.resources) > 0 {
+					p.portForwardResources(ctx, pod)
+					continue
+				}
 				// If the event's type is "DELETED", continue.
 				if evt.Type == watch.Deleted {
 					continue
@@ -189,6 +201,9 @@ func (p *PortForwarder) portForwardPod(ctx context.Context, pod *v1.Pod) error {
 
 	for _, c := range pod.Spec.Containers {
 		for _, port := range c.Ports {
+			if len(p.portNames) > 0 && !util.StrSliceContains(p.portNames, port.Name) {
+				continue
+			}
 			// get current entry for this container
 			entry := p.getCurrentEntry(pod, c, port, resourceVersion)
 			if entry.port != entry.localPort {
@@ -209,11 +224,15 @@ func (p *PortForwarder) getCurrentEntry(pod *v1.Pod, c v1.Container, port v1.Con
 		podName:         pod.Name,
 		namespace:       pod.Namespace,
 		containerName:   c.Name,
+		image:           c.Image,
 		portName:        port.Name,
 		port:            port.ContainerPort,
+		connections:     connectionCount{closed: p.onDisconnect},
 	}
 	// If we have, return the current entry
+	p.lock.Lock()
 	oldEntry, ok := p.forwardedPods[entry.key()]
+	p.lock.Unlock()
 	if ok {
 		entry.localPort = oldEntry.localPort
 		return entry
@@ -225,15 +244,18 @@ func (p *PortForwarder) getCurrentEntry(pod *v1.Pod, c v1.Container, port v1.Con
 }
 
 func (p *PortForwarder) forward(ctx context.Context, entry *portForwardEntry) error {
+	p.lock.Lock()
 	if prevEntry, ok := p.forwardedPods[entry.key()]; ok {
 		// Check if this is a new generation of pod
 		if entry.resourceVersion > prevEntry.resourceVersion {
 			p.Terminate(prevEntry)
 		}
 	}
-
-	color.Default.Fprintln(p.output, fmt.Sprintf("Port Forwarding %s/%s %d -> %d", entry.podName, entry.containerName, entry.port, entry.localPort))
 	p.forwardedPods[entry.key()] = entry
+	p.lock.Unlock()
+
+	podName, port := entry.pod()
+	color.Default.Fprintln(p.output, fmt.Sprintf("Port Forwarding %s/%s %d -> %d", podName, entry.containerName, port, entry.localPort))
 
 	if err := p.Forward(ctx, entry); err != nil {
 		return errors.Wrap(err, "port forwarding failed")
@@ -241,12 +263,63 @@ func (p *PortForwarder) forward(ctx context.Context, entry *portForwardEntry) er
 	return nil
 }
 
+// ConnectedImages returns the images of the containers that have open local connections
+// on forwarded ports with one of the given names, either forwarded pod ports or declared resources.
+func (p *PortForwarder) ConnectedImages(portNames []string) []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var entries []*portForwardEntry
+	for _, entry := range p.forwardedPods {
+		entries = append(entries, entry)
+	}
+	for _, r := range p.resources {
+		if r.entry != nil {
+			entries = append(entries, r.entry)
+		}
+	}
+
+	var images []string
+	for _, entry := range entries {
+		image := entry.containerImage()
+		if image == "" || !util.StrSliceContains(portNames, entry.portName) {
+			continue
+		}
+		if entry.connections.value() > 0 && !util.StrSliceContains(images, image) {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
 // Key is an identifier for the lock on a port during the skaffold dev cycle.
 func (p *portForwardEntry) key() string {
 	return fmt.Sprintf("%s-%s-%s-%d", p.containerName, p.namespace, p.portName, p.port)
 }
 
+// pod returns the pod and the port currently forwarded.
+func (p *portForwardEntry) pod() (string, int32) {
+	p.podLock.Lock()
+	defer p.podLock.Unlock()
+	return p.podName, p.port
+}
+
+// containerImage returns the image of the container forwarded.
+func (p *portForwardEntry) containerImage() string {
+	p.podLock.Lock()
+	defer p.podLock.Unlock()
+	return p.image
+}
+
+// setPod changes the pod, the port and the container image forwarded.
+func (p *portForwardEntry) setPod(podName string, port int32, image string) {
+	p.podLock.Lock()
+	p.podName, p.port, p.image = podName, port, image
+	p.podLock.Unlock()
+}
+
 // String is a utility function that returns the port forward entry as a user-readable string
 func (p *portForwardEntry) String() string {
-	return fmt.Sprintf("%s/%s/%s:%d", p.podName, p.containerName, p.portName, p.port)
+	podName, port := p.pod()
+	return fmt.Sprintf("%s/%s/%s:%d", podName, p.containerName, p.portName, port)
 }
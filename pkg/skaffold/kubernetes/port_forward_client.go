@@ -0,0 +1,303 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+var (
+	// For testing
+	forwardPod         = forwardPodWithClient
+	minReconnectDelay  = 500 * time.Millisecond
+	maxReconnectDelay  = 15 * time.Second
+	podCheckInterval   = 2 * time.Second
+	podGone            = isPodGone
+	errConnectionLost  = errors.New("lost connection to pod")
+	errPodNotAvailable = errors.New("pod is not running")
+)
+
+// clientForwarder port-forwards pods through the Kubernetes API server.
+// When the connection to a pod is lost, it finds the pod to forward to again and
+// reconnects with backoff, keeping the same local port.
+type clientForwarder struct {
+	output io.Writer
+}
+
+// Forward starts port-forwarding a pod in the background.
+func (f *clientForwarder) Forward(parentCtx context.Context, pfe *portForwardEntry) error {
+	logrus.Debugf("Port forwarding %s", pfe)
+
+	ctx, cancel := context.WithCancel(parentCtx)
+	pfe.cancel = cancel
+
+	go f.forwardWithReconnect(ctx, pfe)
+
+	return nil
+}
+
+// Terminate stops port-forwarding a pod.
+func (*clientForwarder) Terminate(p *portForwardEntry) {
+	logrus.Debugf("Terminating port-forward %s", p)
+
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (f *clientForwarder) forwardWithReconnect(ctx context.Context, pfe *portForwardEntry) {
+	podName, port := pfe.pod()
+	delay := minReconnectDelay
+	connected := false
+
+	for {
+		ready := make(chan struct{})
+		done := make(chan struct{})
+		go func(podName string, port int32, reconnected bool) {
+			select {
+			case <-ready:
+			case <-done:
+				return
+			}
+			if reconnected {
+				color.Default.Fprintln(f.output, fmt.Sprintf("Port forwarding of %s reconnected to pod %s", pfe, podName))
+			}
+			event.PortForwarded(pfe.localPort, port, podName, pfe.containerName, pfe.namespace, pfe.portName)
+			metrics.PortForwarded(pfe.namespace, pfe.containerName, pfe.portName)
+		}(podName, port, connected)
+
+		start := time.Now()
+		err := forwardPod(ctx, pfe.namespace, podName, pfe.localPort, port, ready, &pfe.connections)
+		close(done)
+		if ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-ready:
+			color.Yellow.Fprintf(f.output, "Port forwarding of %s on local port %d was interrupted: %s. Reconnecting...\n", pfe, pfe.localPort, err)
+			connected = true
+			// Only back off further when the connection is lost right after being established.
+			if time.Since(start) > maxReconnectDelay {
+				delay = minReconnectDelay
+			}
+		default:
+			logrus.Debugf("Unable to port forward %s: %s", pfe, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+
+		// A pod that is gone won't come back. Its replacement is forwarded by the PortForwarder.
+		if pfe.resolve == nil {
+			if podGone(pfe.namespace, podName) {
+				logrus.Debugf("Stopping port forwarding %s: the pod is gone", pfe)
+				return
+			}
+			continue
+		}
+
+		pod, newPort, err := pfe.resolve(podName)
+		if err != nil {
+			logrus.Debugf("Unable to find a pod for %s: %s", pfe, err)
+			continue
+		}
+		podName, port = pod.Name, newPort
+		pfe.setPod(podName, port, containerImage(pod, pfe.containerName))
+	}
+}
+
+// isPodGone tells whether a pod was deleted or has terminated.
+func isPodGone(namespace, podName string) bool {
+	client, err := Client()
+	if err != nil {
+		return false
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return apierrs.IsNotFound(err)
+	}
+	return pod.DeletionTimestamp != nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+}
+
+// forwardPodWithClient forwards a local port to a pod's port until the connection
+// is lost, the pod stops running or the context is cancelled. ready is closed
+// once the local port is listening. connections is updated with the number
+// of open local connections.
+func forwardPodWithClient(ctx context.Context, namespace, podName string, localPort, port int32, ready chan struct{}, connections *connectionCount) error {
+	config, err := getClientConfig()
+	if err != nil {
+		return errors.Wrap(err, "getting client config")
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "getting k8s client")
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return errors.Wrap(err, "creating spdy round tripper")
+	}
+
+	url := client.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward").URL()
+	dialer := &countingDialer{
+		Dialer:      spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url),
+		connections: connections,
+	}
+
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	var stopErr error
+	stopForwarding := func(err error) {
+		stopOnce.Do(func() {
+			stopErr = err
+			close(stop)
+		})
+	}
+	defer stopForwarding(nil)
+
+	forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("%d:%d", localPort, port)}, stop, ready, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return errors.Wrap(err, "creating port forwarder")
+	}
+
+	// The connection is not always closed when the pod goes away so its status is checked regularly.
+	go func() {
+		ticker := time.NewTicker(podCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				stopForwarding(nil)
+				return
+			case <-ticker.C:
+				pod, err := client.CoreV1().Pods(namespace).Get(podName, metav1.GetOptions{})
+				if err != nil || !isRunning(pod) {
+					stopForwarding(errPodNotAvailable)
+					return
+				}
+			}
+		}
+	}()
+
+	if err := forwarder.ForwardPorts(); err != nil {
+		return err
+	}
+
+	stopForwarding(errConnectionLost)
+	return stopErr
+}
+
+// connectionCount is the number of open local connections of a port forward.
+type connectionCount struct {
+	count int32
+	// closed, if set, is called when the last open connection is closed
+	closed func()
+}
+
+func (c *connectionCount) open() {
+	atomic.AddInt32(&c.count, 1)
+}
+
+func (c *connectionCount) close() {
+	if atomic.AddInt32(&c.count, -1) == 0 && c.closed != nil {
+		c.closed()
+	}
+}
+
+func (c *connectionCount) value() int32 {
+	return atomic.LoadInt32(&c.count)
+}
+
+// countingDialer counts the data streams opened on the connections it dials.
+// The port forwarder opens a data stream for each local connection and closes
+// it once the local connection is closed, so this is the number of open local connections.
+type countingDialer struct {
+	httpstream.Dialer
+	connections *connectionCount
+}
+
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, "", err
+	}
+	return &countingConnection{Connection: conn, connections: d.connections}, protocol, nil
+}
+
+type countingConnection struct {
+	httpstream.Connection
+	connections *connectionCount
+}
+
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil || headers.Get(v1.StreamType) != v1.StreamTypeData {
+		return stream, err
+	}
+	c.connections.open()
+	return &countingStream{Stream: stream, connections: c.connections}, nil
+}
+
+type countingStream struct {
+	httpstream.Stream
+	connections *connectionCount
+	closed      sync.Once
+}
+
+func (s *countingStream) Close() error {
+	s.release()
+	return s.Stream.Close()
+}
+
+func (s *countingStream) Reset() error {
+	s.release()
+	return s.Stream.Reset()
+}
+
+func (s *countingStream) release() {
+	s.closed.Do(s.connections.close)
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+const (
+	dialFailure = iota
+	connectionLost
+	connected
+)
+
+// fakeConnections plays a connection outcome for each call to forwardPod.
+type fakeConnections struct {
+	sync.Mutex
+	outcomes []int
+	calls    []string
+}
+
+func (f *fakeConnections) forwardPod(ctx context.Context, namespace, podName string, localPort, port int32, ready chan struct{}, connections *connectionCount) error {
+	f.Lock()
+	outcome := f.outcomes[len(f.calls)]
+	f.calls = append(f.calls, fmt.Sprintf("%s/%s %d->%d", namespace, podName, localPort, port))
+	f.Unlock()
+
+	switch outcome {
+	case dialFailure:
+		return errors.New("unable to dial")
+	case connectionLost:
+		close(ready)
+		return errConnectionLost
+	default:
+		close(ready)
+		<-ctx.Done()
+		return nil
+	}
+}
+
+func (f *fakeConnections) waitForCalls(t *testutil.T) []string {
+	timeout := time.After(5 * time.Second)
+	for {
+		f.Lock()
+		calls := append([]string{}, f.calls...)
+		f.Unlock()
+		if len(calls) == len(f.outcomes) {
+			return calls
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("expected %d connections, got %d", len(f.outcomes), len(calls))
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestForwardWithReconnect(t *testing.T) {
+	event.InitializeState(&runcontext.RunContext{
+		Cfg:  &latest.Pipeline{},
+		Opts: &config.SkaffoldOptions{},
+	})
+
+	var tests = []struct {
+		description   string
+		outcomes      []int
+		resolve       func(string) (*v1.Pod, int32, error)
+		expectedCalls []string
+		expectedPod   string
+		expectedImage string
+	}{
+		{
+			description: "retry the same pod",
+			outcomes:    []int{dialFailure, connectionLost, connected},
+			expectedCalls: []string{
+				"ns/pod-1 9000->8080",
+				"ns/pod-1 9000->8080",
+				"ns/pod-1 9000->8080",
+			},
+			expectedPod: "pod-1",
+		},
+		{
+			description: "connection lost and pod resolved again",
+			outcomes:    []int{connectionLost, connected},
+			resolve: func(string) (*v1.Pod, int32, error) {
+				return podWithImage("pod-2", "app:v2"), 8081, nil
+			},
+			expectedCalls: []string{
+				"ns/pod-1 9000->8080",
+				"ns/pod-2 9000->8081",
+			},
+			expectedPod:   "pod-2",
+			expectedImage: "app:v2",
+		},
+		{
+			description: "keep retrying while no pod is found",
+			outcomes:    []int{connectionLost, dialFailure, dialFailure, connected},
+			resolve: func() func(string) (*v1.Pod, int32, error) {
+				attempts := 0
+				return func(current string) (*v1.Pod, int32, error) {
+					if attempts++; attempts < 3 {
+						return nil, 0, errPodNotAvailable
+					}
+					return podWithImage("pod-2", "app:v2"), 8080, nil
+				}
+			}(),
+			expectedCalls: []string{
+				"ns/pod-1 9000->8080",
+				"ns/pod-1 9000->8080",
+				"ns/pod-1 9000->8080",
+				"ns/pod-2 9000->8080",
+			},
+			expectedPod:   "pod-2",
+			expectedImage: "app:v2",
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			connections := &fakeConnections{outcomes: test.outcomes}
+			t.Override(&forwardPod, connections.forwardPod)
+			t.Override(&minReconnectDelay, time.Millisecond)
+			t.Override(&maxReconnectDelay, 4*time.Millisecond)
+			t.Override(&podGone, func(string, string) bool { return false })
+
+			entry := &portForwardEntry{
+				podName:       "pod-1",
+				namespace:     "ns",
+				containerName: "app",
+				port:          8080,
+				localPort:     9000,
+				resolve:       test.resolve,
+			}
+
+			forwarder := &clientForwarder{output: ioutil.Discard}
+			err := forwarder.Forward(context.Background(), entry)
+			t.CheckNoError(err)
+
+			calls := connections.waitForCalls(t)
+			forwarder.Terminate(entry)
+
+			t.CheckDeepEqual(test.expectedCalls, calls)
+			podName, _ := entry.pod()
+			t.CheckDeepEqual(test.expectedPod, podName)
+			t.CheckDeepEqual(test.expectedImage, entry.containerImage())
+		})
+	}
+}
+
+func podWithImage(name, image string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "app", Image: image}},
+		},
+	}
+}
+
+func TestForwardStopsWhenPodIsGone(t *testing.T) {
+	event.InitializeState(&runcontext.RunContext{
+		Cfg:  &latest.Pipeline{},
+		Opts: &config.SkaffoldOptions{},
+	})
+
+	testutil.Run(t, "", func(t *testutil.T) {
+		connections := &fakeConnections{outcomes: []int{connectionLost, dialFailure}}
+		t.Override(&forwardPod, connections.forwardPod)
+		t.Override(&minReconnectDelay, time.Millisecond)
+		gone := 0
+		t.Override(&podGone, func(namespace, podName string) bool {
+			gone++
+			return gone == 2
+		})
+
+		entry := &portForwardEntry{
+			podName:   "pod-1",
+			namespace: "ns",
+			port:      8080,
+			localPort: 9000,
+		}
+
+		// returns once the pod is gone
+		forwarder := &clientForwarder{output: ioutil.Discard}
+		forwarder.forwardWithReconnect(context.Background(), entry)
+
+		t.CheckDeepEqual([]string{"ns/pod-1 9000->8080", "ns/pod-1 9000->8080"}, connections.calls)
+	})
+}
+
+type fakeStream struct {
+	httpstream.Stream
+	headers http.Header
+}
+
+func (s *fakeStream) Close() error { return nil }
+func (s *fakeStream) Reset() error { return nil }
+
+type fakeStreamConnection struct {
+	httpstream.Connection
+}
+
+func (c *fakeStreamConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	return &fakeStream{headers: headers}, nil
+}
+
+type fakeDialer struct{}
+
+func (fakeDialer) Dial(...string) (httpstream.Connection, string, error) {
+	return &fakeStreamConnection{}, "portforward.k8s.io", nil
+}
+
+func TestCountingDialer(t *testing.T) {
+	closed := 0
+	connections := &connectionCount{closed: func() { closed++ }}
+	dialer := &countingDialer{Dialer: fakeDialer{}, connections: connections}
+
+	conn, _, err := dialer.Dial("portforward.k8s.io")
+	testutil.CheckError(t, false, err)
+
+	stream := func(streamType string) httpstream.Stream {
+		headers := http.Header{}
+		headers.Set(v1.StreamType, streamType)
+		s, err := conn.CreateStream(headers)
+		testutil.CheckError(t, false, err)
+		return s
+	}
+
+	// each forwarded connection has an error stream and a data stream
+	stream(v1.StreamTypeError)
+	first := stream(v1.StreamTypeData)
+	stream(v1.StreamTypeError)
+	second := stream(v1.StreamTypeData)
+	testutil.CheckDeepEqual(t, int32(2), connections.value())
+
+	first.Close()
+	first.Reset()
+	testutil.CheckDeepEqual(t, int32(1), connections.value())
+	testutil.CheckDeepEqual(t, 0, closed)
+
+	second.Reset()
+	testutil.CheckDeepEqual(t, int32(0), connections.value())
+	testutil.CheckDeepEqual(t, 1, closed)
+}
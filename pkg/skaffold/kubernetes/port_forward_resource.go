@@ -0,0 +1,362 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// resourceForward tracks the port forward of a resource declared in the `portForward` section.
+// The port is forwarded to one of the running pods behind the resource, and forwarded to another
+// one, on the same local port, when that pod goes away or the connection is lost.
+type resourceForward struct {
+	resource  *latest.PortForwardResource
+	namespace string
+	localPort int32
+
+	// selector selects the pods behind the resource. It's resolved
+	// the first time it's needed since the resource might not be deployed yet.
+	selector labels.Selector
+	service  *v1.Service
+
+	// entry is the port forward currently established, if any.
+	entry *portForwardEntry
+}
+
+func newResourceForwards(resources []*latest.PortForwardResource, namespaces []string) []*resourceForward {
+	defaultNamespace := "default"
+	if len(namespaces) > 0 && namespaces[0] != "" {
+		defaultNamespace = namespaces[0]
+	}
+
+	var forwards []*resourceForward
+	for _, resource := range resources {
+		namespace := resource.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+
+		forwards = append(forwards, &resourceForward{
+			resource:  resource,
+			namespace: namespace,
+		})
+	}
+
+	return forwards
+}
+
+// portForwardResources updates the port forwards of the resources
+// that the given pod belongs to, or used to belong to.
+func (p *PortForwarder) portForwardResources(ctx context.Context, pod *v1.Pod) {
+	for _, r := range p.resources {
+		if r.namespace != pod.Namespace || !r.concerns(pod) {
+			continue
+		}
+
+		if err := p.portForwardResource(ctx, r); err != nil {
+			logrus.Warnf("port forwarding %s failed: %s", r, err)
+		}
+	}
+}
+
+func (p *PortForwarder) portForwardResource(ctx context.Context, r *resourceForward) error {
+	pod, err := r.targetPod()
+	if err != nil {
+		return err
+	}
+
+	if r.entry != nil {
+		if podName, _ := r.entry.pod(); pod != nil && pod.Name == podName {
+			return nil
+		}
+		p.Terminate(r.entry)
+		p.lock.Lock()
+		r.entry = nil
+		p.lock.Unlock()
+	}
+
+	// Wait for a pod to be running
+	if pod == nil {
+		return nil
+	}
+
+	port, containerName, portName, err := remotePort(r.service, int32(r.resource.Port), pod)
+	if err != nil {
+		return err
+	}
+
+	if r.localPort == 0 {
+		localPort := r.resource.LocalPort
+		if localPort == 0 {
+			localPort = r.resource.Port
+		}
+		r.localPort = int32(retrieveAvailablePort(localPort, p.forwardedPorts))
+	}
+	if r.resource.LocalPort != 0 && int32(r.resource.LocalPort) != r.localPort {
+		color.Yellow.Fprintf(p.output, "Local port %d is not available. Forwarding %s to local port %d.\n", r.resource.LocalPort, r, r.localPort)
+	}
+
+	entry := &portForwardEntry{
+		podName:       pod.Name,
+		namespace:     pod.Namespace,
+		containerName: containerName,
+		image:         containerImage(pod, containerName),
+		portName:      portName,
+		port:          port,
+		localPort:     r.localPort,
+		connections:   connectionCount{closed: p.onDisconnect},
+		resolve:       r.resolver(),
+	}
+	p.lock.Lock()
+	r.entry = entry
+	p.lock.Unlock()
+
+	color.Default.Fprintln(p.output, fmt.Sprintf("Port Forwarding %s %d -> %d", r, r.resource.Port, r.localPort))
+	return p.Forward(ctx, entry)
+}
+
+// concerns returns true if the pod is the one currently forwarded
+// or if it's one of the pods behind the resource.
+func (r *resourceForward) concerns(pod *v1.Pod) bool {
+	if r.entry != nil {
+		if podName, _ := r.entry.pod(); podName == pod.Name {
+			return true
+		}
+	}
+
+	if r.isNamedPod() {
+		return pod.Name == r.resource.Name
+	}
+
+	selector, err := r.podSelector()
+	if err != nil {
+		logrus.Debugf("Unable to resolve the pods of %s: %s", r, err)
+		return false
+	}
+	return selector.Matches(labels.Set(pod.Labels))
+}
+
+// targetPod finds a running pod behind the resource. The pod currently forwarded is
+// preferred. Otherwise, the most recent pod is chosen. It returns nil if no pod is running.
+func (r *resourceForward) targetPod() (*v1.Pod, error) {
+	var current string
+	if r.entry != nil {
+		current, _ = r.entry.pod()
+	}
+
+	if r.isNamedPod() {
+		return findPod(r.namespace, r.resource.Name, nil, current)
+	}
+
+	selector, err := r.podSelector()
+	if err != nil {
+		return nil, err
+	}
+	return findPod(r.namespace, "", selector, current)
+}
+
+// findPod finds a running pod, either by name or with a selector. The current pod is preferred.
+func findPod(namespace, name string, selector labels.Selector, current string) (*v1.Pod, error) {
+	client, err := Client()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting k8s client")
+	}
+	pods := client.CoreV1().Pods(namespace)
+
+	if name != "" {
+		pod, err := pods.Get(name, metav1.GetOptions{})
+		if err != nil || !isRunning(pod) {
+			return nil, nil
+		}
+		return pod, nil
+	}
+
+	list, err := pods.List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing pods in %s", namespace)
+	}
+
+	var target *v1.Pod
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if !isRunning(pod) {
+			continue
+		}
+		if pod.Name == current {
+			return pod, nil
+		}
+		if target == nil || target.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			target = pod
+		}
+	}
+
+	return target, nil
+}
+
+// resolver returns the function used by the forwarder to find another pod behind the resource
+// when the connection to the current one is lost. Once the pods of the resource have been
+// resolved, the function doesn't depend on the state of the resourceForward.
+func (r *resourceForward) resolver() func(string) (*v1.Pod, int32, error) {
+	namespace, selector, service, port := r.namespace, r.selector, r.service, int32(r.resource.Port)
+	var name string
+	if r.isNamedPod() {
+		name = r.resource.Name
+	}
+
+	return func(current string) (*v1.Pod, int32, error) {
+		pod, err := findPod(namespace, name, selector, current)
+		if err != nil {
+			return nil, 0, err
+		}
+		if pod == nil {
+			return nil, 0, errPodNotAvailable
+		}
+
+		targetPort, _, _, err := remotePort(service, port, pod)
+		return pod, targetPort, err
+	}
+}
+
+func (r *resourceForward) podSelector() (labels.Selector, error) {
+	if r.selector != nil {
+		return r.selector, nil
+	}
+
+	switch r.resourceType() {
+	case latest.Pod:
+		selector, err := labels.Parse(r.resource.Selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing label selector %s", r.resource.Selector)
+		}
+		r.selector = selector
+
+	case latest.Deployment:
+		client, err := Client()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting k8s client")
+		}
+		deployment, err := client.AppsV1().Deployments(r.namespace).Get(r.resource.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting deployment %s", r.resource.Name)
+		}
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing selector of deployment %s", r.resource.Name)
+		}
+		r.selector = selector
+
+	case latest.Service:
+		client, err := Client()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting k8s client")
+		}
+		service, err := client.CoreV1().Services(r.namespace).Get(r.resource.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting service %s", r.resource.Name)
+		}
+		if len(service.Spec.Selector) == 0 {
+			return nil, fmt.Errorf("service %s has no selector", r.resource.Name)
+		}
+		r.service = service
+		r.selector = labels.SelectorFromSet(service.Spec.Selector)
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type %s", r.resource.Type)
+	}
+
+	return r.selector, nil
+}
+
+// remotePort finds the container port to forward on the given pod.
+// For services, the service port is mapped to its target port.
+func remotePort(service *v1.Service, port int32, pod *v1.Pod) (int32, string, string, error) {
+	if service != nil {
+		targetPort, err := serviceTargetPort(service, port, pod)
+		if err != nil {
+			return 0, "", "", err
+		}
+		port = targetPort
+	}
+
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.ContainerPort == port {
+				return port, c.Name, p.Name, nil
+			}
+		}
+	}
+
+	return port, "", "", nil
+}
+
+func serviceTargetPort(service *v1.Service, port int32, pod *v1.Pod) (int32, error) {
+	for _, p := range service.Spec.Ports {
+		if p.Port != port {
+			continue
+		}
+
+		switch {
+		case p.TargetPort.Type == intstr.String:
+			for _, c := range pod.Spec.Containers {
+				for _, cp := range c.Ports {
+					if cp.Name == p.TargetPort.StrVal {
+						return cp.ContainerPort, nil
+					}
+				}
+			}
+			return 0, fmt.Errorf("pod %s has no port named %s", pod.Name, p.TargetPort.StrVal)
+		case p.TargetPort.IntVal != 0:
+			return p.TargetPort.IntVal, nil
+		default:
+			return port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("service %s has no port %d", service.Name, port)
+}
+
+func (r *resourceForward) resourceType() latest.ResourceType {
+	return latest.ResourceType(strings.ToLower(string(r.resource.Type)))
+}
+
+func (r *resourceForward) isNamedPod() bool {
+	return r.resourceType() == latest.Pod && r.resource.Name != ""
+}
+
+func isRunning(pod *v1.Pod) bool {
+	return pod.Status.Phase == v1.PodRunning && pod.DeletionTimestamp == nil
+}
+
+// String is a utility function that returns the resource as a user-readable string
+func (r *resourceForward) String() string {
+	name := r.resource.Name
+	if name == "" {
+		name = r.resource.Selector
+	}
+	return fmt.Sprintf("%s/%s/%s", r.resourceType(), r.namespace, name)
+}
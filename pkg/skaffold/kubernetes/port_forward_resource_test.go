@@ -0,0 +1,202 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func runningPod(name string, created int64, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "ns",
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(time.Unix(created, 0)),
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "web",
+				Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestPortForwardResources(t *testing.T) {
+	webLabels := map[string]string{"app": "web"}
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Spec: v1.ServiceSpec{
+			Selector: webLabels,
+			Ports:    []v1.ServicePort{{Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: webLabels},
+		},
+	}
+
+	var tests = []struct {
+		description     string
+		resource        *latest.PortForwardResource
+		objects         []runtime.Object
+		event           *v1.Pod
+		availablePorts  []int
+		expectedPod     string
+		expectedPort    int32
+		expectedLocal   int32
+		expectForwarded bool
+	}{
+		{
+			description:     "service to target port",
+			resource:        &latest.PortForwardResource{Type: "service", Name: "web", Port: 80, LocalPort: 9000},
+			objects:         []runtime.Object{service, runningPod("web-1", 1, webLabels), runningPod("web-2", 2, webLabels)},
+			event:           runningPod("web-1", 1, webLabels),
+			availablePorts:  []int{9000},
+			expectedPod:     "web-2",
+			expectedPort:    8080,
+			expectedLocal:   9000,
+			expectForwarded: true,
+		},
+		{
+			description:     "deployment",
+			resource:        &latest.PortForwardResource{Type: "deployment", Name: "web", Port: 8080},
+			objects:         []runtime.Object{deployment, runningPod("web-1", 1, webLabels)},
+			event:           runningPod("web-1", 1, webLabels),
+			availablePorts:  []int{8081},
+			expectedPod:     "web-1",
+			expectedPort:    8080,
+			expectedLocal:   8081,
+			expectForwarded: true,
+		},
+		{
+			description:     "pod by name",
+			resource:        &latest.PortForwardResource{Type: "pod", Name: "web-1", Port: 8080},
+			objects:         []runtime.Object{runningPod("web-1", 1, nil)},
+			event:           runningPod("web-1", 1, nil),
+			availablePorts:  []int{8080},
+			expectedPod:     "web-1",
+			expectedPort:    8080,
+			expectedLocal:   8080,
+			expectForwarded: true,
+		},
+		{
+			description:     "pod by selector",
+			resource:        &latest.PortForwardResource{Type: "pod", Selector: "app=web", Port: 8080},
+			objects:         []runtime.Object{runningPod("web-1", 1, webLabels)},
+			event:           runningPod("web-1", 1, webLabels),
+			availablePorts:  []int{8080},
+			expectedPod:     "web-1",
+			expectedPort:    8080,
+			expectedLocal:   8080,
+			expectForwarded: true,
+		},
+		{
+			description: "other pod",
+			resource:    &latest.PortForwardResource{Type: "pod", Selector: "app=web", Port: 8080},
+			objects:     []runtime.Object{runningPod("db", 1, nil)},
+			event:       runningPod("db", 1, nil),
+		},
+		{
+			description: "other namespace",
+			resource:    &latest.PortForwardResource{Type: "pod", Selector: "app=web", Namespace: "other", Port: 8080},
+			objects:     []runtime.Object{runningPod("web-1", 1, webLabels)},
+			event:       runningPod("web-1", 1, webLabels),
+		},
+		{
+			description: "service not deployed yet",
+			resource:    &latest.PortForwardResource{Type: "service", Name: "web", Port: 80},
+			objects:     []runtime.Object{runningPod("web-1", 1, webLabels)},
+			event:       runningPod("web-1", 1, webLabels),
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			clientset := fake.NewSimpleClientset(test.objects...)
+			t.Override(&Client, func() (kubernetes.Interface, error) { return clientset, nil })
+			t.Override(&retrieveAvailablePort, mockRetrieveAvailablePort(map[int]struct{}{}, test.availablePorts))
+
+			forwarder := newTestForwarder(nil)
+			p := NewPortForwarder(ioutil.Discard, NewImageList(), []string{"ns"}, []*latest.PortForwardResource{test.resource})
+			p.Forwarder = forwarder
+
+			p.portForwardResources(context.Background(), test.event)
+
+			entry := p.resources[0].entry
+			t.CheckDeepEqual(test.expectForwarded, entry != nil)
+			t.CheckDeepEqual(test.expectForwarded, len(forwarder.forwardedEntries) == 1)
+			if test.expectForwarded {
+				t.CheckDeepEqual(test.expectedPod, entry.podName)
+				t.CheckDeepEqual(test.expectedPort, entry.port)
+				t.CheckDeepEqual(test.expectedLocal, entry.localPort)
+			}
+		})
+	}
+}
+
+func TestPortForwardResourceRestart(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		labels := map[string]string{"app": "web"}
+		clientset := fake.NewSimpleClientset(runningPod("web-1", 1, labels))
+		t.Override(&Client, func() (kubernetes.Interface, error) { return clientset, nil })
+		t.Override(&retrieveAvailablePort, mockRetrieveAvailablePort(map[int]struct{}{}, []int{8080, 8081}))
+
+		forwarder := newTestForwarder(nil)
+		p := NewPortForwarder(ioutil.Discard, NewImageList(), []string{"ns"}, []*latest.PortForwardResource{{
+			Type:     "pod",
+			Selector: "app=web",
+			Port:     8080,
+		}})
+		p.Forwarder = forwarder
+		ctx := context.Background()
+
+		p.portForwardResources(ctx, runningPod("web-1", 1, labels))
+		t.CheckDeepEqual("web-1", p.resources[0].entry.podName)
+
+		// The pod is replaced
+		deleting := runningPod("web-1", 1, labels)
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		clientset.CoreV1().Pods("ns").Update(deleting)
+		clientset.CoreV1().Pods("ns").Create(runningPod("web-2", 2, labels))
+		p.portForwardResources(ctx, deleting)
+
+		entry := p.resources[0].entry
+		t.CheckDeepEqual("web-2", entry.podName)
+		t.CheckDeepEqual(int32(8080), entry.localPort)
+
+		// Events for the new pod don't restart the port forward
+		p.portForwardResources(ctx, runningPod("web-2", 2, labels))
+		t.CheckDeepEqual(true, entry == p.resources[0].entry)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -74,6 +75,7 @@ func TestPortForwardPod(t *testing.T) {
 	var tests = []struct {
 		description     string
 		pods            []*v1.Pod
+		portNames       []string
 		forwarder       *testForwarder
 		expectedPorts   map[int32]bool
 		expectedEntries map[string]*portForwardEntry
@@ -120,6 +122,51 @@ func TestPortForwardPod(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "only ports with given names",
+			portNames:   []string{"dlv"},
+			expectedPorts: map[int32]bool{
+				56268: true,
+			},
+			availablePorts: []int{56268},
+			expectedEntries: map[string]*portForwardEntry{
+				"containername-namespace-dlv-56268": {
+					resourceVersion: 1,
+					podName:         "podname",
+					containerName:   "containername",
+					namespace:       "namespace",
+					portName:        "dlv",
+					port:            56268,
+					localPort:       56268,
+				},
+			},
+			pods: []*v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "podname",
+						ResourceVersion: "1",
+						Namespace:       "namespace",
+					},
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{
+							{
+								Name: "containername",
+								Ports: []v1.ContainerPort{
+									{
+										ContainerPort: 8080,
+										Name:          "http",
+									},
+									{
+										ContainerPort: 56268,
+										Name:          "dlv",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			description: "unavailable container port",
 			expectedPorts: map[int32]bool{
@@ -438,7 +485,8 @@ func TestPortForwardPod(t *testing.T) {
 
 			t.Override(&retrieveAvailablePort, mockRetrieveAvailablePort(taken, test.availablePorts))
 
-			p := NewPortForwarder(ioutil.Discard, NewImageList(), []string{""})
+			p := NewPortForwarder(ioutil.Discard, NewImageList(), []string{""}, nil)
+			p.ForwardOnly(test.portNames)
 			if test.forwarder == nil {
 				test.forwarder = newTestForwarder(nil)
 			}
@@ -479,3 +527,24 @@ func TestPortForwardEntryKey(t *testing.T) {
 		t.Fatal("key should not contain podname, otherwise containers will be mapped to a new port every time a pod is regenerated. See Issues #1815 and #1594.")
 	}
 }
+
+func TestConnectedImages(t *testing.T) {
+	p := NewPortForwarder(ioutil.Discard, NewImageList(), nil, nil)
+	p.forwardedPods = map[string]*portForwardEntry{
+		"app-jdwp":  {containerName: "app", image: "app:v1", portName: "jdwp", port: 5005, connections: connectionCount{count: 1}},
+		"app-http":  {containerName: "app", image: "app:v1", portName: "http", port: 8080, connections: connectionCount{count: 2}},
+		"web-dev":   {containerName: "web", image: "web:v1", portName: "devtools", port: 9229},
+		"api-dlv":   {containerName: "api", image: "api:v1", portName: "dlv", port: 56268, connections: connectionCount{count: 1}},
+		"other-dlv": {containerName: "other", portName: "dlv", port: 56268, connections: connectionCount{count: 1}},
+	}
+	p.resources = []*resourceForward{
+		{entry: &portForwardEntry{containerName: "worker", image: "worker:v1", portName: "jdwp", port: 5005, connections: connectionCount{count: 1}}},
+		{entry: &portForwardEntry{containerName: "web", image: "web:v1", portName: "devtools", port: 9229}},
+		{},
+	}
+
+	images := p.ConnectedImages([]string{"jdwp", "devtools", "dlv"})
+	sort.Strings(images)
+
+	testutil.CheckDeepEqual(t, []string{"api:v1", "app:v1", "worker:v1"}, images)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "skaffold"
+
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+)
+
+// durationBuckets are the histogram buckets, in seconds, for the duration of builds and deploys.
+var durationBuckets = []float64{1, 2, 5, 10, 20, 30, 60, 120, 300, 600}
+
+var (
+	buildDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "build_duration_seconds",
+		Help:      "Duration of the builds of an artifact, by artifact type.",
+		Buckets:   durationBuckets,
+	}, []string{"artifact", "builder", "status"})
+
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Number of times an artifact was looked up in the cache, by result.",
+	}, []string{"artifact", "result"})
+
+	deployDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "deploy_duration_seconds",
+		Help:      "Duration of the deploys.",
+		Buckets:   durationBuckets,
+	}, []string{"status"})
+
+	deployFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deploy_failures_total",
+		Help:      "Number of failed deploys.",
+	})
+
+	fileSyncs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "file_syncs_total",
+		Help:      "Number of file syncs to the containers of an artifact.",
+	}, []string{"artifact", "status"})
+
+	syncedFiles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "synced_files_total",
+		Help:      "Number of files copied or deleted by file syncs.",
+	}, []string{"artifact"})
+
+	fileChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "file_change_events_total",
+		Help:      "Number of file changes detected for a watched component.",
+	}, []string{"component"})
+
+	forwardedPorts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "forwarded_ports_total",
+		Help:      "Number of times a port was forwarded, including reconnections.",
+	}, []string{"namespace", "container", "port"})
+
+	// cacheHits and cacheMisses back the cache hit ratio gauge
+	cacheHits, cacheMisses int64
+)
+
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		buildDuration,
+		cacheLookups,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "cache_hit_ratio",
+			Help:      "Ratio of cache lookups that found the artifact since skaffold started.",
+		}, cacheHitRatio),
+		deployDuration,
+		deployFailures,
+		fileSyncs,
+		syncedFiles,
+		fileChanges,
+		forwardedPorts,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ArtifactBuilt records the duration of an artifact's build.
+func ArtifactBuilt(artifact *latest.Artifact, duration time.Duration, err error) {
+	buildDuration.WithLabelValues(artifact.ImageName, builderName(artifact), status(err)).Observe(duration.Seconds())
+}
+
+// CacheLookup records whether an artifact was found in the cache.
+func CacheLookup(imageName string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+		atomic.AddInt64(&cacheHits, 1)
+	} else {
+		atomic.AddInt64(&cacheMisses, 1)
+	}
+	cacheLookups.WithLabelValues(imageName, result).Inc()
+}
+
+// Deployed records the duration of a deploy, and whether it failed.
+func Deployed(duration time.Duration, err error) {
+	deployDuration.WithLabelValues(status(err)).Observe(duration.Seconds())
+	if err != nil {
+		deployFailures.Inc()
+	}
+}
+
+// FilesSynced records a file sync to the containers of an artifact.
+func FilesSynced(imageName string, fileCount int, err error) {
+	fileSyncs.WithLabelValues(imageName, status(err)).Inc()
+	if err == nil {
+		syncedFiles.WithLabelValues(imageName).Add(float64(fileCount))
+	}
+}
+
+// FilesChanged records the number of files changed for a watched component.
+func FilesChanged(component string, fileCount int) {
+	fileChanges.WithLabelValues(component).Add(float64(fileCount))
+}
+
+// PortForwarded records a port forwarded to a container.
+func PortForwarded(namespace, containerName, portName string) {
+	forwardedPorts.WithLabelValues(namespace, containerName, portName).Inc()
+}
+
+func cacheHitRatio() float64 {
+	hits := atomic.LoadInt64(&cacheHits)
+	lookups := hits + atomic.LoadInt64(&cacheMisses)
+	if lookups == 0 {
+		return 0
+	}
+	return float64(hits) / float64(lookups)
+}
+
+func status(err error) string {
+	if err != nil {
+		return statusFailed
+	}
+	return statusSucceeded
+}
+
+// builderName returns the name of the artifact's type, as found in skaffold.yaml
+func builderName(a *latest.Artifact) string {
+	switch {
+	case a.DockerArtifact != nil:
+		return "docker"
+	case a.BazelArtifact != nil:
+		return "bazel"
+	case a.JibMavenArtifact != nil:
+		return "jibMaven"
+	case a.JibGradleArtifact != nil:
+		return "jibGradle"
+	case a.KanikoArtifact != nil:
+		return "kaniko"
+	case a.CustomArtifact != nil:
+		return "custom"
+	default:
+		return "unknown"
+	}
+}
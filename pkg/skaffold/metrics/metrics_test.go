@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestHandler(t *testing.T) {
+	ArtifactBuilt(&latest.Artifact{ImageName: "img", ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{}}}, 3*time.Second, nil)
+	CacheLookup("img", true)
+	CacheLookup("img", true)
+	CacheLookup("other", false)
+	CacheLookup("other", true)
+	Deployed(time.Second, errors.New("BUG"))
+	FilesSynced("img", 2, nil)
+	FilesChanged("img", 3)
+	PortForwarded("ns", "container", "http")
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+	testutil.CheckError(t, false, err)
+
+	for _, expected := range []string{
+		`skaffold_build_duration_seconds_count{artifact="img",builder="docker",status="succeeded"} 1`,
+		`skaffold_build_duration_seconds_sum{artifact="img",builder="docker",status="succeeded"} 3`,
+		`skaffold_cache_lookups_total{artifact="img",result="hit"} 2`,
+		`skaffold_cache_hit_ratio 0.75`,
+		`skaffold_deploy_duration_seconds_count{status="failed"} 1`,
+		`skaffold_deploy_failures_total 1`,
+		`skaffold_file_syncs_total{artifact="img",status="succeeded"} 1`,
+		`skaffold_synced_files_total{artifact="img"} 2`,
+		`skaffold_file_change_events_total{component="img"} 3`,
+		`skaffold_forwarded_ports_total{container="container",namespace="ns",port="http"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected metrics to contain %s, got:\n%s", expected, body)
+		}
+	}
+}
+
+func TestBuilderName(t *testing.T) {
+	tests := []struct {
+		description string
+		artifact    latest.ArtifactType
+		expected    string
+	}{
+		{"docker", latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{}}, "docker"},
+		{"bazel", latest.ArtifactType{BazelArtifact: &latest.BazelArtifact{}}, "bazel"},
+		{"jib maven", latest.ArtifactType{JibMavenArtifact: &latest.JibMavenArtifact{}}, "jibMaven"},
+		{"jib gradle", latest.ArtifactType{JibGradleArtifact: &latest.JibGradleArtifact{}}, "jibGradle"},
+		{"kaniko", latest.ArtifactType{KanikoArtifact: &latest.KanikoArtifact{}}, "kaniko"},
+		{"custom", latest.ArtifactType{CustomArtifact: &latest.CustomArtifact{}}, "custom"},
+		{"none", latest.ArtifactType{}, "unknown"},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.CheckDeepEqual(test.expected, builderName(&latest.Artifact{ArtifactType: test.artifact}))
+		})
+	}
+}
@@ -40,6 +40,11 @@ func (c *changes) AddDirtyArtifact(a *latest.Artifact, e watch.Events) {
 }
 
 func (c *changes) AddRebuild(a *latest.Artifact) {
+	for _, pending := range c.needsRebuild {
+		if pending == a {
+			return
+		}
+	}
 	c.needsRebuild = append(c.needsRebuild, a)
 }
 
@@ -47,11 +52,50 @@ func (c *changes) AddResync(s *sync.Item) {
 	c.needsResync = append(c.needsResync, s)
 }
 
-func (c *changes) reset() {
-	c.dirtyArtifacts = nil
-	c.needsRebuild = nil
-	c.needsResync = nil
+// pending tells whether changes were recorded and not applied yet.
+func (c *changes) pending() bool {
+	return len(c.dirtyArtifacts) > 0 || len(c.needsRebuild) > 0 || len(c.needsResync) > 0 || c.needsRedeploy || c.needsReload
+}
+
+// resolve points the pending changes to the artifacts of a new configuration.
+// Changes to artifacts that were removed, or that are rebuilt anyway, are dropped.
+func (c *changes) resolve(artifacts, rebuilt []*latest.Artifact) {
+	current := func(a *latest.Artifact) *latest.Artifact {
+		if findArtifact(rebuilt, a.ImageName) != nil {
+			return nil
+		}
+		return findArtifact(artifacts, a.ImageName)
+	}
+
+	var dirtyArtifacts []*artifactChange
+	for _, change := range c.dirtyArtifacts {
+		if a := current(change.artifact); a != nil {
+			dirtyArtifacts = append(dirtyArtifacts, &artifactChange{artifact: a, events: change.events})
+		}
+	}
+	c.dirtyArtifacts = dirtyArtifacts
 
-	c.needsRedeploy = false
+	var needsRebuild []*latest.Artifact
+	for _, artifact := range c.needsRebuild {
+		if a := current(artifact); a != nil {
+			needsRebuild = append(needsRebuild, a)
+		}
+	}
+	c.needsRebuild = needsRebuild
+}
+
+// reset forgets the changes for the phases that were allowed to run.
+// Changes pending for the other phases are kept.
+func (c *changes) reset(allowed phases) {
+	c.dirtyArtifacts = nil
+	if allowed.build {
+		c.needsRebuild = nil
+	}
+	if allowed.sync {
+		c.needsResync = nil
+	}
+	if allowed.deploy {
+		c.needsRedeploy = false
+	}
 	c.needsReload = false
 }
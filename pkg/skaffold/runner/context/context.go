@@ -33,6 +33,7 @@ type RunContext struct {
 	Cfg  *latest.Pipeline
 
 	Trigger chan bool
+	Intents chan Intent
 
 	DefaultRepo        string
 	KubeContext        string
@@ -76,7 +77,7 @@ func GetRunContext(opts *config.SkaffoldOptions, cfg *latest.Pipeline) (*RunCont
 		insecureRegistries[r] = true
 	}
 
-	return &RunContext{
+	runCtx := &RunContext{
 		Opts:               opts,
 		Cfg:                cfg,
 		WorkingDir:         cwd,
@@ -85,5 +86,10 @@ func GetRunContext(opts *config.SkaffoldOptions, cfg *latest.Pipeline) (*RunCont
 		Namespaces:         namespaces,
 		InsecureRegistries: insecureRegistries,
 		Trigger:            make(chan bool),
-	}, nil
+	}
+	// Only the dev loop receives intents from the control API.
+	if opts.Command == "dev" || opts.Command == "debug" {
+		runCtx.Intents = make(chan Intent)
+	}
+	return runCtx, nil
 }
@@ -0,0 +1,47 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+// Intent is a request, received through the control API, to change
+// the behaviour of the dev loop.
+type Intent struct {
+	// Paused pauses or resumes the dev loop when set.
+	Paused *bool
+
+	// AutoBuild, AutoSync and AutoDeploy enable or disable the
+	// automatic trigger of the corresponding phase when set.
+	AutoBuild  *bool
+	AutoSync   *bool
+	AutoDeploy *bool
+
+	// Build, Sync and Deploy run the corresponding phase once.
+	// Artifacts restricts the build to the given image names.
+	Build     bool
+	Sync      bool
+	Deploy    bool
+	Artifacts []string
+
+	// LogFilters replaces the filters of the logs when set.
+	LogFilters *LogFilters
+}
+
+// LogFilters are the include and exclude filters that choose
+// which containers have their logs printed.
+type LogFilters struct {
+	Include []string
+	Exclude []string
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/debug"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+)
+
+// debugAttachments reports the images of the containers that have open connections on the given ports.
+type debugAttachments interface {
+	ConnectedImages(portNames []string) []string
+}
+
+// deferAttached sets aside the artifacts that have a debugger attached, as redeploying them would
+// end the debugging session. They are rebuilt once the debuggers are detached.
+func (r *SkaffoldRunner) deferAttached(out io.Writer, artifacts []*latest.Artifact) (rebuild, deferred []*latest.Artifact) {
+	if r.debugAttachments == nil || len(artifacts) == 0 {
+		return artifacts, nil
+	}
+
+	rebuild, deferred = partitionAttached(artifacts, r.builds, r.debugAttachments.ConnectedImages(debug.PortNames))
+	for _, artifact := range deferred {
+		color.Yellow.Fprintf(out, "Not rebuilding %s while a debugger is attached.\n", artifact.ImageName)
+	}
+	return rebuild, deferred
+}
+
+// rebuildDetached rebuilds the artifacts that were set aside while a debugger was attached.
+func (r *SkaffoldRunner) rebuildDetached(ctx context.Context, out io.Writer, logger *kubernetes.LogAggregator, changed *changes, intents *intents) error {
+	if intents.paused || len(changed.needsRebuild) == 0 {
+		return nil
+	}
+	return r.applyChanges(ctx, out, logger, changed, intents.auto)
+}
+
+// partitionAttached splits the artifacts between those whose last build is running in one of the given images and the others.
+func partitionAttached(artifacts []*latest.Artifact, builds []build.Artifact, images []string) (detached, attached []*latest.Artifact) {
+	for _, artifact := range artifacts {
+		if isAttached(artifact, builds, images) {
+			attached = append(attached, artifact)
+		} else {
+			detached = append(detached, artifact)
+		}
+	}
+	return detached, attached
+}
+
+func isAttached(artifact *latest.Artifact, builds []build.Artifact, images []string) bool {
+	for _, b := range builds {
+		if b.ImageName == artifact.ImageName && util.StrSliceContains(images, b.Tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestPartitionAttached(t *testing.T) {
+	app := &latest.Artifact{ImageName: "app"}
+	web := &latest.Artifact{ImageName: "web"}
+	builds := []build.Artifact{
+		{ImageName: "app", Tag: "app:v1"},
+		{ImageName: "web", Tag: "web:v1"},
+	}
+
+	tests := []struct {
+		description      string
+		images           []string
+		expectedDetached []*latest.Artifact
+		expectedAttached []*latest.Artifact
+	}{
+		{
+			description:      "nothing attached",
+			expectedDetached: []*latest.Artifact{app, web},
+		},
+		{
+			description:      "one attached",
+			images:           []string{"web:v1"},
+			expectedDetached: []*latest.Artifact{app},
+			expectedAttached: []*latest.Artifact{web},
+		},
+		{
+			description:      "previous build attached",
+			images:           []string{"web:v0"},
+			expectedDetached: []*latest.Artifact{app, web},
+		},
+		{
+			description:      "unknown image attached",
+			images:           []string{"other:v1"},
+			expectedDetached: []*latest.Artifact{app, web},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			detached, attached := partitionAttached([]*latest.Artifact{app, web}, builds, test.images)
+
+			t.CheckDeepEqual(test.expectedDetached, detached)
+			t.CheckDeepEqual(test.expectedAttached, attached)
+		})
+	}
+}
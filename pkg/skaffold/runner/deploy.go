@@ -33,7 +33,10 @@ func (r *SkaffoldRunner) Deploy(ctx context.Context, out io.Writer, artifacts []
 		for i, a := range artifacts {
 			images[i] = a.ImageName
 		}
-		logger := r.newLoggerForImages(out, images)
+		logger, err := r.newLoggerForImages(out, images)
+		if err != nil {
+			return err
+		}
 		return r.TailLogs(ctx, out, logger)
 	}
 	return nil
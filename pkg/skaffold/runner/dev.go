@@ -19,9 +19,15 @@ package runner
 import (
 	"context"
 	"io"
+	gosync "sync"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/debug"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
@@ -29,65 +35,157 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// ErrorConfigurationChanged is a special error that's returned when the skaffold configuration was changed.
+// ErrorConfigurationChanged is a special error that's returned when the skaffold configuration was changed
+// and the runner doesn't know how to reload it.
 var ErrorConfigurationChanged = errors.New("configuration changed")
 
 // Dev watches for changes and runs the skaffold build and deploy
 // config until interrupted by the user.
 func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) error {
-	logger := r.newLogger(out, artifacts)
-	defer logger.Stop()
+	logger, err := r.newLogger(out, artifacts)
+	if err != nil {
+		return err
+	}
+	defer func() { logger.Stop() }()
+
+	// While debugging, artifacts are not rebuilt when a debugger is attached through a forwarded port.
+	// They are rebuilt once the debugger detaches.
+	if r.runCtx.Opts.Command == "debug" {
+		r.debugDetached = make(chan struct{}, 1)
+	}
 
-	portForwarder := kubernetes.NewPortForwarder(out, r.imageList, r.runCtx.Namespaces)
-	defer portForwarder.Stop()
+	portForwarder := r.newPortForwarder(out)
+	defer func() { portForwarder.Stop() }()
 
-	// Create watcher and register artifacts to build current state of files.
+	if r.runCtx.Opts.Command == "debug" {
+		r.debugAttachments = portForwarder
+	}
+
+	// Changes are recorded by the watcher and applied either by the watcher
+	// or when an intent is received through the control API.
+	var lock gosync.Mutex
 	changed := changes{}
+	intents := newIntents(r.runCtx.Opts)
+
 	onChange := func() error {
-		defer changed.reset()
+		lock.Lock()
+		defer lock.Unlock()
 
-		logger.Mute()
+		if intents.paused {
+			return nil
+		}
+		return r.applyChanges(ctx, out, logger, &changed, intents.auto)
+	}
 
-		for _, a := range changed.dirtyArtifacts {
-			s, err := sync.NewItem(a.artifact, a.events, r.builds, r.runCtx.InsecureRegistries)
-			if err != nil {
-				return errors.Wrap(err, "sync")
-			}
-			if s != nil {
-				changed.AddResync(s)
-			} else {
-				changed.AddRebuild(a.artifact)
-			}
+	if err := r.watchChanges(ctx, &lock, &changed, artifacts); err != nil {
+		return err
+	}
+
+	// First run
+	if err := r.buildTestDeploy(ctx, out, artifacts); err != nil {
+		return errors.Wrap(err, "exiting dev mode because first run failed")
+	}
+
+	// Start logs
+	if r.runCtx.Opts.TailDev {
+		if err := logger.Start(ctx); err != nil {
+			return errors.Wrap(err, "starting logger")
+		}
+	}
+
+	if r.forwardsPorts() {
+		if err := portForwarder.Start(ctx); err != nil {
+			return errors.Wrap(err, "starting port-forwarder")
 		}
+	}
 
-		switch {
-		case changed.needsReload:
-			return ErrorConfigurationChanged
-		case len(changed.needsResync) > 0:
-			for _, s := range changed.needsResync {
-				color.Default.Fprintf(out, "Syncing %d files for %s\n", len(s.Copy)+len(s.Delete), s.Image)
+	// Notify the event API of the containers configured for debugging
+	if r.runCtx.Opts.Command == "debug" {
+		debugContainers := debug.NewContainerManager(r.imageList, r.runCtx.Namespaces)
+		if err := debugContainers.Start(ctx); err != nil {
+			return errors.Wrap(err, "starting debug container manager")
+		}
+		defer debugContainers.Stop()
+	}
 
-				if err := r.Syncer.Sync(ctx, s); err != nil {
-					logrus.Warnln("Skipping deploy due to sync error:", err)
-					return nil
+	// Listen to the control API
+	var intentErr error
+	stopWatching := func() {}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case intent := <-r.runCtx.Intents:
+				lock.Lock()
+				if err := r.handleIntent(ctx, out, logger, &changed, intents, artifacts, intent); err != nil {
+					intentErr = err
+					stopWatching()
 				}
-			}
-		case len(changed.needsRebuild) > 0:
-			if err := r.buildTestDeploy(ctx, out, changed.needsRebuild); err != nil {
-				logrus.Warnln("Skipping deploy due to error:", err)
-				return nil
-			}
-		case changed.needsRedeploy:
-			if err := r.Deploy(ctx, out, r.builds); err != nil {
-				logrus.Warnln("Skipping deploy due to error:", err)
-				return nil
+				lock.Unlock()
+			case <-r.debugDetached:
+				lock.Lock()
+				if err := r.rebuildDetached(ctx, out, logger, &changed, intents); err != nil {
+					intentErr = err
+					stopWatching()
+				}
+				lock.Unlock()
 			}
 		}
+	}()
 
+	for {
+		watchCtx, cancel := context.WithCancel(ctx)
+		lock.Lock()
+		stopWatching = cancel
+		lock.Unlock()
+
+		err := r.Watcher.Run(watchCtx, out, onChange)
+		cancel()
+
+		lock.Lock()
+		if intentErr != nil {
+			err, intentErr = intentErr, nil
+		}
+		lock.Unlock()
+
+		if errors.Cause(err) != ErrorConfigurationChanged || r.ReloadConfig == nil {
+			return err
+		}
+
+		// Reload the configuration. The logs and the port forwards are only restarted
+		// if the new configuration changes what they watch.
+		lock.Lock()
+		previous := r.runCtx
+		logger.Mute()
+		r.reload(ctx, out, &changed)
 		logger.Unmute()
-		return nil
+		artifacts = r.runCtx.Cfg.Build.Artifacts
+		var restartErr error
+		if logsChanged(previous, r.runCtx) {
+			logger, restartErr = r.restartLogger(ctx, out, artifacts, logger)
+		}
+		if restartErr == nil && portForwardChanged(previous, r.runCtx) {
+			portForwarder, restartErr = r.restartPortForwarder(ctx, out, portForwarder)
+		}
+		if restartErr == nil && !intents.paused && changed.pending() {
+			restartErr = r.applyChanges(ctx, out, logger, &changed, intents.auto)
+		}
+		lock.Unlock()
+		if restartErr != nil {
+			return restartErr
+		}
+
+		r.Watcher.Reset()
+		if err := r.watchChanges(ctx, &lock, &changed, artifacts); err != nil {
+			return err
+		}
 	}
+}
 
+// watchChanges registers the artifacts, the test and deploy dependencies
+// and the skaffold configuration with the watcher.
+func (r *SkaffoldRunner) watchChanges(ctx context.Context, lock gosync.Locker, changed *changes, artifacts []*latest.Artifact) error {
 	// Watch artifacts
 	for i := range artifacts {
 		artifact := artifacts[i]
@@ -97,7 +195,13 @@ func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*la
 
 		if err := r.Watcher.Register(
 			func() ([]string, error) { return r.Builder.DependenciesForArtifact(ctx, artifact) },
-			func(e watch.Events) { changed.AddDirtyArtifact(artifact, e) },
+			func(e watch.Events) {
+				event.FilesChanged(artifact.ImageName, e.Added, e.Modified, e.Deleted)
+				metrics.FilesChanged(artifact.ImageName, len(e.Added)+len(e.Modified)+len(e.Deleted))
+				lock.Lock()
+				changed.AddDirtyArtifact(artifact, e)
+				lock.Unlock()
+			},
 		); err != nil {
 			return errors.Wrapf(err, "watching files for artifact %s", artifact.ImageName)
 		}
@@ -105,45 +209,179 @@ func (r *SkaffoldRunner) Dev(ctx context.Context, out io.Writer, artifacts []*la
 
 	// Watch test configuration
 	if err := r.Watcher.Register(
-		r.TestDependencies,
-		func(watch.Events) { changed.needsRedeploy = true },
+		func() ([]string, error) { return r.TestDependencies() },
+		func(e watch.Events) {
+			event.FilesChanged("test", e.Added, e.Modified, e.Deleted)
+			metrics.FilesChanged("test", len(e.Added)+len(e.Modified)+len(e.Deleted))
+			lock.Lock()
+			changed.needsRedeploy = true
+			lock.Unlock()
+		},
 	); err != nil {
 		return errors.Wrap(err, "watching test files")
 	}
 
 	// Watch deployment configuration
 	if err := r.Watcher.Register(
-		r.Dependencies,
-		func(watch.Events) { changed.needsRedeploy = true },
+		func() ([]string, error) { return r.Dependencies() },
+		func(e watch.Events) {
+			event.FilesChanged("deploy", e.Added, e.Modified, e.Deleted)
+			metrics.FilesChanged("deploy", len(e.Added)+len(e.Modified)+len(e.Deleted))
+			lock.Lock()
+			changed.needsRedeploy = true
+			lock.Unlock()
+		},
 	); err != nil {
 		return errors.Wrap(err, "watching files for deployer")
 	}
 
 	// Watch Skaffold configuration
 	if err := r.Watcher.Register(
-		func() ([]string, error) { return []string{r.runCtx.Opts.ConfigurationFile}, nil },
-		func(watch.Events) { changed.needsReload = true },
+		func() ([]string, error) {
+			if len(r.ConfigFiles) == 0 {
+				return []string{r.runCtx.Opts.ConfigurationFile}, nil
+			}
+			return r.ConfigFiles, nil
+		},
+		func(e watch.Events) {
+			event.FilesChanged("config", e.Added, e.Modified, e.Deleted)
+			metrics.FilesChanged("config", len(e.Added)+len(e.Modified)+len(e.Deleted))
+			lock.Lock()
+			changed.needsReload = true
+			lock.Unlock()
+		},
 	); err != nil {
 		return errors.Wrapf(err, "watching skaffold configuration %s", r.runCtx.Opts.ConfigurationFile)
 	}
 
-	// First run
-	if err := r.buildTestDeploy(ctx, out, artifacts); err != nil {
-		return errors.Wrap(err, "exiting dev mode because first run failed")
+	return nil
+}
+
+// handleIntent updates the dev loop according to an intent received through the control API,
+// and runs the phases requested by the user.
+func (r *SkaffoldRunner) handleIntent(ctx context.Context, out io.Writer, logger *kubernetes.LogAggregator, changed *changes, intents *intents, artifacts []*latest.Artifact, intent runcontext.Intent) error {
+	if intent.LogFilters != nil {
+		filter, err := kubernetes.NewLogFilter(intent.LogFilters.Include, intent.LogFilters.Exclude)
+		if err != nil {
+			logrus.Warnln("Ignoring log filters:", err)
+		} else {
+			logger.SetFilter(filter)
+		}
 	}
 
-	// Start logs
-	if r.runCtx.Opts.TailDev {
-		if err := logger.Start(ctx); err != nil {
-			return errors.Wrap(err, "starting logger")
+	wasPaused := intents.paused
+	intents.update(intent)
+
+	resumed := !intents.paused && wasPaused
+	switch {
+	case intents.paused && !wasPaused:
+		color.Yellow.Fprintln(out, "Dev loop paused. Changes will be applied when it is resumed.")
+	case resumed:
+		color.Yellow.Fprintln(out, "Dev loop resumed.")
+	}
+	if intents.paused {
+		return nil
+	}
+	// Only a request for a phase, or resuming with pending changes, runs the dev loop.
+	if !requestsPhase(intent) && !(resumed && changed.pending()) {
+		return nil
+	}
+
+	if intent.Build {
+		for _, name := range intent.Artifacts {
+			artifact := findArtifact(artifacts, name)
+			if artifact == nil {
+				logrus.Warnf("Ignoring build request for unknown artifact %s", name)
+				continue
+			}
+			changed.AddRebuild(artifact)
 		}
 	}
+	if intent.Deploy {
+		changed.needsRedeploy = true
+	}
 
-	if r.runCtx.Opts.PortForward {
-		if err := portForwarder.Start(ctx); err != nil {
-			return errors.Wrap(err, "starting port-forwarder")
+	return r.applyChanges(ctx, out, logger, changed, intents.allowed(intent))
+}
+
+// applyChanges runs the phases of the dev loop for the pending changes.
+// Changes pending for a phase that isn't allowed are kept for later.
+func (r *SkaffoldRunner) applyChanges(ctx context.Context, out io.Writer, logger *kubernetes.LogAggregator, changed *changes, allowed phases) error {
+	event.DevLoopIterationStarted()
+
+	// The other changes are kept and applied once the configuration is reloaded.
+	if changed.needsReload {
+		changed.needsReload = false
+		return ErrorConfigurationChanged
+	}
+
+	var deferred []*latest.Artifact
+	defer func() {
+		for _, artifact := range deferred {
+			changed.AddRebuild(artifact)
+		}
+	}()
+	defer changed.reset(allowed)
+
+	for _, a := range changed.dirtyArtifacts {
+		s, err := sync.NewItem(a.artifact, a.events, r.builds, r.runCtx.InsecureRegistries)
+		if err != nil {
+			return errors.Wrap(err, "sync")
+		}
+		if s != nil {
+			changed.AddResync(s)
+		} else {
+			changed.AddRebuild(a.artifact)
+		}
+	}
+	changed.needsRebuild, deferred = r.deferAttached(out, changed.needsRebuild)
+
+	logger.Mute()
+	defer logger.Unmute()
+
+	switch {
+	case len(changed.needsResync) > 0 && allowed.sync:
+		for _, s := range changed.needsResync {
+			fileCount := len(s.Copy) + len(s.Delete)
+			color.Default.Fprintf(out, "Syncing %d files for %s\n", fileCount, s.Image)
+
+			event.FileSyncInProgress(s.Artifact, fileCount)
+			start := time.Now()
+			err := r.Syncer.Sync(ctx, s)
+			metrics.FilesSynced(s.Artifact, fileCount, err)
+			if err != nil {
+				event.FileSyncFailed(s.Artifact, fileCount, err, time.Since(start))
+				logrus.Warnln("Skipping deploy due to sync error:", err)
+				return nil
+			}
+			event.FileSyncComplete(s.Artifact, fileCount, time.Since(start))
+		}
+	case len(changed.needsRebuild) > 0 && allowed.build && allowed.deploy:
+		if err := r.buildTestDeploy(ctx, out, changed.needsRebuild); err != nil {
+			logrus.Warnln("Skipping deploy due to error:", err)
+			return nil
+		}
+	case len(changed.needsRebuild) > 0 && allowed.build:
+		if err := r.buildTest(ctx, out, changed.needsRebuild); err != nil {
+			logrus.Warnln("Skipping deploy due to error:", err)
+			return nil
+		}
+		changed.needsRedeploy = true
+	case changed.needsRedeploy && allowed.deploy:
+		if err := r.Deploy(ctx, out, r.builds); err != nil {
+			logrus.Warnln("Skipping deploy due to error:", err)
+			return nil
 		}
 	}
 
-	return r.Watcher.Run(ctx, out, onChange)
+	return nil
+}
+
+func findArtifact(artifacts []*latest.Artifact, imageName string) *latest.Artifact {
+	for _, artifact := range artifacts {
+		if artifact.ImageName == imageName {
+			return artifact
+		}
+	}
+	return nil
 }
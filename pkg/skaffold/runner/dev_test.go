@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"testing"
 
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/sync"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
@@ -40,6 +41,8 @@ func (t *NoopWatcher) Run(context.Context, io.Writer, func() error) error {
 	return nil
 }
 
+func (t *NoopWatcher) Reset() {}
+
 type FailWatcher struct{}
 
 func (t *FailWatcher) Register(func() ([]string, error), func(watch.Events)) error {
@@ -50,6 +53,8 @@ func (t *FailWatcher) Run(context.Context, io.Writer, func() error) error {
 	return errors.New("BUG")
 }
 
+func (t *FailWatcher) Reset() {}
+
 type TestWatcher struct {
 	events    []watch.Events
 	callbacks []func(watch.Events)
@@ -61,8 +66,15 @@ func (t *TestWatcher) Register(deps func() ([]string, error), onChange func(watc
 	return nil
 }
 
+func (t *TestWatcher) Reset() {
+	t.callbacks = nil
+}
+
 func (t *TestWatcher) Run(ctx context.Context, out io.Writer, onChange func() error) error {
-	for _, evt := range t.events {
+	// Events are consumed so that the watcher can be run again after a reload.
+	for len(t.events) > 0 {
+		evt := t.events[0]
+		t.events = t.events[1:]
 		t.testBench.enterNewCycle()
 
 		for _, file := range evt.Modified {
@@ -73,6 +85,8 @@ func (t *TestWatcher) Run(ctx context.Context, out io.Writer, onChange func() er
 				t.callbacks[1](evt) // 2nd artifact changed
 			case "manifest.yaml":
 				t.callbacks[3](evt) // deployment configuration changed
+			case "skaffold.yaml":
+				t.callbacks[len(t.callbacks)-1](evt) // skaffold configuration changed
 			}
 		}
 
@@ -349,3 +363,363 @@ func TestDevSync(t *testing.T) {
 		})
 	}
 }
+
+func TestDevIntents(t *testing.T) {
+	paused := false
+	enabled := true
+
+	var tests = []struct {
+		description     string
+		auto            phases
+		paused          bool
+		changed         []string
+		intents         []runcontext.Intent
+		expectedActions []Actions
+	}{
+		{
+			description: "build waits for intent",
+			auto:        phases{sync: true, deploy: true},
+			changed:     []string{"img1"},
+			intents:     []runcontext.Intent{{Build: true}},
+			expectedActions: []Actions{
+				{
+					Built:    []string{"img1:1", "img2:1"},
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+				{},
+				{
+					Built:    []string{"img1:2"},
+					Tested:   []string{"img1:2"},
+					Deployed: []string{"img1:2", "img2:1"},
+				},
+			},
+		},
+		{
+			description: "build named artifact",
+			auto:        phases{build: true, sync: true, deploy: true},
+			intents:     []runcontext.Intent{{Build: true, Artifacts: []string{"img2", "unknown"}}},
+			expectedActions: []Actions{
+				{
+					Built:    []string{"img1:1", "img2:1"},
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+				{},
+				{
+					Built:    []string{"img2:2"},
+					Tested:   []string{"img2:2"},
+					Deployed: []string{"img2:2", "img1:1"},
+				},
+			},
+		},
+		{
+			description: "deploy waits for intent",
+			auto:        phases{build: true, sync: true},
+			changed:     []string{"img1"},
+			intents:     []runcontext.Intent{{Deploy: true}},
+			expectedActions: []Actions{
+				{
+					Built:    []string{"img1:1", "img2:1"},
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+				{
+					Built:  []string{"img1:2"},
+					Tested: []string{"img1:2"},
+				},
+				{
+					Deployed: []string{"img1:2", "img2:1"},
+				},
+			},
+		},
+		{
+			description: "changes are applied on resume",
+			auto:        phases{build: true, sync: true, deploy: true},
+			paused:      true,
+			changed:     []string{"img2"},
+			intents:     []runcontext.Intent{{Paused: &paused}},
+			expectedActions: []Actions{
+				{
+					Built:    []string{"img1:1", "img2:1"},
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+				{},
+				{
+					Built:    []string{"img2:2"},
+					Tested:   []string{"img2:2"},
+					Deployed: []string{"img2:2", "img1:1"},
+				},
+			},
+		},
+		{
+			description: "settings don't apply pending changes",
+			auto:        phases{sync: true, deploy: true},
+			changed:     []string{"img1"},
+			intents: []runcontext.Intent{
+				{AutoBuild: &enabled},
+				{LogFilters: &runcontext.LogFilters{Include: []string{"img1"}}},
+			},
+			expectedActions: []Actions{
+				{
+					Built:    []string{"img1:1", "img2:1"},
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+				{},
+				{},
+				{},
+			},
+		},
+		{
+			description: "resume without changes",
+			auto:        phases{build: true, sync: true, deploy: true},
+			paused:      true,
+			intents:     []runcontext.Intent{{Paused: &paused}},
+			expectedActions: []Actions{
+				{
+					Built:    []string{"img1:1", "img2:1"},
+					Tested:   []string{"img1:1", "img2:1"},
+					Deployed: []string{"img1:1", "img2:1"},
+				},
+				{},
+				{},
+			},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.SetupFakeKubernetesContext(api.Config{CurrentContext: "cluster1"})
+
+			testBench := &TestBench{}
+			runner := createRunner(t, testBench)
+			artifacts := []*latest.Artifact{
+				{ImageName: "img1"},
+				{ImageName: "img2"},
+			}
+			ctx := context.Background()
+			logger, err := runner.newLogger(ioutil.Discard, artifacts)
+			t.CheckNoError(err)
+
+			err = runner.buildTestDeploy(ctx, ioutil.Discard, artifacts)
+			t.CheckNoError(err)
+
+			// Changes detected by the watcher
+			testBench.enterNewCycle()
+			changed := changes{}
+			for _, name := range test.changed {
+				changed.AddDirtyArtifact(findArtifact(artifacts, name), watch.Events{Modified: []string{name}})
+			}
+			intents := &intents{auto: test.auto, paused: test.paused}
+			if !intents.paused {
+				err = runner.applyChanges(ctx, ioutil.Discard, logger, &changed, intents.auto)
+				t.CheckNoError(err)
+			}
+
+			// Intents received through the control API
+			for _, intent := range test.intents {
+				testBench.enterNewCycle()
+				err = runner.handleIntent(ctx, ioutil.Discard, logger, &changed, intents, artifacts, intent)
+				t.CheckNoError(err)
+			}
+
+			t.CheckDeepEqual(test.expectedActions, testBench.Actions())
+		})
+	}
+}
+
+// fakeAttachments reports a debugger attached to fixed images.
+type fakeAttachments []string
+
+func (f fakeAttachments) ConnectedImages([]string) []string {
+	return f
+}
+
+func TestDevDebugAttached(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		t.SetupFakeKubernetesContext(api.Config{CurrentContext: "cluster1"})
+
+		testBench := &TestBench{}
+		runner := createRunner(t, testBench)
+		runner.debugAttachments = fakeAttachments{"img1:1"}
+		runner.Watcher = &TestWatcher{
+			events: []watch.Events{
+				{Modified: []string{"file1", "file2"}},
+			},
+			testBench: testBench,
+		}
+
+		err := runner.Dev(context.Background(), ioutil.Discard, []*latest.Artifact{
+			{ImageName: "img1"},
+			{ImageName: "img2"},
+		})
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]Actions{
+			{
+				Built:    []string{"img1:1", "img2:1"},
+				Tested:   []string{"img1:1", "img2:1"},
+				Deployed: []string{"img1:1", "img2:1"},
+			},
+			{
+				Built:    []string{"img2:2"},
+				Tested:   []string{"img2:2"},
+				Deployed: []string{"img2:2", "img1:1"},
+			},
+		}, testBench.Actions())
+	})
+}
+
+func TestRebuildDetached(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		t.SetupFakeKubernetesContext(api.Config{CurrentContext: "cluster1"})
+
+		testBench := &TestBench{}
+		runner := createRunner(t, testBench)
+		artifacts := []*latest.Artifact{
+			{ImageName: "img1"},
+			{ImageName: "img2"},
+		}
+		ctx := context.Background()
+		logger, err := runner.newLogger(ioutil.Discard, artifacts)
+		t.CheckNoError(err)
+
+		err = runner.buildTestDeploy(ctx, ioutil.Discard, artifacts)
+		t.CheckNoError(err)
+
+		// img1 has a debugger attached
+		runner.debugAttachments = fakeAttachments{"img1:1"}
+		testBench.enterNewCycle()
+		changed := changes{}
+		changed.AddDirtyArtifact(artifacts[0], watch.Events{Modified: []string{"file1"}})
+		intents := &intents{auto: phases{build: true, sync: true, deploy: true}}
+		err = runner.applyChanges(ctx, ioutil.Discard, logger, &changed, intents.auto)
+		t.CheckNoError(err)
+
+		// the debugger detaches
+		runner.debugAttachments = fakeAttachments{}
+		testBench.enterNewCycle()
+		err = runner.rebuildDetached(ctx, ioutil.Discard, logger, &changed, intents)
+		t.CheckNoError(err)
+
+		// nothing left to rebuild
+		testBench.enterNewCycle()
+		err = runner.rebuildDetached(ctx, ioutil.Discard, logger, &changed, intents)
+		t.CheckNoError(err)
+
+		t.CheckDeepEqual([]Actions{
+			{
+				Built:    []string{"img1:1", "img2:1"},
+				Tested:   []string{"img1:1", "img2:1"},
+				Deployed: []string{"img1:1", "img2:1"},
+			},
+			{},
+			{
+				Built:    []string{"img1:2"},
+				Tested:   []string{"img1:2"},
+				Deployed: []string{"img1:2", "img2:1"},
+			},
+			{},
+		}, testBench.Actions())
+	})
+}
+
+func TestDevReload(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		t.SetupFakeKubernetesContext(api.Config{CurrentContext: "cluster1"})
+
+		testBench := &TestBench{}
+		runner := createRunner(t, testBench)
+		artifacts := []*latest.Artifact{
+			{ImageName: "img1"},
+			{ImageName: "img2"},
+		}
+		runner.runCtx.Cfg.Build.Artifacts = artifacts
+		reloads := 0
+		runner.ReloadConfig = func() (*latest.SkaffoldConfig, error) {
+			reloads++
+			return nil, errors.New("invalid configuration")
+		}
+		runner.Watcher = &TestWatcher{
+			events: []watch.Events{
+				{Modified: []string{"skaffold.yaml"}},
+				{Modified: []string{"file1"}},
+			},
+			testBench: testBench,
+		}
+
+		err := runner.Dev(context.Background(), ioutil.Discard, artifacts)
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual(1, reloads)
+		t.CheckDeepEqual([]Actions{
+			{
+				Built:    []string{"img1:1", "img2:1"},
+				Tested:   []string{"img1:1", "img2:1"},
+				Deployed: []string{"img1:1", "img2:1"},
+			},
+			{},
+			{
+				Built:    []string{"img1:2"},
+				Tested:   []string{"img1:2"},
+				Deployed: []string{"img1:2", "img2:1"},
+			},
+		}, testBench.Actions())
+	})
+}
+
+func TestDevReloadWithSourceChanges(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		t.SetupFakeKubernetesContext(api.Config{CurrentContext: "cluster1"})
+
+		testBench := &TestBench{}
+		runner := createRunner(t, testBench)
+		artifacts := []*latest.Artifact{
+			{ImageName: "img1"},
+			{ImageName: "img2"},
+		}
+		runner.runCtx.Cfg.Build.Artifacts = artifacts
+		runner.ReloadConfig = func() (*latest.SkaffoldConfig, error) {
+			return nil, errors.New("invalid configuration")
+		}
+		runner.Watcher = &TestWatcher{
+			events: []watch.Events{
+				{Modified: []string{"skaffold.yaml", "file1"}},
+			},
+			testBench: testBench,
+		}
+
+		err := runner.Dev(context.Background(), ioutil.Discard, artifacts)
+
+		// img1 is rebuilt once the configuration is reloaded
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]Actions{
+			{
+				Built:    []string{"img1:1", "img2:1"},
+				Tested:   []string{"img1:1", "img2:1"},
+				Deployed: []string{"img1:1", "img2:1"},
+			},
+			{
+				Built:    []string{"img1:2"},
+				Tested:   []string{"img1:2"},
+				Deployed: []string{"img1:2", "img2:1"},
+			},
+		}, testBench.Actions())
+	})
+}
+
+func TestApplyChangesUnmutesLogs(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		t.SetupFakeKubernetesContext(api.Config{CurrentContext: "cluster1"})
+
+		runner := createRunner(t, &TestBench{})
+		logger, err := runner.newLogger(ioutil.Discard, nil)
+		t.CheckNoError(err)
+
+		err = runner.applyChanges(context.Background(), ioutil.Discard, logger, &changes{needsReload: true}, phases{build: true, sync: true, deploy: true})
+
+		t.CheckDeepEqual(true, err == ErrorConfigurationChanged)
+		t.CheckDeepEqual(false, logger.IsMuted())
+	})
+}
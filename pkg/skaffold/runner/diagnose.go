@@ -88,6 +88,26 @@ func (r *SkaffoldRunner) DiagnoseArtifacts(out io.Writer) error {
 	return nil
 }
 
+// ListDependencies lists the files each artifact depends on, by image name,
+// and the files the deployers depend on.
+func (r *SkaffoldRunner) ListDependencies(ctx context.Context) (map[string][]string, []string, error) {
+	artifacts := map[string][]string{}
+	for _, artifact := range r.runCtx.Cfg.Build.Artifacts {
+		deps, err := r.Builder.DependenciesForArtifact(ctx, artifact)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "listing dependencies of artifact %s", artifact.ImageName)
+		}
+		artifacts[artifact.ImageName] = deps
+	}
+
+	deploy, err := r.Deployer.Dependencies()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing deploy dependencies")
+	}
+
+	return artifacts, deploy, nil
+}
+
 func typeOfArtifact(a *latest.Artifact) string {
 	switch {
 	case a.DockerArtifact != nil:
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+)
+
+// phases lists the phases of the dev loop that are allowed to run.
+type phases struct {
+	build  bool
+	sync   bool
+	deploy bool
+}
+
+// intents tracks whether the dev loop is paused and which phases
+// are run automatically when a change is detected.
+type intents struct {
+	paused bool
+	auto   phases
+}
+
+func newIntents(opts *config.SkaffoldOptions) *intents {
+	return &intents{
+		auto: phases{
+			build:  opts.AutoBuild,
+			sync:   opts.AutoSync,
+			deploy: opts.AutoDeploy,
+		},
+	}
+}
+
+// update applies the settings of an intent received through the control API.
+func (i *intents) update(intent runcontext.Intent) {
+	if intent.Paused != nil {
+		i.paused = *intent.Paused
+	}
+	if intent.AutoBuild != nil {
+		i.auto.build = *intent.AutoBuild
+	}
+	if intent.AutoSync != nil {
+		i.auto.sync = *intent.AutoSync
+	}
+	if intent.AutoDeploy != nil {
+		i.auto.deploy = *intent.AutoDeploy
+	}
+}
+
+// requestsPhase tells whether an intent asks for a build, a sync or a deploy.
+func requestsPhase(intent runcontext.Intent) bool {
+	return intent.Build || intent.Sync || intent.Deploy
+}
+
+// allowed returns the phases allowed to run for an intent.
+func (i *intents) allowed(intent runcontext.Intent) phases {
+	return phases{
+		build:  i.auto.build || intent.Build,
+		sync:   i.auto.sync || intent.Sync,
+		deploy: i.auto.deploy || intent.Deploy,
+	}
+}
@@ -18,14 +18,16 @@ package runner
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
 	"github.com/pkg/errors"
 )
 
-func (r *SkaffoldRunner) newLogger(out io.Writer, artifacts []*latest.Artifact) *kubernetes.LogAggregator {
+func (r *SkaffoldRunner) newLogger(out io.Writer, artifacts []*latest.Artifact) (*kubernetes.LogAggregator, error) {
 	var imageNames []string
 	for _, artifact := range artifacts {
 		imageNames = append(imageNames, artifact.ImageName)
@@ -33,8 +35,62 @@ func (r *SkaffoldRunner) newLogger(out io.Writer, artifacts []*latest.Artifact)
 	return r.newLoggerForImages(out, imageNames)
 }
 
-func (r *SkaffoldRunner) newLoggerForImages(out io.Writer, images []string) *kubernetes.LogAggregator {
-	return kubernetes.NewLogAggregator(out, images, r.imageList, r.runCtx.Namespaces)
+func (r *SkaffoldRunner) newLoggerForImages(out io.Writer, images []string) (*kubernetes.LogAggregator, error) {
+	opts, err := r.logOptions()
+	if err != nil {
+		return nil, err
+	}
+	podSelector, namespaces, err := r.logSelectors()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewLogAggregator(out, images, podSelector, namespaces, opts), nil
+}
+
+// logSelectors returns the pods to stream logs from and the namespaces to watch:
+// the pods running the images built by Skaffold and the pods listed in the `logs`
+// section of the deploy config.
+func (r *SkaffoldRunner) logSelectors() (kubernetes.PodSelector, []string, error) {
+	logs := r.runCtx.Cfg.Deploy.Logs
+	if logs == nil {
+		return r.imageList, r.runCtx.Namespaces, nil
+	}
+
+	selector, err := kubernetes.NewLabelSelector(logs.Selectors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namespaces := append([]string{}, r.runCtx.Namespaces...)
+	for _, ns := range logs.Namespaces {
+		if !util.StrSliceContains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	return kubernetes.PodSelectors{r.imageList, selector}, namespaces, nil
+}
+
+func (r *SkaffoldRunner) logOptions() (kubernetes.LogOptions, error) {
+	filter, err := kubernetes.NewLogFilter(r.runCtx.Opts.LogInclude, r.runCtx.Opts.LogExclude)
+	if err != nil {
+		return kubernetes.LogOptions{}, err
+	}
+
+	var json bool
+	switch r.runCtx.Opts.LogFormat {
+	case "", "raw":
+	case "json":
+		json = true
+	default:
+		return kubernetes.LogOptions{}, fmt.Errorf("unknown log format %s", r.runCtx.Opts.LogFormat)
+	}
+
+	return kubernetes.LogOptions{
+		Filter: filter,
+		JSON:   json,
+		Dir:    r.runCtx.Opts.LogDir,
+	}, nil
 }
 
 func (r *SkaffoldRunner) TailLogs(ctx context.Context, out io.Writer, logger *kubernetes.LogAggregator) error {
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/debug"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+)
+
+func (r *SkaffoldRunner) newPortForwarder(out io.Writer) *kubernetes.PortForwarder {
+	resources := r.runCtx.Cfg.PortForward
+
+	// When debugging without --port-forward, only the debug ports are forwarded
+	// so that attached debuggers can be detected.
+	debugOnly := r.runCtx.Opts.Command == "debug" && !r.runCtx.Opts.PortForward
+	if debugOnly {
+		resources = nil
+	}
+
+	namespaces := append([]string{}, r.runCtx.Namespaces...)
+	for _, resource := range resources {
+		if resource.Namespace != "" && !util.StrSliceContains(namespaces, resource.Namespace) {
+			namespaces = append(namespaces, resource.Namespace)
+		}
+	}
+
+	portForwarder := kubernetes.NewPortForwarder(out, r.imageList, namespaces, resources)
+	if debugOnly {
+		portForwarder.ForwardOnly(debug.PortNames)
+	}
+	if r.debugDetached != nil {
+		portForwarder.OnDisconnect(func() {
+			select {
+			case r.debugDetached <- struct{}{}:
+			default:
+			}
+		})
+	}
+	return portForwarder
+}
+
+// forwardsPorts tells whether `dev` forwards ports. While debugging,
+// the debug ports are always forwarded.
+func (r *SkaffoldRunner) forwardsPorts() bool {
+	return r.runCtx.Opts.PortForward || r.runCtx.Opts.Command == "debug"
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// pipelineChanges describes what needs to be done after the configuration has changed.
+type pipelineChanges struct {
+	rebuild  []*latest.Artifact
+	redeploy bool
+}
+
+// diffPipelines compares two versions of a pipeline. New artifacts and artifacts whose
+// definition changed have to be rebuilt. Every artifact is rebuilt if the global build
+// configuration changed. A redeploy is needed if artifacts were removed or if the test
+// or deploy configuration changed.
+func diffPipelines(oldPipeline, newPipeline *latest.Pipeline) pipelineChanges {
+	var changes pipelineChanges
+
+	oldArtifacts := map[string]*latest.Artifact{}
+	for _, a := range oldPipeline.Build.Artifacts {
+		oldArtifacts[a.ImageName] = a
+	}
+
+	buildChanged := !reflect.DeepEqual(withoutArtifacts(oldPipeline.Build), withoutArtifacts(newPipeline.Build))
+	for _, a := range newPipeline.Build.Artifacts {
+		if buildChanged || !reflect.DeepEqual(oldArtifacts[a.ImageName], a) {
+			changes.rebuild = append(changes.rebuild, a)
+		}
+		delete(oldArtifacts, a.ImageName)
+	}
+
+	changes.redeploy = len(oldArtifacts) > 0 ||
+		!reflect.DeepEqual(oldPipeline.Test, newPipeline.Test) ||
+		!reflect.DeepEqual(oldPipeline.Deploy, newPipeline.Deploy)
+
+	return changes
+}
+
+func withoutArtifacts(cfg latest.BuildConfig) latest.BuildConfig {
+	cfg.Artifacts = nil
+	return cfg
+}
+
+// reload reads the skaffold configuration again and updates the runner in place.
+// Only the artifacts that changed are rebuilt and the deployment is only redone
+// if needed. An invalid configuration is reported and the previous one is kept.
+// The pending changes are pointed to the artifacts of the new configuration.
+func (r *SkaffoldRunner) reload(ctx context.Context, out io.Writer, changed *changes) {
+	event.ConfigReloadInProgress()
+	start := time.Now()
+
+	cfg, changes, err := r.reconfigure()
+	if err != nil {
+		event.ConfigReloadFailed(err, time.Since(start))
+		logrus.Warnln("Keeping previous configuration due to error:", err)
+		return
+	}
+	event.ConfigReloadComplete(time.Since(start))
+	color.Default.Fprintln(out, "Configuration reloaded")
+
+	// Forget about the artifacts that were removed.
+	var builds []build.Artifact
+	for _, b := range r.builds {
+		if findArtifact(cfg.Build.Artifacts, b.ImageName) != nil {
+			builds = append(builds, b)
+		}
+	}
+	r.builds = builds
+	changed.resolve(cfg.Build.Artifacts, changes.rebuild)
+
+	switch {
+	case len(changes.rebuild) > 0:
+		if err := r.buildTestDeploy(ctx, out, changes.rebuild); err != nil {
+			logrus.Warnln("Skipping deploy due to error:", err)
+		}
+	case changes.redeploy:
+		if err := r.Deploy(ctx, out, r.builds); err != nil {
+			logrus.Warnln("Skipping deploy due to error:", err)
+		}
+	}
+}
+
+// reconfigure reads the skaffold configuration and reconfigures the runner with it.
+// It returns the new configuration and how the pipeline changed.
+func (r *SkaffoldRunner) reconfigure() (*latest.SkaffoldConfig, pipelineChanges, error) {
+	cfg, err := r.ReloadConfig()
+	if err != nil {
+		return nil, pipelineChanges{}, err
+	}
+
+	runCtx, err := runcontext.GetRunContext(r.runCtx.Opts, &cfg.Pipeline)
+	if err != nil {
+		return nil, pipelineChanges{}, err
+	}
+	// The gRPC and HTTP servers keep sending requests to the same channels.
+	runCtx.Trigger = r.runCtx.Trigger
+	runCtx.Intents = r.runCtx.Intents
+
+	changes := diffPipelines(r.runCtx.Cfg, runCtx.Cfg)
+	if err := r.configure(runCtx); err != nil {
+		return nil, pipelineChanges{}, err
+	}
+	return cfg, changes, nil
+}
+
+// logsChanged tells whether the logs have to be streamed again after a reload,
+// because the pods or the namespaces to stream them from changed.
+func logsChanged(oldCtx, newCtx *runcontext.RunContext) bool {
+	return !reflect.DeepEqual(oldCtx.Cfg.Deploy.Logs, newCtx.Cfg.Deploy.Logs) ||
+		!reflect.DeepEqual(oldCtx.Namespaces, newCtx.Namespaces)
+}
+
+// portForwardChanged tells whether the ports have to be forwarded again after a reload,
+// because the declared resources or the namespaces changed.
+func portForwardChanged(oldCtx, newCtx *runcontext.RunContext) bool {
+	return !reflect.DeepEqual(oldCtx.Cfg.PortForward, newCtx.Cfg.PortForward) ||
+		!reflect.DeepEqual(oldCtx.Namespaces, newCtx.Namespaces)
+}
+
+// restartLogger stops a logger and starts a new one with the current configuration.
+// The log filter set through the control API is kept.
+func (r *SkaffoldRunner) restartLogger(ctx context.Context, out io.Writer, artifacts []*latest.Artifact, old *kubernetes.LogAggregator) (*kubernetes.LogAggregator, error) {
+	old.Stop()
+
+	logger, err := r.newLogger(out, artifacts)
+	if err != nil {
+		return old, err
+	}
+	logger.SetFilter(old.Filter())
+
+	if r.runCtx.Opts.TailDev {
+		if err := logger.Start(ctx); err != nil {
+			return logger, errors.Wrap(err, "starting logger")
+		}
+	}
+	return logger, nil
+}
+
+// restartPortForwarder stops a port forwarder and starts a new one with the current configuration.
+func (r *SkaffoldRunner) restartPortForwarder(ctx context.Context, out io.Writer, old *kubernetes.PortForwarder) (*kubernetes.PortForwarder, error) {
+	old.Stop()
+
+	portForwarder := r.newPortForwarder(out)
+	if r.runCtx.Opts.Command == "debug" {
+		r.debugAttachments = portForwarder
+	}
+
+	if r.forwardsPorts() {
+		if err := portForwarder.Start(ctx); err != nil {
+			return portForwarder, errors.Wrap(err, "starting port-forwarder")
+		}
+	}
+	return portForwarder, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/watch"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestDiffPipelines(t *testing.T) {
+	var tests = []struct {
+		description      string
+		oldPipeline      latest.Pipeline
+		newPipeline      latest.Pipeline
+		expectedRebuild  []string
+		expectedRedeploy bool
+	}{
+		{
+			description: "no change",
+			oldPipeline: pipeline(artifact("img1", "."), artifact("img2", ".")),
+			newPipeline: pipeline(artifact("img1", "."), artifact("img2", ".")),
+		},
+		{
+			description:     "artifact changed",
+			oldPipeline:     pipeline(artifact("img1", "."), artifact("img2", ".")),
+			newPipeline:     pipeline(artifact("img1", "."), artifact("img2", "other")),
+			expectedRebuild: []string{"img2"},
+		},
+		{
+			description:     "artifact added",
+			oldPipeline:     pipeline(artifact("img1", ".")),
+			newPipeline:     pipeline(artifact("img1", "."), artifact("img2", ".")),
+			expectedRebuild: []string{"img2"},
+		},
+		{
+			description:      "artifact removed",
+			oldPipeline:      pipeline(artifact("img1", "."), artifact("img2", ".")),
+			newPipeline:      pipeline(artifact("img1", ".")),
+			expectedRedeploy: true,
+		},
+		{
+			description: "tag policy changed",
+			oldPipeline: pipeline(artifact("img1", "."), artifact("img2", ".")),
+			newPipeline: func() latest.Pipeline {
+				p := pipeline(artifact("img1", "."), artifact("img2", "."))
+				p.Build.TagPolicy = latest.TagPolicy{ShaTagger: &latest.ShaTagger{}}
+				return p
+			}(),
+			expectedRebuild: []string{"img1", "img2"},
+		},
+		{
+			description: "deploy changed",
+			oldPipeline: pipeline(artifact("img1", ".")),
+			newPipeline: func() latest.Pipeline {
+				p := pipeline(artifact("img1", "."))
+				p.Deploy.KubectlDeploy = &latest.KubectlDeploy{Manifests: []string{"k8s/*.yaml"}}
+				return p
+			}(),
+			expectedRedeploy: true,
+		},
+		{
+			description: "test changed",
+			oldPipeline: pipeline(artifact("img1", ".")),
+			newPipeline: func() latest.Pipeline {
+				p := pipeline(artifact("img1", "."))
+				p.Test = []*latest.TestCase{{ImageName: "img1", StructureTests: []string{"test.yaml"}}}
+				return p
+			}(),
+			expectedRedeploy: true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			changes := diffPipelines(&test.oldPipeline, &test.newPipeline)
+
+			var rebuild []string
+			for _, a := range changes.rebuild {
+				rebuild = append(rebuild, a.ImageName)
+			}
+			t.CheckDeepEqual(test.expectedRebuild, rebuild)
+			t.CheckDeepEqual(test.expectedRedeploy, changes.redeploy)
+		})
+	}
+}
+
+func pipeline(artifacts ...*latest.Artifact) latest.Pipeline {
+	return latest.Pipeline{
+		Build: latest.BuildConfig{
+			Artifacts: artifacts,
+		},
+	}
+}
+
+func artifact(imageName, workspace string) *latest.Artifact {
+	return &latest.Artifact{
+		ImageName: imageName,
+		Workspace: workspace,
+	}
+}
+
+func TestChangesResolve(t *testing.T) {
+	oldApp, oldWeb, oldAPI := artifact("app", "."), artifact("web", "web"), artifact("api", "api")
+	newApp, newWeb := artifact("app", "."), artifact("web", "web2")
+
+	changed := changes{}
+	changed.AddDirtyArtifact(oldApp, watch.Events{Modified: []string{"main.go"}})
+	changed.AddDirtyArtifact(oldWeb, watch.Events{Modified: []string{"index.html"}})
+	changed.AddRebuild(oldAPI)
+	changed.AddRebuild(oldApp)
+
+	// api was removed and web is rebuilt with its new definition
+	changed.resolve([]*latest.Artifact{newApp, newWeb}, []*latest.Artifact{newWeb})
+
+	testutil.CheckDeepEqual(t, 1, len(changed.dirtyArtifacts))
+	testutil.CheckDeepEqual(t, true, changed.dirtyArtifacts[0].artifact == newApp)
+	testutil.CheckDeepEqual(t, []string{"main.go"}, changed.dirtyArtifacts[0].events.Modified)
+	testutil.CheckDeepEqual(t, 1, len(changed.needsRebuild))
+	testutil.CheckDeepEqual(t, true, changed.needsRebuild[0] == newApp)
+}
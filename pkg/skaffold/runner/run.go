@@ -21,6 +21,7 @@ import (
 	"io"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/pkg/errors"
 )
 
 // Run builds artifacts, runs tests on built artifacts, and then deploys them.
@@ -28,9 +29,24 @@ func (r *SkaffoldRunner) Run(ctx context.Context, out io.Writer, artifacts []*la
 	if err := r.buildTestDeploy(ctx, out, artifacts); err != nil {
 		return err
 	}
+	if r.runCtx.Opts.PortForward {
+		portForwarder := r.newPortForwarder(out)
+		defer portForwarder.Stop()
+
+		if err := portForwarder.Start(ctx); err != nil {
+			return errors.Wrap(err, "starting port-forwarder")
+		}
+	}
+
 	if r.runCtx.Opts.Tail {
-		logger := r.newLogger(out, artifacts)
+		logger, err := r.newLogger(out, artifacts)
+		if err != nil {
+			return err
+		}
 		return r.TailLogs(ctx, out, logger)
 	}
+	if r.runCtx.Opts.PortForward {
+		<-ctx.Done()
+	}
 	return nil
 }
@@ -61,7 +61,17 @@ type SkaffoldRunner struct {
 	hasBuilt          bool
 	hasDeployed       bool
 	imageList         *kubernetes.ImageList
+	debugAttachments  debugAttachments
+	debugDetached     chan struct{}
 	RPCServerShutdown func() error
+
+	// ReloadConfig, if set, is used by `dev` to reload the configuration
+	// in place when skaffold.yaml changes.
+	ReloadConfig func() (*latest.SkaffoldConfig, error)
+
+	// ConfigFiles are the configuration files watched by `dev`, including
+	// the required configurations. Defaults to the file given with `--filename`.
+	ConfigFiles []string
 }
 
 // NewForConfig returns a new SkaffoldRunner for a SkaffoldConfig
@@ -71,14 +81,57 @@ func NewForConfig(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) (*Sk
 		return nil, errors.Wrap(err, "getting run context")
 	}
 
-	tagger, err := getTagger(cfg.Build.TagPolicy, opts.CustomTag)
+	r := &SkaffoldRunner{
+		imageList: kubernetes.NewImageList(),
+	}
+	if err := r.configure(runCtx); err != nil {
+		return nil, err
+	}
+
+	trigger, err := watch.NewTrigger(runCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating watch trigger")
+	}
+	r.Watcher = watch.NewWatcher(trigger)
+
+	shutdown, err := server.Initialize(runCtx)
 	if err != nil {
-		return nil, errors.Wrap(err, "parsing tag config")
+		return nil, errors.Wrap(err, "initializing skaffold server")
+	}
+	r.RPCServerShutdown = shutdown
+
+	event.InitializeState(runCtx)
+	if opts.EventLogFile != "" {
+		closeEventLog, err := event.SaveEventsToFile(opts.EventLogFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "saving events")
+		}
+		r.RPCServerShutdown = func() error {
+			// Save the last events before shutting down.
+			if err := closeEventLog(); err != nil {
+				logrus.Warnln(err)
+			}
+			return shutdown()
+		}
+	}
+
+	event.LogSkaffoldMetadata(version.Get())
+
+	return r, nil
+}
+
+// configure creates the tagger, builder, tester, deployer and syncer for a given run context.
+func (r *SkaffoldRunner) configure(runCtx *runcontext.RunContext) error {
+	opts := runCtx.Opts
+
+	tagger, err := getTagger(runCtx.Cfg.Build.TagPolicy, opts.CustomTag)
+	if err != nil {
+		return errors.Wrap(err, "parsing tag config")
 	}
 
 	builder, err := getBuilder(runCtx)
 	if err != nil {
-		return nil, errors.Wrap(err, "parsing build config")
+		return errors.Wrap(err, "parsing build config")
 	}
 	artifactCache := cache.NewCache(builder, runCtx)
 
@@ -86,7 +139,7 @@ func NewForConfig(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) (*Sk
 
 	deployer, err := getDeployer(runCtx)
 	if err != nil {
-		return nil, errors.Wrap(err, "parsing deploy config")
+		return errors.Wrap(err, "parsing deploy config")
 	}
 
 	defaultLabeller := NewLabeller("")
@@ -97,32 +150,15 @@ func NewForConfig(opts *config.SkaffoldOptions, cfg *latest.SkaffoldConfig) (*Sk
 		deployer = WithNotification(deployer)
 	}
 
-	trigger, err := watch.NewTrigger(runCtx)
-	if err != nil {
-		return nil, errors.Wrap(err, "creating watch trigger")
-	}
-
-	shutdown, err := server.Initialize(runCtx)
-	if err != nil {
-		return nil, errors.Wrap(err, "initializing skaffold server")
-	}
-	event.InitializeState(runCtx)
-
-	event.LogSkaffoldMetadata(version.Get())
-
-	return &SkaffoldRunner{
-		Builder:           builder,
-		Tester:            tester,
-		Deployer:          deployer,
-		Tagger:            tagger,
-		Syncer:            kubectl.NewSyncer(runCtx.Namespaces),
-		Watcher:           watch.NewWatcher(trigger),
-		labellers:         labellers,
-		imageList:         kubernetes.NewImageList(),
-		cache:             artifactCache,
-		runCtx:            runCtx,
-		RPCServerShutdown: shutdown,
-	}, nil
+	r.Builder = builder
+	r.Tester = tester
+	r.Deployer = deployer
+	r.Tagger = tagger
+	r.Syncer = kubectl.NewSyncer(runCtx.Namespaces)
+	r.labellers = labellers
+	r.cache = artifactCache
+	r.runCtx = runCtx
+	return nil
 }
 
 func getBuilder(runCtx *runcontext.RunContext) (build.Builder, error) {
@@ -148,19 +184,28 @@ func getTester(runCtx *runcontext.RunContext) test.Tester {
 	return test.NewTester(runCtx)
 }
 
+// getDeployer returns the deployers of the configuration. Merged configurations
+// can use several deployers.
 func getDeployer(runCtx *runcontext.RunContext) (deploy.Deployer, error) {
-	switch {
-	case runCtx.Cfg.Deploy.HelmDeploy != nil:
-		return deploy.NewHelmDeployer(runCtx), nil
+	var deployers deploy.DeployerMux
 
-	case runCtx.Cfg.Deploy.KubectlDeploy != nil:
-		return deploy.NewKubectlDeployer(runCtx), nil
-
-	case runCtx.Cfg.Deploy.KustomizeDeploy != nil:
-		return deploy.NewKustomizeDeployer(runCtx), nil
+	if runCtx.Cfg.Deploy.HelmDeploy != nil {
+		deployers = append(deployers, deploy.NewHelmDeployer(runCtx))
+	}
+	if runCtx.Cfg.Deploy.KubectlDeploy != nil {
+		deployers = append(deployers, deploy.NewKubectlDeployer(runCtx))
+	}
+	if runCtx.Cfg.Deploy.KustomizeDeploy != nil {
+		deployers = append(deployers, deploy.NewKustomizeDeployer(runCtx))
+	}
 
-	default:
+	switch len(deployers) {
+	case 0:
 		return nil, fmt.Errorf("unknown deployer for config %+v", runCtx.Cfg.Deploy)
+	case 1:
+		return deployers[0], nil
+	default:
+		return deployers, nil
 	}
 }
 
@@ -248,6 +293,19 @@ func (r *SkaffoldRunner) imageTags(ctx context.Context, out io.Writer, artifacts
 }
 
 func (r *SkaffoldRunner) buildTestDeploy(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) error {
+	if err := r.buildTest(ctx, out, artifacts); err != nil {
+		return err
+	}
+
+	if err := r.deploy(ctx, out, r.builds); err != nil {
+		return errors.Wrap(err, "deploy failed")
+	}
+
+	return nil
+}
+
+// buildTest builds and tests artifacts, and records the results for the next deployment.
+func (r *SkaffoldRunner) buildTest(ctx context.Context, out io.Writer, artifacts []*latest.Artifact) error {
 	bRes, err := r.BuildAndTest(ctx, out, artifacts)
 	if err != nil {
 		return err
@@ -261,9 +319,5 @@ func (r *SkaffoldRunner) buildTestDeploy(ctx context.Context, out io.Writer, art
 	// Make sure all artifacts are redeployed. Not only those that were just built.
 	r.builds = build.MergeWithPreviousBuilds(bRes, r.builds)
 
-	if err := r.deploy(ctx, out, r.builds); err != nil {
-		return errors.Wrap(err, "deploy failed")
-	}
-
 	return nil
 }
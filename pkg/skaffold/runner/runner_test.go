@@ -146,7 +146,10 @@ func findTags(artifacts []build.Artifact) []string {
 
 func createRunner(t *testutil.T, testBench *TestBench) *SkaffoldRunner {
 	opts := &config.SkaffoldOptions{
-		Trigger: "polling",
+		Trigger:    "polling",
+		AutoBuild:  true,
+		AutoSync:   true,
+		AutoDeploy: true,
 	}
 
 	cfg := &latest.SkaffoldConfig{}
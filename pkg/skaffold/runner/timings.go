@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build/tag"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/color"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/deploy"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test"
 	"k8s.io/apimachinery/pkg/labels"
@@ -86,7 +87,9 @@ func (w withTimings) Deploy(ctx context.Context, out io.Writer, builds []build.A
 	start := time.Now()
 	color.Default.Fprintln(out, "Starting deploy...")
 
-	if err := w.Deployer.Deploy(ctx, out, builds, labellers); err != nil {
+	err := w.Deployer.Deploy(ctx, out, builds, labellers)
+	metrics.Deployed(time.Since(start), err)
+	if err != nil {
 		return err
 	}
 
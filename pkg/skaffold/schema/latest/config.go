@@ -20,7 +20,7 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/util"
 )
 
-const Version string = "skaffold/v1beta11"
+const Version string = "skaffold/v1beta12"
 
 // NewSkaffoldConfig creates a SkaffoldConfig
 func NewSkaffoldConfig() util.VersionedConfig {
@@ -35,6 +35,13 @@ type SkaffoldConfig struct {
 	// Kind is always `Config`. Defaults to `Config`.
 	Kind string `yaml:"kind" yamltags:"required"`
 
+	// Metadata holds additional information about the config.
+	Metadata Metadata `yaml:"metadata,omitempty"`
+
+	// Requires lists other Skaffold configurations whose artifacts, tests and
+	// deployments are imported into this one.
+	Requires []ConfigDependency `yaml:"requires,omitempty"`
+
 	// Pipeline defines the Build/Test/Deploy phases.
 	Pipeline `yaml:",inline"`
 
@@ -52,12 +59,47 @@ type Pipeline struct {
 
 	// Deploy describes how images are deployed.
 	Deploy DeployConfig `yaml:"deploy,omitempty"`
+
+	// PortForward describes user defined resources to port-forward.
+	// When set, only these resources are forwarded by `--port-forward`.
+	PortForward []*PortForwardResource `yaml:"portForward,omitempty"`
 }
 
 func (c *SkaffoldConfig) GetVersion() string {
 	return c.APIVersion
 }
 
+// Metadata holds additional information about the config.
+type Metadata struct {
+	// Name is an identifier for the config, used to select it with `--module`.
+	Name string `yaml:"name,omitempty"`
+}
+
+// ConfigDependency describes a Skaffold configuration required by another one.
+type ConfigDependency struct {
+	// Path is the path to the required configuration file, or to the directory containing
+	// its `skaffold.yaml`. It is relative to the directory of the requiring configuration,
+	// or to the root of the git repository if `git` is set.
+	Path string `yaml:"path,omitempty" skaffold:"template"`
+
+	// GitRepo describes a git repository containing the required configuration.
+	GitRepo *GitInfo `yaml:"git,omitempty"`
+
+	// ActiveProfiles are the profiles to activate in the required configuration.
+	ActiveProfiles []string `yaml:"activeProfiles,omitempty" skaffold:"template"`
+}
+
+// GitInfo describes a git repository containing a Skaffold configuration.
+type GitInfo struct {
+	// Repo is the git repository to clone.
+	// For example: `https://github.com/GoogleContainerTools/skaffold.git`.
+	Repo string `yaml:"repo,omitempty" yamltags:"required" skaffold:"template"`
+
+	// Ref is the branch, tag or commit to check out.
+	// Defaults to the default branch of the repository.
+	Ref string `yaml:"ref,omitempty" skaffold:"template"`
+}
+
 // BuildConfig contains all the configuration for the build steps.
 type BuildConfig struct {
 	// Artifacts lists the images you're going to be building.
@@ -65,7 +107,7 @@ type BuildConfig struct {
 
 	// InsecureRegistries is a list of registries declared by the user to be insecure.
 	// These registries will be connected to via HTTP instead of HTTPS.
-	InsecureRegistries []string `yaml:"insecureRegistries,omitempty"`
+	InsecureRegistries []string `yaml:"insecureRegistries,omitempty" skaffold:"template"`
 
 	// TagPolicy *beta* determines how images are tagged.
 	// A few strategies are provided here, although you most likely won't need to care!
@@ -101,7 +143,8 @@ type GitTagger struct {
 	// `AbbrevCommitSha`: use the abbreviated git commit sha.
 	// `TreeSha`: use the full tree hash of the artifact workingdir.
 	// `AbbrevTreeSha`: use the abbreviated tree hash of the artifact workingdir.
-	Variant string `yaml:"variant,omitempty"`
+	// Variants are not case sensitive.
+	Variant string `yaml:"variant,omitempty" yamltags:"enumIgnoreCase=Tags|CommitSha|AbbrevCommitSha|TreeSha|AbbrevTreeSha"`
 }
 
 // EnvTemplateTagger *beta* tags images with a configurable template string.
@@ -168,7 +211,7 @@ type GoogleCloudBuild struct {
 	// If it is not provided, Skaffold will guess it from the image name.
 	// For example, given the artifact image name `gcr.io/myproject/image`, Skaffold
 	// will use the `myproject` GCP project.
-	ProjectID string `yaml:"projectId,omitempty"`
+	ProjectID string `yaml:"projectId,omitempty" skaffold:"template"`
 
 	// DiskSizeGb is the disk size of the VM that runs the build.
 	// See [Cloud Build Reference](https://cloud.google.com/cloud-build/docs/api/reference/rest/v1/projects.builds#buildoptions).
@@ -176,32 +219,33 @@ type GoogleCloudBuild struct {
 
 	// MachineType is the type of the VM that runs the build.
 	// See [Cloud Build Reference](https://cloud.google.com/cloud-build/docs/api/reference/rest/v1/projects.builds#buildoptions).
-	MachineType string `yaml:"machineType,omitempty"`
+	MachineType string `yaml:"machineType,omitempty" skaffold:"template"`
 
 	// Timeout is the amount of time (in seconds) that this build should be allowed to run.
 	// See [Cloud Build Reference](https://cloud.google.com/cloud-build/docs/api/reference/rest/v1/projects.builds#resource-build).
-	Timeout string `yaml:"timeout,omitempty"`
+	// For example: `600s`.
+	Timeout string `yaml:"timeout,omitempty" yamltags:"pattern=^[0-9]+(\\.[0-9]+)?s$" skaffold:"template"`
 
 	// DockerImage is the image that runs a Docker build.
 	// See [Cloud Builders](https://cloud.google.com/cloud-build/docs/cloud-builders).
 	// Defaults to `gcr.io/cloud-builders/docker`.
-	DockerImage string `yaml:"dockerImage,omitempty"`
+	DockerImage string `yaml:"dockerImage,omitempty" skaffold:"template"`
 
 	// MavenImage is the image that runs a Maven build.
 	// See [Cloud Builders](https://cloud.google.com/cloud-build/docs/cloud-builders).
 	// Defaults to `gcr.io/cloud-builders/mvn`.
-	MavenImage string `yaml:"mavenImage,omitempty"`
+	MavenImage string `yaml:"mavenImage,omitempty" skaffold:"template"`
 
 	// GradleImage is the image that runs a Gradle build.
 	// See [Cloud Builders](https://cloud.google.com/cloud-build/docs/cloud-builders).
 	// Defaults to `gcr.io/cloud-builders/gradle`.
-	GradleImage string `yaml:"gradleImage,omitempty"`
+	GradleImage string `yaml:"gradleImage,omitempty" skaffold:"template"`
 }
 
 // LocalDir configures how Kaniko mounts sources directly via an `emptyDir` volume.
 type LocalDir struct {
 	// InitImage is the image used to run init container which mounts kaniko context.
-	InitImage string `yaml:"initImage,omitempty"`
+	InitImage string `yaml:"initImage,omitempty" skaffold:"template"`
 }
 
 // KanikoBuildContext contains the different fields available to specify
@@ -209,7 +253,7 @@ type LocalDir struct {
 type KanikoBuildContext struct {
 	// GCSBucket is the GCS bucket to which sources are uploaded.
 	// Kaniko will need access to that bucket to download the sources.
-	GCSBucket string `yaml:"gcsBucket,omitempty" yamltags:"oneOf=buildContext"`
+	GCSBucket string `yaml:"gcsBucket,omitempty" yamltags:"oneOf=buildContext" skaffold:"template"`
 
 	// LocalDir configures how Kaniko mounts sources directly via an `emptyDir` volume.
 	LocalDir *LocalDir `yaml:"localDir,omitempty" yamltags:"oneOf=buildContext"`
@@ -220,30 +264,30 @@ type KanikoBuildContext struct {
 type KanikoCache struct {
 	// Repo is a remote repository to store cached layers. If none is specified, one will be
 	// inferred from the image name. See [Kaniko Caching](https://github.com/GoogleContainerTools/kaniko#caching).
-	Repo string `yaml:"repo,omitempty"`
+	Repo string `yaml:"repo,omitempty" skaffold:"template"`
 	// HostPath specifies a path on the host that is mounted to each pod as read only cache volume containing base images.
 	// If set, must exist on each node and prepopulated with kaniko-warmer.
-	HostPath string `yaml:"hostPath,omitempty"`
+	HostPath string `yaml:"hostPath,omitempty" skaffold:"template"`
 }
 
 // ClusterDetails *beta* describes how to do an on-cluster build.
 type ClusterDetails struct {
 	// PullSecret is the path to the Google Cloud service account secret key file.
-	PullSecret string `yaml:"pullSecret,omitempty"`
+	PullSecret string `yaml:"pullSecret,omitempty" skaffold:"template"`
 
 	// PullSecretName is the name of the Kubernetes secret for pulling the files
 	// from the build context and pushing the final image. If given, the secret needs to
 	// contain the Google Cloud service account secret key under the key `kaniko-secret`.
 	// Defaults to `kaniko-secret`.
-	PullSecretName string `yaml:"pullSecretName,omitempty"`
+	PullSecretName string `yaml:"pullSecretName,omitempty" skaffold:"template"`
 
 	// Namespace is the Kubernetes namespace.
 	// Defaults to current namespace in Kubernetes configuration.
-	Namespace string `yaml:"namespace,omitempty"`
+	Namespace string `yaml:"namespace,omitempty" skaffold:"template"`
 
 	// Timeout is the amount of time (in seconds) that this build is allowed to run.
 	// Defaults to 20 minutes (`20m`).
-	Timeout string `yaml:"timeout,omitempty"`
+	Timeout string `yaml:"timeout,omitempty" yamltags:"duration" skaffold:"template"`
 
 	// DockerConfig describes how to mount the local Docker configuration into a pod.
 	DockerConfig *DockerConfig `yaml:"dockerConfig,omitempty"`
@@ -255,11 +299,11 @@ type ClusterDetails struct {
 // DockerConfig contains information about the docker `config.json` to mount.
 type DockerConfig struct {
 	// Path is the path to the docker `config.json`.
-	Path string `yaml:"path,omitempty" yamltags:"oneOf=dockerSecret"`
+	Path string `yaml:"path,omitempty" yamltags:"oneOf=dockerSecret" skaffold:"template"`
 
 	// SecretName is the Kubernetes secret that contains the `config.json` Docker configuration.
 	// Note that the expected secret type is not 'kubernetes.io/dockerconfigjson' but 'Opaque'.
-	SecretName string `yaml:"secretName,omitempty" yamltags:"oneOf=dockerSecret"`
+	SecretName string `yaml:"secretName,omitempty" yamltags:"oneOf=dockerSecret" skaffold:"template"`
 }
 
 // ResourceRequirements describes the resource requirements for the kaniko pod.
@@ -275,28 +319,43 @@ type ResourceRequirements struct {
 type ResourceRequirement struct {
 	// CPU the number cores to be used.
 	// For example: `2`, `2.0` or `200m`.
-	CPU string `yaml:"cpu,omitempty"`
+	CPU string `yaml:"cpu,omitempty" skaffold:"template"`
 
 	// Memory the amount of memory to allocate to the pod.
 	// For example: `1Gi` or `1000Mi`.
-	Memory string `yaml:"memory,omitempty"`
+	Memory string `yaml:"memory,omitempty" skaffold:"template"`
 }
 
 // TestCase is a list of structure tests to run on images that Skaffold builds.
 type TestCase struct {
 	// ImageName is the artifact on which to run those tests.
 	// For example: `gcr.io/k8s-skaffold/example`.
-	ImageName string `yaml:"image" yamltags:"required"`
+	ImageName string `yaml:"image" yamltags:"required" skaffold:"template"`
 
 	// StructureTests lists the [Container Structure Tests](https://github.com/GoogleContainerTools/container-structure-test)
 	// to run on that artifact.
 	// For example: `["./test/*"]`.
-	StructureTests []string `yaml:"structureTests,omitempty"`
+	StructureTests []string `yaml:"structureTests,omitempty" skaffold:"template"`
 }
 
 // DeployConfig contains all the configuration needed by the deploy steps.
 type DeployConfig struct {
 	DeployType `yaml:",inline"`
+
+	// Logs configures additional pods whose logs are streamed, next to
+	// the pods running the images built by Skaffold.
+	Logs *LogsConfig `yaml:"logs,omitempty"`
+}
+
+// LogsConfig describes additional pods whose logs are streamed, such as sidecars,
+// databases or third-party charts deployed with the application.
+type LogsConfig struct {
+	// Selectors are label selectors for the pods to stream logs from.
+	// For example: `["app=redis", "release in (my-release)"]`.
+	Selectors []string `yaml:"selectors,omitempty" skaffold:"template"`
+
+	// Namespaces are additional namespaces in which pods are watched.
+	Namespaces []string `yaml:"namespaces,omitempty" skaffold:"template"`
 }
 
 // DeployType contains the specific implementation and parameters needed
@@ -313,15 +372,50 @@ type DeployType struct {
 	KustomizeDeploy *KustomizeDeploy `yaml:"kustomize,omitempty" yamltags:"oneOf=deploy"`
 }
 
+// ResourceType is the type of a Kubernetes resource that can be port forwarded.
+type ResourceType string
+
+// Types of resources that can be port forwarded.
+const (
+	Service    ResourceType = "service"
+	Deployment ResourceType = "deployment"
+	Pod        ResourceType = "pod"
+)
+
+// PortForwardResource describes a resource to port forward.
+type PortForwardResource struct {
+	// Type is the Kubernetes type that should be port forwarded.
+	// One of `service`, `deployment` or `pod`.
+	Type ResourceType `yaml:"resourceType,omitempty"`
+
+	// Name is the name of the Kubernetes resource to port forward.
+	Name string `yaml:"resourceName,omitempty" skaffold:"template"`
+
+	// Selector is a label selector for the pods to port forward, such as `app=web`.
+	// Only used for `pod` resources without a `resourceName`.
+	Selector string `yaml:"selector,omitempty" skaffold:"template"`
+
+	// Namespace is the namespace of the resource.
+	// Defaults to the namespace Skaffold deploys to.
+	Namespace string `yaml:"namespace,omitempty" skaffold:"template"`
+
+	// Port is the remote port: a port of the service, or a container port for deployments and pods.
+	Port int `yaml:"port,omitempty" yamltags:"min=1,max=65535"`
+
+	// LocalPort is the local port to forward to. If the port is unavailable, or not set,
+	// Skaffold will choose a random open port.
+	LocalPort int `yaml:"localPort,omitempty" yamltags:"min=1,max=65535"`
+}
+
 // KubectlDeploy *beta* uses a client side `kubectl apply` to deploy manifests.
 // You'll need a `kubectl` CLI version installed that's compatible with your cluster.
 type KubectlDeploy struct {
 	// Manifests lists the Kubernetes yaml or json manifests.
 	// Defaults to `["k8s/*.yaml"]`.
-	Manifests []string `yaml:"manifests,omitempty"`
+	Manifests []string `yaml:"manifests,omitempty" skaffold:"template"`
 
 	// RemoteManifests lists Kubernetes manifests in remote clusters.
-	RemoteManifests []string `yaml:"remoteManifests,omitempty"`
+	RemoteManifests []string `yaml:"remoteManifests,omitempty" skaffold:"template"`
 
 	// Flags are additional flags passed to `kubectl`.
 	Flags KubectlFlags `yaml:"flags,omitempty"`
@@ -332,13 +426,13 @@ type KubectlDeploy struct {
 // or deletions (Delete).
 type KubectlFlags struct {
 	// Global are additional flags passed on every command.
-	Global []string `yaml:"global,omitempty"`
+	Global []string `yaml:"global,omitempty" skaffold:"template"`
 
 	// Apply are additional flags passed on creations (`kubectl apply`).
-	Apply []string `yaml:"apply,omitempty"`
+	Apply []string `yaml:"apply,omitempty" skaffold:"template"`
 
 	// Delete are additional flags passed on deletions (`kubectl delete`).
-	Delete []string `yaml:"delete,omitempty"`
+	Delete []string `yaml:"delete,omitempty" skaffold:"template"`
 }
 
 // HelmDeploy *beta* uses the `helm` CLI to apply the charts to the cluster.
@@ -355,20 +449,20 @@ type HelmDeploy struct {
 // line to `helm`.
 type HelmDeployFlags struct {
 	// Global are additional flags passed on every command.
-	Global []string `yaml:"global,omitempty"`
+	Global []string `yaml:"global,omitempty" skaffold:"template"`
 
 	// Install are additional flags passed to (`helm install`).
-	Install []string `yaml:"install,omitempty"`
+	Install []string `yaml:"install,omitempty" skaffold:"template"`
 
 	// Upgrade are additional flags passed to (`helm upgrade`).
-	Upgrade []string `yaml:"upgrade,omitempty"`
+	Upgrade []string `yaml:"upgrade,omitempty" skaffold:"template"`
 }
 
 // KustomizeDeploy *beta* uses the `kustomize` CLI to "patch" a deployment for a target environment.
 type KustomizeDeploy struct {
 	// KustomizePath is the path to Kustomization files.
 	// Defaults to `.`.
-	KustomizePath string `yaml:"path,omitempty"`
+	KustomizePath string `yaml:"path,omitempty" skaffold:"template"`
 
 	// Flags are additional flags passed to `kubectl`.
 	Flags KubectlFlags `yaml:"flags,omitempty"`
@@ -377,26 +471,26 @@ type KustomizeDeploy struct {
 // HelmRelease describes a helm release to be deployed.
 type HelmRelease struct {
 	// Name is the name of the Helm release.
-	Name string `yaml:"name,omitempty" yamltags:"required"`
+	Name string `yaml:"name,omitempty" yamltags:"required" skaffold:"template"`
 
 	// ChartPath is the path to the Helm chart.
-	ChartPath string `yaml:"chartPath,omitempty" yamltags:"required"`
+	ChartPath string `yaml:"chartPath,omitempty" yamltags:"required" skaffold:"template"`
 
 	// ValuesFiles are the paths to the Helm `values` files.
-	ValuesFiles []string `yaml:"valuesFiles,omitempty"`
+	ValuesFiles []string `yaml:"valuesFiles,omitempty" skaffold:"template"`
 
 	// Values are key-value pairs supplementing the Helm `values` file.
-	Values map[string]string `yaml:"values,omitempty,omitempty"`
+	Values map[string]string `yaml:"values,omitempty,omitempty" skaffold:"template"`
 
 	// Namespace is the Kubernetes namespace.
-	Namespace string `yaml:"namespace,omitempty"`
+	Namespace string `yaml:"namespace,omitempty" skaffold:"template"`
 
 	// Version is the version of the chart.
-	Version string `yaml:"version,omitempty"`
+	Version string `yaml:"version,omitempty" skaffold:"template"`
 
 	// SetValues are key-value pairs.
 	// If present, Skaffold will send `--set` flag to Helm CLI and append all pairs after the flag.
-	SetValues map[string]string `yaml:"setValues,omitempty"`
+	SetValues map[string]string `yaml:"setValues,omitempty" skaffold:"template"`
 
 	// SetValueTemplates are key-value pairs.
 	// If present, Skaffold will try to parse the value part of each key-value pair using
@@ -437,10 +531,10 @@ type HelmRelease struct {
 // HelmPackaged parameters for packaging helm chart (`helm package`).
 type HelmPackaged struct {
 	// Version sets the `version` on the chart to this semver version.
-	Version string `yaml:"version,omitempty"`
+	Version string `yaml:"version,omitempty" skaffold:"template"`
 
 	// AppVersion sets the `appVersion` on the chart to this version.
-	AppVersion string `yaml:"appVersion,omitempty"`
+	AppVersion string `yaml:"appVersion,omitempty" skaffold:"template"`
 }
 
 // HelmImageStrategy adds image configurations to the Helm `values` file.
@@ -472,11 +566,11 @@ type HelmConventionConfig struct {
 type Artifact struct {
 	// ImageName is the name of the image to be built.
 	// For example: `gcr.io/k8s-skaffold/example`.
-	ImageName string `yaml:"image,omitempty" yamltags:"required"`
+	ImageName string `yaml:"image,omitempty" yamltags:"required" skaffold:"template"`
 
 	// Workspace is the directory containing the artifact's sources.
 	// Defaults to `.`.
-	Workspace string `yaml:"context,omitempty"`
+	Workspace string `yaml:"context,omitempty" skaffold:"template"`
 
 	// Sync *alpha* lists local files synced to pods instead
 	// of triggering an image build when modified.
@@ -485,6 +579,11 @@ type Artifact struct {
 	// ArtifactType describes how to build an artifact.
 	ArtifactType `yaml:",inline"`
 
+	// RuntimeType *alpha* is the language runtime of the built image, used by `skaffold debug`
+	// instead of guessing it from the image configuration.
+	// One of `go`, `jvm`, `nodejs` or `python`.
+	RuntimeType string `yaml:"runtimeType,omitempty" yamltags:"enum=go|jvm|nodejs|python"`
+
 	WorkspaceHash string `yaml:"-,omitempty"`
 }
 
@@ -499,16 +598,16 @@ type Sync struct {
 type SyncRule struct {
 	// Src is a glob pattern to match local paths against.
 	// For example: `"css/**/*.css"`.
-	Src string `yaml:"src,omitempty" yamltags:"required"`
+	Src string `yaml:"src,omitempty" yamltags:"required" skaffold:"template"`
 
 	// Dest is the destination path in the container where the files should be synced to.
 	// For example: `"app/"`
-	Dest string `yaml:"dest,omitempty" yamltags:"required"`
+	Dest string `yaml:"dest,omitempty" yamltags:"required" skaffold:"template"`
 
 	// Strip specifies the path prefix to remove from the source path when
 	// transplanting the files into the destination folder.
 	// For example: `"css/"`
-	Strip string `yaml:"strip,omitempty"`
+	Strip string `yaml:"strip,omitempty" skaffold:"template"`
 }
 
 // Profile *beta* profiles are used to override any `build`, `test` or `deploy` configuration.
@@ -526,23 +625,34 @@ type Profile struct {
 
 	// Activation criteria by which a profile can be auto-activated.
 	// The profile is auto-activated if any one of the activations are triggered.
-	// An activation is triggered if all of the criteria (env, kubeContext, command) are triggered.
+	// An activation is triggered if all of the criteria (env, kubeContext, command, path) are triggered.
 	Activation []Activation `yaml:"activation,omitempty"`
+
+	// RequiresAllActivations auto-activates the profile only if all of the activations are triggered.
+	RequiresAllActivations bool `yaml:"requiresAllActivations,omitempty"`
+
+	// ActivatedBy lists profiles that auto-activate this profile when any of them is active.
+	// For example: `[prod]`.
+	ActivatedBy []string `yaml:"activatedBy,omitempty"`
+
+	// Requires lists profiles that are activated, and applied before, whenever this profile is active.
+	// For example: `[gcb]`.
+	Requires []string `yaml:"requires,omitempty"`
 }
 
 // JSONPatch patch to be applied by a profile.
 type JSONPatch struct {
 	// Op is the operation carried by the patch: `add`, `remove`, `replace`, `move`, `copy` or `test`.
 	// Defaults to `replace`.
-	Op string `yaml:"op,omitempty"`
+	Op string `yaml:"op,omitempty" yamltags:"enum=add|remove|replace|move|copy|test"`
 
 	// Path is the position in the yaml where the operation takes place.
 	// For example, this targets the `dockerfile` of the first artifact built.
 	// For example: `/build/artifacts/0/docker/dockerfile`.
 	Path string `yaml:"path,omitempty" yamltags:"required"`
 
-	// From is the source position in the yaml, used for `copy` or `move` operations.
-	From string `yaml:"from,omitempty"`
+	// From is the source position in the yaml, required for `copy` or `move` operations.
+	From string `yaml:"from,omitempty" yamltags:"requiredIf=op:move|copy"`
 
 	// Value is the value to apply. Can be any portion of yaml.
 	Value *util.YamlpatchNode `yaml:"value,omitempty"`
@@ -551,17 +661,23 @@ type JSONPatch struct {
 // Activation criteria by which a profile is auto-activated.
 type Activation struct {
 	// Env is a `key=value` pair. The profile is auto-activated if an Environment
-	// Variable `key` has value `value`.
+	// Variable `key` has value `value`. The value can be a regular expression.
 	// For example: `ENV=production`.
 	Env string `yaml:"env,omitempty"`
 
 	// KubeContext is a Kubernetes context for which the profile is auto-activated.
+	// It can be a regular expression.
 	// For example: `minikube`.
 	KubeContext string `yaml:"kubeContext,omitempty"`
 
 	// Command is a Skaffold command for which the profile is auto-activated.
 	// For example: `dev`.
 	Command string `yaml:"command,omitempty"`
+
+	// Path is a file or directory, relative to the `skaffold.yaml`, for which the profile
+	// is auto-activated if it exists. Prefix it with `!` to activate the profile if it doesn't exist.
+	// For example: `.env`.
+	Path string `yaml:"path,omitempty"`
 }
 
 // ArtifactType describes how to build an artifact.
@@ -592,7 +708,7 @@ type ArtifactType struct {
 // written by the user. It can be used to build images with builders that aren't directly integrated with skaffold.
 type CustomArtifact struct {
 	// BuildCommand is the command executed to build the image.
-	BuildCommand string `yaml:"buildCommand,omitempty"`
+	BuildCommand string `yaml:"buildCommand,omitempty" skaffold:"template"`
 	// Dependencies are the file dependencies that skaffold should watch for both rebuilding and file syncing for this artifact.
 	Dependencies *CustomDependencies `yaml:"dependencies,omitempty"`
 }
@@ -603,23 +719,23 @@ type CustomDependencies struct {
 	// Dockerfile should be set if the artifact is built from a Dockerfile, from which skaffold can determine dependencies.
 	Dockerfile *DockerfileDependency `yaml:"dockerfile,omitempty" yamltags:"oneOf=dependency"`
 	// Command represents a custom command that skaffold executes to obtain dependencies. The output of this command *must* be a valid JSON array.
-	Command string `yaml:"command,omitempty" yamltags:"oneOf=dependency"`
+	Command string `yaml:"command,omitempty" yamltags:"oneOf=dependency" skaffold:"template"`
 	// Paths should be set to the file dependencies for this artifact, so that the skaffold file watcher knows when to rebuild and perform file synchronization.
-	Paths []string `yaml:"paths,omitempty" yamltags:"oneOf=dependency"`
+	Paths []string `yaml:"paths,omitempty" yamltags:"oneOf=dependency" skaffold:"template"`
 	// Ignore specifies the paths that should be ignored by skaffold's file watcher. If a file exists in both `paths` and in `ignore`, it will be ignored, and will be excluded from both rebuilds and file synchronization.
 	// Will only work in conjunction with `paths`.
-	Ignore []string `yaml:"ignore,omitempty"`
+	Ignore []string `yaml:"ignore,omitempty" skaffold:"template"`
 }
 
 // DockerfileDependency *alpha* is used to specify a custom build artifact that is built from a Dockerfile. This allows skaffold to determine dependencies from the Dockerfile.
 type DockerfileDependency struct {
 	// Path locates the Dockerfile relative to workspace.
-	Path string `yaml:"path,omitempty"`
+	Path string `yaml:"path,omitempty" skaffold:"template"`
 
 	// BuildArgs are arguments passed to the docker build.
 	// It also accepts environment variables via the go template syntax.
 	// For example: `{"key1": "value1", "key2": "value2", "key3": "{{.ENV_VARIABLE}}"}`.
-	BuildArgs map[string]*string `yaml:"buildArgs,omitempty"`
+	BuildArgs map[string]*string `yaml:"buildArgs,omitempty" skaffold:"template"`
 }
 
 // KanikoArtifact *alpha* describes an artifact built from a Dockerfile,
@@ -628,26 +744,26 @@ type KanikoArtifact struct {
 	// AdditionalFlags are additional flags to be passed to Kaniko command line.
 	// See [Kaniko Additional Flags](https://github.com/GoogleContainerTools/kaniko#additional-flags).
 	// Deprecated - instead the named, unique fields should be used, e.g. `buildArgs`, `cache`, `target`.
-	AdditionalFlags []string `yaml:"flags,omitempty"`
+	AdditionalFlags []string `yaml:"flags,omitempty" skaffold:"template"`
 
 	// DockerfilePath locates the Dockerfile relative to workspace.
 	// Defaults to `Dockerfile`.
-	DockerfilePath string `yaml:"dockerfile,omitempty"`
+	DockerfilePath string `yaml:"dockerfile,omitempty" skaffold:"template"`
 
 	// Target is the Dockerfile target name to build.
-	Target string `yaml:"target,omitempty"`
+	Target string `yaml:"target,omitempty" skaffold:"template"`
 
 	// BuildArgs are arguments passed to the docker build.
 	// It also accepts environment variables via the go template syntax.
 	// For example: `{"key1": "value1", "key2": "value2", "key3": "{{.ENV_VARIABLE}}"}`.
-	BuildArgs map[string]*string `yaml:"buildArgs,omitempty"`
+	BuildArgs map[string]*string `yaml:"buildArgs,omitempty" skaffold:"template"`
 
 	// BuildContext is where the build context for this artifact resides.
 	BuildContext *KanikoBuildContext `yaml:"buildContext,omitempty"`
 
 	// Image is the Docker image used by the Kaniko pod.
 	// Defaults to the latest released version of `gcr.io/kaniko-project/executor`.
-	Image string `yaml:"image,omitempty"`
+	Image string `yaml:"image,omitempty" skaffold:"template"`
 
 	// Cache configures Kaniko caching. If a cache is specified, Kaniko will
 	// use a remote cache which will speed up builds.
@@ -659,14 +775,14 @@ type KanikoArtifact struct {
 type DockerArtifact struct {
 	// DockerfilePath locates the Dockerfile relative to workspace.
 	// Defaults to `Dockerfile`.
-	DockerfilePath string `yaml:"dockerfile,omitempty"`
+	DockerfilePath string `yaml:"dockerfile,omitempty" skaffold:"template"`
 
 	// Target is the Dockerfile target name to build.
-	Target string `yaml:"target,omitempty"`
+	Target string `yaml:"target,omitempty" skaffold:"template"`
 
 	// BuildArgs are arguments passed to the docker build.
 	// For example: `{"key1": "value1", "key2": "value2"}`.
-	BuildArgs map[string]*string `yaml:"buildArgs,omitempty"`
+	BuildArgs map[string]*string `yaml:"buildArgs,omitempty" skaffold:"template"`
 
 	// NetworkMode is passed through to docker and overrides the
 	// network configuration of docker builder. If unset, use whatever
@@ -678,7 +794,7 @@ type DockerArtifact struct {
 
 	// CacheFrom lists the Docker images used as cache sources.
 	// For example: `["golang:1.10.1-alpine3.7", "alpine:3.7"]`.
-	CacheFrom []string `yaml:"cacheFrom,omitempty"`
+	CacheFrom []string `yaml:"cacheFrom,omitempty" skaffold:"template"`
 
 	// NoCache used to pass in --no-cache to docker build to prevent caching.
 	NoCache bool `yaml:"noCache,omitempty"`
@@ -688,34 +804,34 @@ type DockerArtifact struct {
 type BazelArtifact struct {
 	// BuildTarget is the `bazel build` target to run.
 	// For example: `//:skaffold_example.tar`.
-	BuildTarget string `yaml:"target,omitempty" yamltags:"required"`
+	BuildTarget string `yaml:"target,omitempty" yamltags:"required" skaffold:"template"`
 
 	// BuildArgs are additional args to pass to `bazel build`.
 	// For example: `["-flag", "--otherflag"]`.
-	BuildArgs []string `yaml:"args,omitempty"`
+	BuildArgs []string `yaml:"args,omitempty" skaffold:"template"`
 }
 
 // JibMavenArtifact *alpha* builds images using the
 // [Jib plugin for Maven](https://github.com/GoogleContainerTools/jib/tree/master/jib-maven-plugin).
 type JibMavenArtifact struct {
 	// Module selects which Maven module to build, for a multi module project.
-	Module string `yaml:"module"`
+	Module string `yaml:"module" skaffold:"template"`
 
 	// Profile selects which Maven profile to activate.
-	Profile string `yaml:"profile"`
+	Profile string `yaml:"profile" skaffold:"template"`
 
 	// Flags are additional build flags passed to Maven.
 	// For example: `["-x", "-DskipTests"]`.
-	Flags []string `yaml:"args,omitempty"`
+	Flags []string `yaml:"args,omitempty" skaffold:"template"`
 }
 
 // JibGradleArtifact *alpha* builds images using the
 // [Jib plugin for Gradle](https://github.com/GoogleContainerTools/jib/tree/master/jib-gradle-plugin).
 type JibGradleArtifact struct {
 	// Project selects which Gradle project to build.
-	Project string `yaml:"project"`
+	Project string `yaml:"project" skaffold:"template"`
 
 	// Flags are additional build flags passed to Gradle.
 	// For example: `["--no-build-cache"]`.
-	Flags []string `yaml:"args,omitempty"`
+	Flags []string `yaml:"args,omitempty" skaffold:"template"`
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Override is a value of a configuration that was not read as is from its file.
+type Override struct {
+	// File is the configuration file. It is empty for values of the merged configuration.
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// Path is the yaml path to the value, for example `build.artifacts[0].image`.
+	Path string `json:"path" yaml:"path"`
+
+	// Value is the resulting value.
+	Value string `json:"value" yaml:"value"`
+
+	// Source is what set the value, for example `profile prod` or `default value`.
+	Source string `json:"source" yaml:"source"`
+}
+
+// OverrideTracer records which step of the loading of a configuration changed its values.
+type OverrideTracer struct {
+	file      string
+	values    map[string]string
+	overrides map[string]Override
+}
+
+// NewOverrideTracer starts tracing the values of a configuration read from a file.
+func NewOverrideTracer(file string, config *latest.SkaffoldConfig) *OverrideTracer {
+	return &OverrideTracer{
+		file:      file,
+		values:    flatten(config),
+		overrides: map[string]Override{},
+	}
+}
+
+// Record attributes the values that changed since the previous step to the given source.
+func (t *OverrideTracer) Record(config *latest.SkaffoldConfig, source string) {
+	values := flatten(config)
+
+	for path, value := range values {
+		if previous, found := t.values[path]; !found || previous != value {
+			t.overrides[path] = Override{File: t.file, Path: path, Value: value, Source: source}
+		}
+	}
+	for path := range t.overrides {
+		if _, found := values[path]; !found {
+			delete(t.overrides, path)
+		}
+	}
+
+	t.values = values
+}
+
+// Overrides returns the values that were set by a step other than reading the file, sorted by path.
+func (t *OverrideTracer) Overrides() []Override {
+	var overrides []Override
+	for _, o := range t.overrides {
+		overrides = append(overrides, o)
+	}
+
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i].Path < overrides[j].Path })
+	return overrides
+}
+
+// flatten lists the scalar values of a configuration by yaml path.
+func flatten(config *latest.SkaffoldConfig) map[string]string {
+	values := map[string]string{}
+
+	buf, err := yaml.Marshal(config)
+	if err != nil {
+		return values
+	}
+	var tree interface{}
+	if err := yaml.Unmarshal(buf, &tree); err != nil {
+		return values
+	}
+
+	flattenValue(tree, "", values)
+	return values
+}
+
+func flattenValue(v interface{}, path string, values map[string]string) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		if len(v) == 0 {
+			// Empty structs, such as `sha256: {}`, are meaningful.
+			values[path] = "{}"
+		}
+		for key, value := range v {
+			childPath := fmt.Sprintf("%v", key)
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+			flattenValue(value, childPath, values)
+		}
+	case []interface{}:
+		for i, value := range v {
+			flattenValue(value, fmt.Sprintf("%s[%d]", path, i), values)
+		}
+	default:
+		values[path] = fmt.Sprintf("%v", v)
+	}
+}
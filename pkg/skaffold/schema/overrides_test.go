@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	cfg "github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestTraceConfigs(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		t.Override(&util.OSEnviron, func() []string { return []string{"VERSION=v1"} })
+		tmpDir := t.NewTempDir().
+			Write("skaffold.yaml", skaffoldYaml(`
+build:
+  artifacts:
+  - image: app
+    docker:
+      dockerfile: Dockerfile
+      buildArgs:
+        VERSION: "{{.VERSION}}"
+  local: {}
+  tagPolicy:
+    gitCommit: {}
+deploy:
+  kubectl:
+    manifests: [k8s/*.yaml]
+profiles:
+- name: prod
+  build:
+    tagPolicy:
+      sha256: {}
+`))
+		t.Chdir(tmpDir.Root())
+
+		configs, err := TraceConfigs(&cfg.SkaffoldOptions{ConfigurationFile: "skaffold.yaml", Profiles: []string{"prod"}})
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]Override{
+			{File: "skaffold.yaml", Path: "build.artifacts[0].context", Value: ".", Source: "default value"},
+			{File: "skaffold.yaml", Path: "build.artifacts[0].docker.buildArgs.VERSION", Value: "v1", Source: "environment template"},
+			{File: "skaffold.yaml", Path: "build.tagPolicy.sha256", Value: "{}", Source: "profile prod"},
+		}, configs.Overrides)
+	})
+}
+
+func TestParseConfigsDoesNotTrace(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().Write("skaffold.yaml", skaffoldYaml(""))
+		t.Chdir(tmpDir.Root())
+
+		configs, err := ParseConfigs(&cfg.SkaffoldOptions{ConfigurationFile: "skaffold.yaml"})
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual(0, len(configs.Overrides))
+	})
+}
+
+func TestOverrideTracer(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		config := &latest.SkaffoldConfig{Pipeline: latest.Pipeline{Build: latest.BuildConfig{
+			Artifacts: []*latest.Artifact{{ImageName: "a"}, {ImageName: "b"}},
+		}}}
+		tracer := NewOverrideTracer("skaffold.yaml", config)
+
+		config.Build.Artifacts[0].ImageName = "registry/a"
+		tracer.Record(config, "first")
+		config.Build.Artifacts = config.Build.Artifacts[1:]
+		tracer.Record(config, "second")
+
+		// The override of a removed value is forgotten.
+		t.CheckDeepEqual([]Override{
+			{File: "skaffold.yaml", Path: "build.artifacts[0].image", Value: "b", Source: "second"},
+		}, tracer.Overrides())
+	})
+}
@@ -19,7 +19,9 @@ package schema
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 
 	cfg "github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
@@ -35,52 +37,190 @@ import (
 // ApplyProfiles returns configuration modified by the application
 // of a list of profiles.
 func ApplyProfiles(c *latest.SkaffoldConfig, opts *cfg.SkaffoldOptions) error {
+	return applyProfiles(c, opts, func(string) {})
+}
+
+// applyProfiles applies the activated profiles and calls onApplied after each one.
+func applyProfiles(c *latest.SkaffoldConfig, opts *cfg.SkaffoldOptions, onApplied func(profile string)) error {
 	byName := profilesByName(c.Profiles)
 
 	profiles, err := activatedProfiles(c.Profiles, opts)
 	if err != nil {
-		return errors.Wrap(err, "finding auto-activated profiles")
+		return errors.Wrap(err, "finding activated profiles")
+	}
+	if len(profiles) > 0 {
+		logrus.Infof("applying profiles in order: %s", strings.Join(profiles, ", "))
 	}
 
 	for _, name := range profiles {
-		profile, present := byName[name]
-		if !present {
-			return fmt.Errorf("couldn't find profile %s", name)
-		}
-
-		if err := applyProfile(c, profile); err != nil {
+		if err := applyProfile(c, byName[name]); err != nil {
 			return errors.Wrapf(err, "applying profile %s", name)
 		}
+		onApplied(name)
 	}
 
 	return nil
 }
 
+// activatedProfiles returns the names of the active profiles, in the order
+// they should be applied: profiles selected on the command line first, then
+// auto-activated profiles, in the order they are defined. A profile always
+// comes after the profiles it requires.
 func activatedProfiles(profiles []latest.Profile, opts *cfg.SkaffoldOptions) ([]string, error) {
-	activated := opts.Profiles
+	a := &profileActivation{
+		byName:      profilesByName(profiles),
+		deactivated: map[string]bool{},
+		active:      map[string]bool{},
+		visiting:    map[string]bool{},
+	}
 
-	// Auto-activated profiles
 	for _, profile := range profiles {
-		for _, cond := range profile.Activation {
-			command := isCommand(cond.Command, opts)
+		for _, by := range profile.ActivatedBy {
+			if _, found := a.byName[by]; !found {
+				return nil, fmt.Errorf("couldn't find profile %s, activating profile %s", by, profile.Name)
+			}
+		}
+	}
 
-			env, err := isEnv(cond.Env)
-			if err != nil {
-				return nil, err
+	var selected []string
+	for _, name := range opts.Profiles {
+		if strings.HasPrefix(name, "-") {
+			name = name[1:]
+			if _, found := a.byName[name]; !found {
+				return nil, fmt.Errorf("couldn't find profile %s", name)
 			}
+			a.deactivated[name] = true
+		} else {
+			selected = append(selected, name)
+		}
+	}
 
-			kubeContext, err := isKubeContext(cond.KubeContext)
-			if err != nil {
+	for _, name := range selected {
+		if err := a.activate(name, "selected on the command line"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Auto-activated profiles
+	for _, profile := range profiles {
+		activated, err := isAutoActivated(profile, opts)
+		if err != nil {
+			return nil, err
+		}
+		if activated {
+			if err := a.activate(profile.Name, "auto-activated"); err != nil {
 				return nil, err
 			}
+		}
+	}
 
-			if command && env && kubeContext {
-				activated = append(activated, profile.Name)
+	// Profiles activated by other profiles, until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		for _, profile := range profiles {
+			if a.active[profile.Name] {
+				continue
+			}
+			for _, by := range profile.ActivatedBy {
+				if a.active[by] {
+					if err := a.activate(profile.Name, "activated by "+by); err != nil {
+						return nil, err
+					}
+					if a.active[profile.Name] {
+						changed = true
+					}
+					break
+				}
 			}
 		}
 	}
 
-	return activated, nil
+	return a.order, nil
+}
+
+type profileActivation struct {
+	byName      map[string]latest.Profile
+	deactivated map[string]bool
+	active      map[string]bool
+	visiting    map[string]bool
+	order       []string
+}
+
+// activate activates a profile and, before it, the profiles it requires.
+// A profile deactivated on the command line is skipped, and activating
+// a profile that requires it is an error.
+func (a *profileActivation) activate(name, reason string) error {
+	if a.active[name] {
+		return nil
+	}
+	if a.visiting[name] {
+		return fmt.Errorf("cycle detected: profile %s requires itself", name)
+	}
+
+	profile, found := a.byName[name]
+	if !found {
+		return fmt.Errorf("couldn't find profile %s", name)
+	}
+	if a.deactivated[name] {
+		logrus.Debugf("not activating profile %s (%s): deactivated on the command line", name, reason)
+		return nil
+	}
+
+	a.visiting[name] = true
+	for _, required := range profile.Requires {
+		if a.deactivated[required] {
+			return fmt.Errorf("profile %s requires profile %s, which is deactivated", name, required)
+		}
+		if err := a.activate(required, "required by "+name); err != nil {
+			return err
+		}
+	}
+	delete(a.visiting, name)
+
+	logrus.Debugf("activating profile %s: %s", name, reason)
+	a.active[name] = true
+	a.order = append(a.order, name)
+	return nil
+}
+
+func isAutoActivated(profile latest.Profile, opts *cfg.SkaffoldOptions) (bool, error) {
+	if len(profile.Activation) == 0 {
+		return false, nil
+	}
+
+	for _, cond := range profile.Activation {
+		triggered, err := isTriggered(cond, opts)
+		if err != nil {
+			return false, err
+		}
+
+		if triggered && !profile.RequiresAllActivations {
+			return true, nil
+		}
+		if !triggered && profile.RequiresAllActivations {
+			return false, nil
+		}
+	}
+
+	return profile.RequiresAllActivations, nil
+}
+
+func isTriggered(cond latest.Activation, opts *cfg.SkaffoldOptions) (bool, error) {
+	command := isCommand(cond.Command, opts)
+
+	env, err := isEnv(cond.Env)
+	if err != nil {
+		return false, err
+	}
+
+	kubeContext, err := isKubeContext(cond.KubeContext)
+	if err != nil {
+		return false, err
+	}
+
+	path := isPath(cond.Path, opts)
+
+	return command && env && kubeContext && path, nil
 }
 
 func isEnv(env string) (bool, error) {
@@ -120,11 +260,45 @@ func isKubeContext(kubeContext string) (bool, error) {
 	return satisfies(kubeContext, currentKubeContext), nil
 }
 
+// isPath checks that a path, relative to the configuration file, exists.
+func isPath(path string, opts *cfg.SkaffoldOptions) bool {
+	if path == "" {
+		return true
+	}
+
+	expected := true
+	if strings.HasPrefix(path, "!") {
+		expected = false
+		path = path[1:]
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(configDir(opts.ConfigurationFile), path)
+	}
+
+	_, err := os.Stat(path)
+	return (err == nil) == expected
+}
+
 func satisfies(expected, actual string) bool {
 	if strings.HasPrefix(expected, "!") {
-		return actual != expected[1:]
+		return !matches(expected[1:], actual)
+	}
+	return matches(expected, actual)
+}
+
+// matches compares a value to an exact string or to a regular expression
+// that must match the whole value.
+func matches(expected, actual string) bool {
+	if actual == expected {
+		return true
+	}
+
+	re, err := regexp.Compile("^(?:" + expected + ")$")
+	if err != nil {
+		logrus.Debugf("activation criteria %q is not a valid regular expression", expected)
+		return false
 	}
-	return actual == expected
+	return re.MatchString(actual)
 }
 
 func applyProfile(config *latest.SkaffoldConfig, profile latest.Profile) error {
@@ -134,10 +308,13 @@ func applyProfile(config *latest.SkaffoldConfig, profile latest.Profile) error {
 	*config = latest.SkaffoldConfig{
 		APIVersion: config.APIVersion,
 		Kind:       config.Kind,
+		Metadata:   config.Metadata,
+		Requires:   config.Requires,
 		Pipeline: latest.Pipeline{
-			Build:  overlayProfileField(config.Build, profile.Build).(latest.BuildConfig),
-			Deploy: overlayProfileField(config.Deploy, profile.Deploy).(latest.DeployConfig),
-			Test:   overlayProfileField(config.Test, profile.Test).([]*latest.TestCase),
+			Build:       overlayProfileField(config.Build, profile.Build).(latest.BuildConfig),
+			Deploy:      overlayProfileField(config.Deploy, profile.Deploy).(latest.DeployConfig),
+			Test:        overlayProfileField(config.Test, profile.Test).([]*latest.TestCase),
+			PortForward: overlayProfileField(config.PortForward, profile.PortForward).([]*latest.PortForwardResource),
 		},
 	}
 
@@ -414,6 +414,90 @@ func TestActivatedProfiles(t *testing.T) {
 				},
 			},
 			expected: []string{"activated"},
+		}, {
+			description: "Regular expressions",
+			opts:        &cfg.SkaffoldOptions{},
+			profiles: []latest.Profile{
+				{Name: "activated", Activation: []latest.Activation{{Env: "KEY=V.*"}}},
+				{Name: "not-activated", Activation: []latest.Activation{{KubeContext: "dev-.*"}}},
+				{Name: "also-activated", Activation: []latest.Activation{{KubeContext: "!dev-.*"}}},
+				{Name: "partial-match", Activation: []latest.Activation{{KubeContext: "prod"}}},
+			},
+			expected: []string{"activated", "also-activated"},
+		}, {
+			description: "Auto-activated by path",
+			opts:        &cfg.SkaffoldOptions{ConfigurationFile: "skaffold.yaml"},
+			profiles: []latest.Profile{
+				{Name: "activated", Activation: []latest.Activation{{Path: ".env"}}},
+				{Name: "not-activated", Activation: []latest.Activation{{Path: "missing"}}},
+				{Name: "also-activated", Activation: []latest.Activation{{Path: "!missing"}}},
+			},
+			expected: []string{"activated", "also-activated"},
+		}, {
+			description: "AND between activations",
+			opts: &cfg.SkaffoldOptions{
+				Command: "dev",
+			},
+			profiles: []latest.Profile{
+				{Name: "activated", RequiresAllActivations: true, Activation: []latest.Activation{{Command: "dev"}, {Env: "KEY=VALUE"}}},
+				{Name: "not-activated", RequiresAllActivations: true, Activation: []latest.Activation{{Command: "dev"}, {Env: "KEY=OTHER"}}},
+			},
+			expected: []string{"activated"},
+		}, {
+			description: "Deactivated on the command line",
+			opts: &cfg.SkaffoldOptions{
+				Command:  "dev",
+				Profiles: []string{"-auto", "selected"},
+			},
+			profiles: []latest.Profile{
+				{Name: "auto", Activation: []latest.Activation{{Command: "dev"}}},
+				{Name: "selected"},
+			},
+			expected: []string{"selected"},
+		}, {
+			description: "Unknown deactivated profile",
+			opts:        &cfg.SkaffoldOptions{Profiles: []string{"-unknown"}},
+			shouldErr:   true,
+		}, {
+			description: "Requires and activatedBy",
+			opts: &cfg.SkaffoldOptions{
+				Command:  "dev",
+				Profiles: []string{"prod"},
+			},
+			profiles: []latest.Profile{
+				{Name: "gcb"},
+				{Name: "monitoring", ActivatedBy: []string{"prod"}},
+				{Name: "prod", Requires: []string{"gcb"}},
+				{Name: "unused", Requires: []string{"gcb"}},
+			},
+			expected: []string{"gcb", "prod", "monitoring"},
+		}, {
+			description: "Requires a deactivated profile",
+			opts:        &cfg.SkaffoldOptions{Profiles: []string{"prod", "-gcb"}},
+			profiles: []latest.Profile{
+				{Name: "gcb"},
+				{Name: "prod", Requires: []string{"gcb"}},
+			},
+			shouldErr: true,
+		}, {
+			description: "Requires cycle",
+			opts:        &cfg.SkaffoldOptions{Profiles: []string{"a"}},
+			profiles: []latest.Profile{
+				{Name: "a", Requires: []string{"b"}},
+				{Name: "b", Requires: []string{"a"}},
+			},
+			shouldErr: true,
+		}, {
+			description: "Activated once",
+			opts: &cfg.SkaffoldOptions{
+				Command:  "dev",
+				Profiles: []string{"dev", "base"},
+			},
+			profiles: []latest.Profile{
+				{Name: "base"},
+				{Name: "dev", Requires: []string{"base"}, Activation: []latest.Activation{{Command: "dev"}}},
+			},
+			expected: []string{"base", "dev"},
 		},
 	}
 
@@ -421,6 +505,7 @@ func TestActivatedProfiles(t *testing.T) {
 		testutil.Run(t, test.description, func(t *testutil.T) {
 			t.SetEnvs(map[string]string{"KEY": "VALUE"})
 			t.SetupFakeKubernetesContext(api.Config{CurrentContext: "prod-context"})
+			t.Chdir(t.NewTempDir().Write(".env", "").Root())
 
 			activated, err := activatedProfiles(test.profiles, test.opts)
 
@@ -0,0 +1,453 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	cfg "github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/defaults"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Configs are a Skaffold configuration and the configurations it requires.
+type Configs struct {
+	// Root is the configuration file given to Skaffold.
+	Root *latest.SkaffoldConfig
+
+	// Selected are the configurations to run, required configurations first.
+	// Without modules, these are the root configuration and every configuration it requires.
+	Selected []*latest.SkaffoldConfig
+
+	// SelectedFiles are the files the selected configurations were read from.
+	SelectedFiles []string
+
+	// Files are the local configuration files that were read.
+	Files []string
+
+	// Overrides are the values of the selected configurations that were set by profiles,
+	// templates or defaults. They are only recorded by TraceConfigs.
+	Overrides []Override
+}
+
+// parseError is returned when a configuration file can't be read or parsed.
+type parseError struct {
+	error
+}
+
+// IsParseError tells if an error was returned because a configuration file
+// couldn't be read or parsed, rather than because it is invalid.
+func IsParseError(err error) bool {
+	_, ok := errors.Cause(err).(parseError)
+	return ok
+}
+
+type loadedConfig struct {
+	config    *latest.SkaffoldConfig
+	file      string
+	overrides []Override
+	requires  []*loadedConfig
+	selected  bool
+}
+
+type configLoader struct {
+	opts   *cfg.SkaffoldOptions
+	loaded map[string]*loadedConfig
+	repos  map[latest.GitInfo]string
+	stack  []string
+	order  []*loadedConfig
+	files  []string
+	trace  bool
+}
+
+// ParseConfigs reads a configuration file and, recursively, the configurations it requires.
+// Profiles are applied, environment variable templates are expanded and default values
+// are set on each configuration. Relative paths in the required configurations are
+// resolved against the directory of their own file. If modules are given, only the
+// configurations with these names, and the configurations they require, are selected.
+func ParseConfigs(opts *cfg.SkaffoldOptions) (*Configs, error) {
+	return parseConfigs(opts, false)
+}
+
+// TraceConfigs is like ParseConfigs, but also records which profile, template or
+// default value set each value of the selected configurations.
+func TraceConfigs(opts *cfg.SkaffoldOptions) (*Configs, error) {
+	return parseConfigs(opts, true)
+}
+
+func parseConfigs(opts *cfg.SkaffoldOptions, trace bool) (*Configs, error) {
+	l := &configLoader{
+		opts:   opts,
+		loaded: map[string]*loadedConfig{},
+		repos:  map[latest.GitInfo]string{},
+		trace:  trace,
+	}
+
+	root, err := l.load(opts.ConfigurationFile, opts.Profiles, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Modules) == 0 {
+		root.selectAll()
+	} else {
+		for _, module := range opts.Modules {
+			found := false
+			for _, c := range l.order {
+				if c.config.Metadata.Name == module {
+					c.selectAll()
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("couldn't find module %s", module)
+			}
+		}
+	}
+
+	configs := &Configs{
+		Root:  root.config,
+		Files: l.files,
+	}
+	for _, c := range l.order {
+		if c.selected {
+			configs.Selected = append(configs.Selected, c.config)
+			configs.SelectedFiles = append(configs.SelectedFiles, c.file)
+			configs.Overrides = append(configs.Overrides, c.overrides...)
+		}
+	}
+	return configs, nil
+}
+
+func (c *loadedConfig) selectAll() {
+	if c.selected {
+		return
+	}
+	c.selected = true
+	for _, r := range c.requires {
+		r.selectAll()
+	}
+}
+
+func (l *configLoader) load(filename string, profiles []string, required bool) (*loadedConfig, error) {
+	key := filename
+	if isLocalFile(filename) {
+		if abs, err := filepath.Abs(filename); err == nil {
+			key = abs
+		}
+	}
+	for _, f := range l.stack {
+		if f == key {
+			return nil, fmt.Errorf("cycle detected: %s requires itself", filename)
+		}
+	}
+
+	// The same config can be required several times, with different profiles.
+	profilesKey := key + ":" + strings.Join(profiles, ",")
+	if c, found := l.loaded[profilesKey]; found {
+		return c, nil
+	}
+
+	l.stack = append(l.stack, key)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	parsed, err := ParseConfig(filename, true)
+	if err != nil {
+		return nil, parseError{err}
+	}
+	config := parsed.(*latest.SkaffoldConfig)
+
+	opts := l.opts
+	if required {
+		withProfiles := *l.opts
+		withProfiles.Profiles = profiles
+		withProfiles.ConfigurationFile = filename
+		opts = &withProfiles
+	}
+
+	var tracer *OverrideTracer
+	record := func(string) {}
+	if l.trace {
+		tracer = NewOverrideTracer(filename, config)
+		record = func(source string) { tracer.Record(config, source) }
+	}
+
+	if err := applyProfiles(config, opts, func(profile string) { record("profile " + profile) }); err != nil {
+		return nil, errors.Wrap(err, "applying profiles")
+	}
+	if err := expandEnvTemplates(config); err != nil {
+		return nil, errors.Wrapf(err, "expanding templates in %s", filename)
+	}
+	record("environment template")
+	if err := defaults.Set(config); err != nil {
+		return nil, errors.Wrap(err, "setting default values")
+	}
+	record("default value")
+
+	dir := configDir(filename)
+	if required {
+		resolvePaths(config, dir)
+		record("path relative to " + dir)
+	}
+	if !required || isLocalFile(filename) {
+		l.files = append(l.files, filename)
+	}
+
+	loaded := &loadedConfig{config: config, file: filename}
+	if tracer != nil {
+		loaded.overrides = tracer.Overrides()
+	}
+	l.loaded[profilesKey] = loaded
+
+	for _, dependency := range config.Requires {
+		file, err := l.locate(dependency, dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "locating config required by %s", filename)
+		}
+
+		r, err := l.load(file, dependency.ActiveProfiles, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading config %s required by %s", file, filename)
+		}
+		loaded.requires = append(loaded.requires, r)
+	}
+
+	l.order = append(l.order, loaded)
+	return loaded, nil
+}
+
+// locate returns the path to a required configuration file, cloning its git repository if needed.
+func (l *configLoader) locate(dependency latest.ConfigDependency, dir string) (string, error) {
+	if dependency.GitRepo != nil {
+		repoDir, err := l.syncRepo(*dependency.GitRepo)
+		if err != nil {
+			return "", errors.Wrapf(err, "syncing git repository %s", dependency.GitRepo.Repo)
+		}
+		dir = repoDir
+	} else if dependency.Path == "" {
+		return "", errors.New("required config has neither a path nor a git repository")
+	}
+
+	path := dependency.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "skaffold.yaml")
+	}
+	return path, nil
+}
+
+// syncRepo clones a git repository into Skaffold's directory, or fetches it if it was
+// cloned before, and checks out the requested reference.
+func (l *configLoader) syncRepo(repo latest.GitInfo) (string, error) {
+	if dir, found := l.repos[repo]; found {
+		return dir, nil
+	}
+	// Neither can be mistaken for an option of git.
+	if strings.HasPrefix(repo.Repo, "-") {
+		return "", fmt.Errorf("invalid git repository %q", repo.Repo)
+	}
+	if strings.HasPrefix(repo.Ref, "-") {
+		return "", fmt.Errorf("invalid git reference %q", repo.Ref)
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving home directory")
+	}
+	hash := sha256.Sum256([]byte(repo.Repo + "@" + repo.Ref))
+	dir := filepath.Join(home, constants.DefaultSkaffoldDir, "repos", hex.EncodeToString(hash[:])[:16])
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		logrus.Infof("Cloning %s into %s", repo.Repo, dir)
+		if _, err := runGit("", "clone", "--quiet", "--", repo.Repo, dir); err != nil {
+			return "", err
+		}
+	} else {
+		logrus.Infof("Fetching %s into %s", repo.Repo, dir)
+		if _, err := runGit(dir, "fetch", "--quiet", "--tags", "origin"); err != nil {
+			return "", err
+		}
+	}
+
+	// Branches are checked out at their latest remote commit.
+	ref := repo.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if _, err := runGit(dir, "rev-parse", "--verify", "--quiet", "origin/"+ref); err == nil {
+		ref = "origin/" + ref
+	}
+	if _, err := runGit(dir, "checkout", "--quiet", "--force", ref, "--"); err != nil {
+		return "", err
+	}
+
+	l.repos[repo] = dir
+	return dir, nil
+}
+
+func runGit(dir string, arg ...string) ([]byte, error) {
+	cmd := exec.Command("git", arg...)
+	cmd.Dir = dir
+	return util.RunCmdOut(cmd)
+}
+
+func isLocalFile(filename string) bool {
+	return filename != "-" && !util.IsURL(filename)
+}
+
+func configDir(filename string) string {
+	if !isLocalFile(filename) {
+		return "."
+	}
+	return filepath.Dir(filename)
+}
+
+// resolvePaths makes the relative paths of a required configuration relative
+// to the directory of its file. Slices are replaced rather than modified because
+// default values can be shared between configurations.
+func resolvePaths(config *latest.SkaffoldConfig, dir string) {
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) || util.IsURL(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+	resolveAll := func(paths []string) []string {
+		var resolved []string
+		for _, path := range paths {
+			resolved = append(resolved, resolve(path))
+		}
+		return resolved
+	}
+
+	for _, a := range config.Build.Artifacts {
+		a.Workspace = resolve(a.Workspace)
+	}
+	for _, t := range config.Test {
+		t.StructureTests = resolveAll(t.StructureTests)
+	}
+	if kubectl := config.Deploy.KubectlDeploy; kubectl != nil {
+		kubectl.Manifests = resolveAll(kubectl.Manifests)
+	}
+	if helm := config.Deploy.HelmDeploy; helm != nil {
+		releases := make([]latest.HelmRelease, len(helm.Releases))
+		for i, r := range helm.Releases {
+			if !r.Remote {
+				r.ChartPath = resolve(r.ChartPath)
+			}
+			r.ValuesFiles = resolveAll(r.ValuesFiles)
+			releases[i] = r
+		}
+		helm.Releases = releases
+	}
+	if kustomize := config.Deploy.KustomizeDeploy; kustomize != nil {
+		kustomize.KustomizePath = resolve(kustomize.KustomizePath)
+	}
+}
+
+// Merge combines the selected configurations into a single one.
+// The build type and the tag policy of the root configuration are used for every artifact.
+func (c *Configs) Merge() (*latest.SkaffoldConfig, error) {
+	merged := &latest.SkaffoldConfig{
+		APIVersion: c.Root.APIVersion,
+		Kind:       c.Root.Kind,
+		Metadata:   c.Root.Metadata,
+		Pipeline: latest.Pipeline{
+			Build: latest.BuildConfig{
+				TagPolicy: c.Root.Build.TagPolicy,
+				BuildType: c.Root.Build.BuildType,
+			},
+		},
+	}
+
+	images := map[string]bool{}
+	for _, config := range c.Selected {
+		for _, a := range config.Build.Artifacts {
+			if images[a.ImageName] {
+				return nil, fmt.Errorf("artifact %s is defined in several configurations", a.ImageName)
+			}
+			images[a.ImageName] = true
+		}
+
+		merged.Build.Artifacts = append(merged.Build.Artifacts, config.Build.Artifacts...)
+		merged.Build.InsecureRegistries = append(merged.Build.InsecureRegistries, config.Build.InsecureRegistries...)
+		merged.Test = append(merged.Test, config.Test...)
+		merged.PortForward = append(merged.PortForward, config.PortForward...)
+
+		if err := mergeDeploy(&merged.Deploy, config.Deploy); err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
+// mergeDeploy adds the deployments of a configuration to the merged deploy configuration.
+// Several deployers can be set on the result. The flags of the first configuration
+// using a given deployer are kept.
+func mergeDeploy(merged *latest.DeployConfig, deploy latest.DeployConfig) error {
+	if helm := deploy.HelmDeploy; helm != nil {
+		if merged.HelmDeploy == nil {
+			copied := *helm
+			merged.HelmDeploy = &copied
+		} else {
+			merged.HelmDeploy.Releases = append(merged.HelmDeploy.Releases, helm.Releases...)
+		}
+	}
+
+	if kubectl := deploy.KubectlDeploy; kubectl != nil {
+		if merged.KubectlDeploy == nil {
+			copied := *kubectl
+			merged.KubectlDeploy = &copied
+		} else {
+			merged.KubectlDeploy.Manifests = append(merged.KubectlDeploy.Manifests, kubectl.Manifests...)
+			merged.KubectlDeploy.RemoteManifests = append(merged.KubectlDeploy.RemoteManifests, kubectl.RemoteManifests...)
+		}
+	}
+
+	if kustomize := deploy.KustomizeDeploy; kustomize != nil {
+		if merged.KustomizeDeploy == nil {
+			copied := *kustomize
+			merged.KustomizeDeploy = &copied
+		} else if merged.KustomizeDeploy.KustomizePath != kustomize.KustomizePath {
+			return fmt.Errorf("only one kustomize path is supported, found %s and %s", merged.KustomizeDeploy.KustomizePath, kustomize.KustomizePath)
+		}
+	}
+
+	if logs := deploy.Logs; logs != nil {
+		if merged.Logs == nil {
+			merged.Logs = &latest.LogsConfig{}
+		}
+		merged.Logs.Selectors = append(merged.Logs.Selectors, logs.Selectors...)
+		merged.Logs.Namespaces = append(merged.Logs.Namespaces, logs.Namespaces...)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"testing"
+
+	cfg "github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func skaffoldYaml(content string) string {
+	return fmt.Sprintf("apiVersion: %s\nkind: Config\n%s", latest.Version, content)
+}
+
+func TestParseConfigsRequires(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("skaffold.yaml", skaffoldYaml(`
+requires:
+- path: svc
+  activeProfiles: [prod]
+build:
+  artifacts:
+  - image: frontend
+`)).
+			Write("svc/skaffold.yaml", skaffoldYaml(`
+build:
+  artifacts:
+  - image: backend
+    context: src
+test:
+- image: backend
+  structureTests: [tests/*]
+deploy:
+  helm:
+    releases:
+    - name: backend
+      chartPath: chart
+profiles:
+- name: prod
+  patches:
+  - path: /deploy/helm/releases/0/name
+    value: backend-prod
+`))
+		t.Chdir(tmpDir.Root())
+
+		configs, err := ParseConfigs(&cfg.SkaffoldOptions{ConfigurationFile: "skaffold.yaml"})
+		t.CheckNoError(err)
+		t.CheckDeepEqual([]string{"skaffold.yaml", "svc/skaffold.yaml"}, configs.Files)
+		t.CheckDeepEqual([]string{"svc/skaffold.yaml", "skaffold.yaml"}, configs.SelectedFiles)
+
+		merged, err := configs.Merge()
+		t.CheckNoError(err)
+		t.CheckDeepEqual(2, len(merged.Build.Artifacts))
+		t.CheckDeepEqual("svc/src", merged.Build.Artifacts[0].Workspace)
+		t.CheckDeepEqual(".", merged.Build.Artifacts[1].Workspace)
+		t.CheckDeepEqual([]string{"svc/tests/*"}, merged.Test[0].StructureTests)
+		t.CheckDeepEqual("backend-prod", merged.Deploy.HelmDeploy.Releases[0].Name)
+		t.CheckDeepEqual("svc/chart", merged.Deploy.HelmDeploy.Releases[0].ChartPath)
+		t.CheckDeepEqual([]string{"k8s/*.yaml"}, merged.Deploy.KubectlDeploy.Manifests)
+		t.CheckDeepEqual(0, len(merged.Requires))
+	})
+}
+
+func TestParseConfigsModules(t *testing.T) {
+	tests := []struct {
+		description    string
+		modules        []string
+		expectedImages []string
+		shouldErr      bool
+	}{
+		{
+			description:    "all configs",
+			expectedImages: []string{"b", "a", "c", "root"},
+		},
+		{
+			description:    "module and its requirements",
+			modules:        []string{"a"},
+			expectedImages: []string{"b", "a"},
+		},
+		{
+			description:    "several modules",
+			modules:        []string{"b", "c"},
+			expectedImages: []string{"b", "c"},
+		},
+		{
+			description: "unknown module",
+			modules:     []string{"unknown"},
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			tmpDir := t.NewTempDir().
+				Write("skaffold.yaml", skaffoldYaml("metadata:\n  name: root\nrequires:\n- path: a\n- path: c/skaffold.yaml\nbuild:\n  artifacts:\n  - image: root\n")).
+				Write("a/skaffold.yaml", skaffoldYaml("metadata:\n  name: a\nrequires:\n- path: ../b\nbuild:\n  artifacts:\n  - image: a\n")).
+				Write("b/skaffold.yaml", skaffoldYaml("metadata:\n  name: b\nbuild:\n  artifacts:\n  - image: b\n")).
+				Write("c/skaffold.yaml", skaffoldYaml("metadata:\n  name: c\nbuild:\n  artifacts:\n  - image: c\n"))
+			t.Chdir(tmpDir.Root())
+
+			configs, err := ParseConfigs(&cfg.SkaffoldOptions{
+				ConfigurationFile: "skaffold.yaml",
+				Modules:           test.modules,
+			})
+			t.CheckError(test.shouldErr, err)
+			if test.shouldErr {
+				return
+			}
+
+			merged, err := configs.Merge()
+			t.CheckNoError(err)
+			var images []string
+			for _, a := range merged.Build.Artifacts {
+				images = append(images, a.ImageName)
+			}
+			t.CheckDeepEqual(test.expectedImages, images)
+		})
+	}
+}
+
+func TestParseConfigsErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		files       map[string]string
+		expected    string
+	}{
+		{
+			description: "cycle",
+			files: map[string]string{
+				"skaffold.yaml":   "requires:\n- path: a\n",
+				"a/skaffold.yaml": "requires:\n- path: ..\n",
+			},
+			expected: "cycle detected",
+		},
+		{
+			description: "missing required config",
+			files: map[string]string{
+				"skaffold.yaml": "requires:\n- path: missing\n",
+			},
+			expected: "reading config missing required by skaffold.yaml",
+		},
+		{
+			description: "neither path nor git",
+			files: map[string]string{
+				"skaffold.yaml": "requires:\n- activeProfiles: [prod]\n",
+			},
+			expected: "neither a path nor a git repository",
+		},
+		{
+			description: "git repository looking like an option",
+			files: map[string]string{
+				"skaffold.yaml": "requires:\n- git:\n    repo: --upload-pack=touch pwned\n",
+			},
+			expected: "invalid git repository",
+		},
+		{
+			description: "git reference looking like an option",
+			files: map[string]string{
+				"skaffold.yaml": "requires:\n- git:\n    repo: https://github.com/GoogleContainerTools/skaffold.git\n    ref: --orphan\n",
+			},
+			expected: "invalid git reference",
+		},
+		{
+			description: "unknown profile in required config",
+			files: map[string]string{
+				"skaffold.yaml":   "requires:\n- path: a\n  activeProfiles: [unknown]\n",
+				"a/skaffold.yaml": "",
+			},
+			expected: "applying profiles",
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			tmpDir := t.NewTempDir()
+			for file, content := range test.files {
+				tmpDir.Write(file, skaffoldYaml(content))
+			}
+			t.Chdir(tmpDir.Root())
+
+			_, err := ParseConfigs(&cfg.SkaffoldOptions{ConfigurationFile: "skaffold.yaml"})
+
+			t.CheckErrorContains(test.expected, err)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		description string
+		configs     []*latest.SkaffoldConfig
+		expected    latest.DeployConfig
+		shouldErr   bool
+	}{
+		{
+			description: "different deployers",
+			configs: []*latest.SkaffoldConfig{
+				{Pipeline: latest.Pipeline{Deploy: latest.DeployConfig{DeployType: latest.DeployType{HelmDeploy: &latest.HelmDeploy{Releases: []latest.HelmRelease{{Name: "a"}}}}}}},
+				{Pipeline: latest.Pipeline{Deploy: latest.DeployConfig{DeployType: latest.DeployType{KubectlDeploy: &latest.KubectlDeploy{Manifests: []string{"b.yaml"}}}}}},
+			},
+			expected: latest.DeployConfig{DeployType: latest.DeployType{
+				HelmDeploy:    &latest.HelmDeploy{Releases: []latest.HelmRelease{{Name: "a"}}},
+				KubectlDeploy: &latest.KubectlDeploy{Manifests: []string{"b.yaml"}},
+			}},
+		},
+		{
+			description: "same deployer",
+			configs: []*latest.SkaffoldConfig{
+				{Pipeline: latest.Pipeline{Deploy: latest.DeployConfig{
+					DeployType: latest.DeployType{KubectlDeploy: &latest.KubectlDeploy{Manifests: []string{"a.yaml"}}},
+					Logs:       &latest.LogsConfig{Selectors: []string{"app=a"}},
+				}}},
+				{Pipeline: latest.Pipeline{Deploy: latest.DeployConfig{
+					DeployType: latest.DeployType{KubectlDeploy: &latest.KubectlDeploy{Manifests: []string{"b.yaml"}, RemoteManifests: []string{"c"}}},
+					Logs:       &latest.LogsConfig{Selectors: []string{"app=b"}},
+				}}},
+			},
+			expected: latest.DeployConfig{
+				DeployType: latest.DeployType{KubectlDeploy: &latest.KubectlDeploy{Manifests: []string{"a.yaml", "b.yaml"}, RemoteManifests: []string{"c"}}},
+				Logs:       &latest.LogsConfig{Selectors: []string{"app=a", "app=b"}},
+			},
+		},
+		{
+			description: "different kustomize paths",
+			configs: []*latest.SkaffoldConfig{
+				{Pipeline: latest.Pipeline{Deploy: latest.DeployConfig{DeployType: latest.DeployType{KustomizeDeploy: &latest.KustomizeDeploy{KustomizePath: "a"}}}}},
+				{Pipeline: latest.Pipeline{Deploy: latest.DeployConfig{DeployType: latest.DeployType{KustomizeDeploy: &latest.KustomizeDeploy{KustomizePath: "b"}}}}},
+			},
+			shouldErr: true,
+		},
+		{
+			description: "duplicate artifact",
+			configs: []*latest.SkaffoldConfig{
+				{Pipeline: latest.Pipeline{Build: latest.BuildConfig{Artifacts: []*latest.Artifact{{ImageName: "image"}}}}},
+				{Pipeline: latest.Pipeline{Build: latest.BuildConfig{Artifacts: []*latest.Artifact{{ImageName: "image"}}}}},
+			},
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			configs := &Configs{
+				Root:     test.configs[len(test.configs)-1],
+				Selected: test.configs,
+			}
+
+			merged, err := configs.Merge()
+
+			t.CheckError(test.shouldErr, err)
+			if !test.shouldErr {
+				t.CheckDeepEqual(test.expected, merged.Deploy)
+			}
+		})
+	}
+}
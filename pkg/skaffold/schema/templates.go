@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+)
+
+// templateTag marks the fields whose value can contain environment variable templates.
+const templateTag = "template"
+
+// expandEnvTemplates expands the environment variables templates, such as `{{.REGISTRY}}`,
+// found in the fields tagged with `skaffold:"template"`. Profiles are left untouched, since
+// they are applied before.
+func expandEnvTemplates(config *latest.SkaffoldConfig) error {
+	if err := expandValue(reflect.ValueOf(&config.Pipeline).Elem(), "", false); err != nil {
+		return err
+	}
+	for i := range config.Requires {
+		if err := expandValue(reflect.ValueOf(&config.Requires[i]).Elem(), fmt.Sprintf("requires[%d]", i), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandValue(v reflect.Value, path string, templated bool) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return expandValue(v.Elem(), path, templated)
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			fieldPath := path
+			if name := yamlName(field); name != "" {
+				fieldPath = strings.TrimPrefix(path+"."+name, ".")
+			}
+			if err := expandValue(v.Field(i), fieldPath, field.Tag.Get("skaffold") == templateTag); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := expandValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), templated); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		// Map values are not addressable: expand a copy and put it back.
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			if err := expandValue(value, fmt.Sprintf("%s.%v", path, key), templated); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, value)
+		}
+
+	case reflect.String:
+		if !templated || !strings.Contains(v.String(), "{{") {
+			return nil
+		}
+		expanded, err := expandEnvTemplate(v.String())
+		if err != nil {
+			return errors.Wrapf(err, "expanding %s", path)
+		}
+		v.SetString(expanded)
+	}
+
+	return nil
+}
+
+// expandEnvTemplate expands a template with the environment variables.
+// Unlike the tagger, it fails if a variable is not set.
+func expandEnvTemplate(s string) (string, error) {
+	tmpl, err := util.ParseEnvTemplate(s)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing template")
+	}
+
+	tmpl.Option("missingkey=error")
+	return util.ExecuteEnvTemplate(tmpl, nil)
+}
+
+func yamlName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
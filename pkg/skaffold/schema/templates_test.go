@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestExpandEnvTemplates(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		t.Override(&util.OSEnviron, func() []string {
+			return []string{"REGISTRY=gcr.io/project", "NS=staging", "VERSION=v1"}
+		})
+
+		version := "{{.VERSION}}"
+		config := &latest.SkaffoldConfig{
+			Pipeline: latest.Pipeline{
+				Build: latest.BuildConfig{
+					TagPolicy: latest.TagPolicy{EnvTemplateTagger: &latest.EnvTemplateTagger{Template: "{{.IMAGE_NAME}}"}},
+					Artifacts: []*latest.Artifact{{
+						ImageName: "{{.REGISTRY}}/app",
+						ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{
+							BuildArgs: map[string]*string{"VERSION": &version},
+							CacheFrom: []string{"{{.REGISTRY}}/app:latest"},
+						}},
+					}},
+				},
+				Deploy: latest.DeployConfig{
+					DeployType: latest.DeployType{HelmDeploy: &latest.HelmDeploy{
+						Releases: []latest.HelmRelease{{
+							Namespace:         "{{.NS}}",
+							Values:            map[string]string{"image": "{{.REGISTRY}}/app"},
+							SetValueTemplates: map[string]string{"tag": "{{.VERSION}}"},
+						}},
+					}},
+				},
+			},
+			Profiles: []latest.Profile{{
+				Pipeline: latest.Pipeline{Build: latest.BuildConfig{InsecureRegistries: []string{"{{.MISSING}}"}}},
+			}},
+		}
+
+		err := expandEnvTemplates(config)
+
+		t.CheckNoError(err)
+		t.CheckDeepEqual("gcr.io/project/app", config.Build.Artifacts[0].ImageName)
+		t.CheckDeepEqual("v1", version)
+		t.CheckDeepEqual([]string{"gcr.io/project/app:latest"}, config.Build.Artifacts[0].DockerArtifact.CacheFrom)
+		t.CheckDeepEqual("staging", config.Deploy.HelmDeploy.Releases[0].Namespace)
+		t.CheckDeepEqual(map[string]string{"image": "gcr.io/project/app"}, config.Deploy.HelmDeploy.Releases[0].Values)
+
+		// Fields that are not templated, or templated later, are left untouched.
+		t.CheckDeepEqual("{{.IMAGE_NAME}}", config.Build.TagPolicy.EnvTemplateTagger.Template)
+		t.CheckDeepEqual(map[string]string{"tag": "{{.VERSION}}"}, config.Deploy.HelmDeploy.Releases[0].SetValueTemplates)
+		t.CheckDeepEqual([]string{"{{.MISSING}}"}, config.Profiles[0].Build.InsecureRegistries)
+	})
+}
+
+func TestExpandEnvTemplatesErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		config      *latest.SkaffoldConfig
+		expected    string
+	}{
+		{
+			description: "missing variable",
+			config: &latest.SkaffoldConfig{Pipeline: latest.Pipeline{
+				Test: []*latest.TestCase{{ImageName: "app", StructureTests: []string{"tests/{{.SUITE}}/*"}}},
+			}},
+			expected: `expanding test[0].structureTests[0]: executing template: template: envTemplate:1:8: executing "envTemplate" at <.SUITE>: map has no entry for key "SUITE"`,
+		},
+		{
+			description: "invalid template",
+			config: &latest.SkaffoldConfig{Pipeline: latest.Pipeline{
+				Deploy: latest.DeployConfig{DeployType: latest.DeployType{KubectlDeploy: &latest.KubectlDeploy{Manifests: []string{"{{.NS"}}}},
+			}},
+			expected: "expanding deploy.kubectl.manifests[0]: parsing template",
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.Override(&util.OSEnviron, func() []string { return nil })
+
+			err := expandEnvTemplates(test.config)
+
+			t.CheckErrorContains(test.expected, err)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/docker"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+)
+
+// Source is a configuration and the file it was read from.
+type Source struct {
+	Config *latest.SkaffoldConfig
+	File   string
+
+	// ProfilePaths are the yaml paths of the values set by profiles.
+	ProfilePaths []string
+}
+
+// Lint checks configurations more thoroughly than Process: images referenced by tests and
+// helm values must be built by one of the configurations, and workspaces, Dockerfiles and
+// the pull secret of cluster builds must exist. Errors are prefixed with their position in the configuration file,
+// for example `skaffold.yaml:12:5`, unless a profile changed the field.
+func Lint(sources []Source) []error {
+	images := map[string]bool{}
+	for _, source := range sources {
+		for _, a := range source.Config.Build.Artifacts {
+			images[a.ImageName] = true
+		}
+	}
+
+	var errs []error
+	for _, source := range sources {
+		positions := map[string]Position{}
+		if source.File != "-" && !util.IsURL(source.File) {
+			if buf, err := ioutil.ReadFile(source.File); err == nil {
+				positions = yamlPositions(buf)
+			}
+		}
+
+		sourceErrs := validate(source.Config)
+		sourceErrs = append(sourceErrs, validateImageReferences(source.Config, images)...)
+		sourceErrs = append(sourceErrs, validateFiles(source.Config.Build.Artifacts)...)
+		sourceErrs = append(sourceErrs, validatePullSecret(source.Config.Build.Cluster)...)
+
+		for _, err := range sourceErrs {
+			errs = append(errs, locate(err, source.File, positions, source.ProfilePaths))
+		}
+	}
+	return errs
+}
+
+// locate prefixes an error with the position of its field in the configuration file.
+func locate(err error, file string, positions map[string]Position, profilePaths []string) error {
+	if fieldErr, ok := err.(*fieldError); ok && !setByProfile(profilePaths, fieldErr.path) {
+		if pos, found := lookup(positions, fieldErr.path); found {
+			return fmt.Errorf("%s:%d:%d: %s", file, pos.Line, pos.Col, fieldErr.err)
+		}
+	}
+	return fmt.Errorf("%s: %s", file, err)
+}
+
+// setByProfile tells whether a profile changed a value under a path or, for
+// a path in a list, under the outermost list item. The field checked then isn't
+// the one at this path in the file.
+func setByProfile(profilePaths []string, path string) bool {
+	if i := strings.Index(path, "]"); i >= 0 {
+		path = path[:i+1]
+	}
+	for _, p := range profilePaths {
+		if p == path || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateImageReferences makes sure that tests and helm values only reference built images.
+func validateImageReferences(config *latest.SkaffoldConfig, images map[string]bool) (errs []error) {
+	for i, test := range config.Test {
+		if !images[test.ImageName] {
+			errs = append(errs, fieldErrorf(fmt.Sprintf("test[%d].image", i), "test image %s is not built by any artifact", test.ImageName))
+		}
+	}
+
+	if config.Deploy.HelmDeploy != nil {
+		for i, release := range config.Deploy.HelmDeploy.Releases {
+			var keys []string
+			for key := range release.Values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				if image := release.Values[key]; !images[image] {
+					errs = append(errs, fieldErrorf(fmt.Sprintf("deploy.helm.releases[%d].values.%s", i, key), "helm value %s of release %s references image %s, which is not built by any artifact", key, release.Name, image))
+				}
+			}
+		}
+	}
+	return
+}
+
+// validateFiles makes sure that the workspaces and Dockerfiles of the artifacts exist.
+func validateFiles(artifacts []*latest.Artifact) (errs []error) {
+	for i, a := range artifacts {
+		path := fmt.Sprintf("build.artifacts[%d]", i)
+
+		if info, err := os.Stat(a.Workspace); err != nil || !info.IsDir() {
+			errs = append(errs, fieldErrorf(path+".context", "artifact %s has a context %s that is not a directory", a.ImageName, a.Workspace))
+			continue
+		}
+
+		if a.DockerArtifact != nil {
+			dockerfile, err := docker.NormalizeDockerfilePath(a.Workspace, a.DockerArtifact.DockerfilePath)
+			if err != nil {
+				errs = append(errs, fieldErrorf(path+".docker.dockerfile", "artifact %s has an invalid Dockerfile path: %s", a.ImageName, err))
+				continue
+			}
+			if _, err := os.Stat(dockerfile); err != nil {
+				errs = append(errs, fieldErrorf(path+".docker.dockerfile", "artifact %s has no Dockerfile at %s", a.ImageName, dockerfile))
+			}
+		}
+	}
+	return
+}
+
+// validatePullSecret makes sure that the pull secret of a cluster build is an existing file.
+// Templated paths are only known once they are expanded, at build time.
+func validatePullSecret(cluster *latest.ClusterDetails) (errs []error) {
+	if cluster == nil || cluster.PullSecret == "" || strings.Contains(cluster.PullSecret, "{{") {
+		return
+	}
+	if info, err := os.Stat(cluster.PullSecret); err != nil || info.IsDir() {
+		errs = append(errs, fieldErrorf("build.cluster.pullSecret", "pull secret %s is not a file", cluster.PullSecret))
+	}
+	return
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+func TestLocate(t *testing.T) {
+	positions := map[string]Position{
+		"build.artifacts[0]":       {Line: 3, Col: 5},
+		"build.artifacts[0].image": {Line: 3, Col: 5},
+		"test[0].image":            {Line: 5, Col: 3},
+	}
+
+	tests := []struct {
+		description  string
+		err          error
+		profilePaths []string
+		expected     string
+	}{
+		{
+			description: "field in file",
+			err:         fieldErrorf("build.artifacts[0].image", "invalid image"),
+			expected:    "skaffold.yaml:3:5: invalid image",
+		},
+		{
+			description: "field not in file",
+			err:         fieldErrorf("build.artifacts[0].context", "invalid context"),
+			expected:    "skaffold.yaml:3:5: invalid context",
+		},
+		{
+			description:  "field set by profile",
+			err:          fieldErrorf("build.artifacts[0].image", "invalid image"),
+			profilePaths: []string{"build.artifacts[0].image"},
+			expected:     "skaffold.yaml: invalid image",
+		},
+		{
+			description:  "list item changed by profile",
+			err:          fieldErrorf("build.artifacts[0].context", "invalid context"),
+			profilePaths: []string{"build.artifacts[0].docker.dockerfile"},
+			expected:     "skaffold.yaml: invalid context",
+		},
+		{
+			description:  "other list item changed by profile",
+			err:          fieldErrorf("test[0].image", "invalid image"),
+			profilePaths: []string{"build.artifacts[0].image", "test[1].image"},
+			expected:     "skaffold.yaml:5:3: invalid image",
+		},
+		{
+			description: "not a field error",
+			err:         errors.New("invalid config"),
+			expected:    "skaffold.yaml: invalid config",
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			err := locate(test.err, "skaffold.yaml", positions, test.profilePaths)
+
+			t.CheckDeepEqual(test.expected, err.Error())
+		})
+	}
+}
+
+func TestValidatePullSecret(t *testing.T) {
+	tests := []struct {
+		description string
+		pullSecret  string
+		shouldErr   bool
+	}{
+		{
+			description: "no pull secret",
+		},
+		{
+			description: "existing file",
+			pullSecret:  "secret.json",
+		},
+		{
+			description: "templated path",
+			pullSecret:  "{{.SECRET}}",
+		},
+		{
+			description: "missing file",
+			pullSecret:  "missing.json",
+			shouldErr:   true,
+		},
+		{
+			description: "directory",
+			pullSecret:  "dir",
+			shouldErr:   true,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			tmpDir := t.NewTempDir().
+				Write("secret.json", "{}").
+				Mkdir("dir")
+			t.Chdir(tmpDir.Root())
+
+			errs := validatePullSecret(&latest.ClusterDetails{PullSecret: test.pullSecret})
+
+			t.CheckDeepEqual(test.shouldErr, len(errs) > 0)
+		})
+	}
+}
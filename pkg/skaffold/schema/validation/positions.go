@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Position is a line and a column in a configuration file, starting at 1.
+type Position struct {
+	Line int
+	Col  int
+}
+
+// yamlPositions indexes the position of every key and sequence item of the first
+// document of a yaml file, by path, such as `build.artifacts[0].image`.
+func yamlPositions(buf []byte) map[string]Position {
+	positions := map[string]Position{}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(buf, &doc); err != nil || len(doc.Content) == 0 {
+		return positions
+	}
+
+	indexNode(doc.Content[0], "", positions)
+	return positions
+}
+
+func indexNode(node *yaml.Node, path string, positions map[string]Position) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := key.Value
+			if path != "" {
+				keyPath = path + "." + key.Value
+			}
+			positions[keyPath] = Position{Line: key.Line, Col: key.Column}
+			indexNode(value, keyPath, positions)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			positions[itemPath] = Position{Line: item.Line, Col: item.Column}
+			indexNode(item, itemPath, positions)
+		}
+	}
+}
+
+// lookup returns the position of a path or, if it is not in the file,
+// of the closest parent that is.
+func lookup(positions map[string]Position, path string) (Position, bool) {
+	for path != "" {
+		if pos, found := positions[path]; found {
+			return pos, true
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return Position{}, false
+}
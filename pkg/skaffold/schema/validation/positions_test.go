@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/skaffold/testutil"
+)
+
+const positionsYaml = `apiVersion: skaffold/v1beta12
+kind: Config
+# comment
+build:
+  artifacts:
+  - image: app # comment
+    context: app
+    docker:
+      buildArgs: {A: B}
+  -
+    image: "other"
+    sync:
+      manual:
+        - src: "*.js"
+          dest: .
+  tagPolicy:
+    envTemplate:
+      template: |
+        image: {{.IMAGE_NAME}}
+        - not: a key
+test:
+- image: app
+  structureTests:
+  - ./test/*
+deploy:
+  helm:
+    releases:
+    - name: release
+      values:
+        'image': app
+`
+
+func TestYamlPositions(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected Position
+	}{
+		{path: "build", expected: Position{Line: 4, Col: 1}},
+		{path: "build.artifacts[0]", expected: Position{Line: 6, Col: 5}},
+		{path: "build.artifacts[0].image", expected: Position{Line: 6, Col: 5}},
+		{path: "build.artifacts[0].context", expected: Position{Line: 7, Col: 5}},
+		{path: "build.artifacts[0].docker.buildArgs", expected: Position{Line: 9, Col: 7}},
+		{path: "build.artifacts[0].docker.buildArgs.A", expected: Position{Line: 9, Col: 19}},
+		{path: "build.artifacts[1]", expected: Position{Line: 11, Col: 5}},
+		{path: "build.artifacts[1].image", expected: Position{Line: 11, Col: 5}},
+		{path: "build.artifacts[1].sync.manual[0].dest", expected: Position{Line: 15, Col: 11}},
+		{path: "build.tagPolicy.envTemplate.template", expected: Position{Line: 18, Col: 7}},
+		{path: "test[0].structureTests[0]", expected: Position{Line: 24, Col: 5}},
+		{path: "deploy.helm.releases[0].values.image", expected: Position{Line: 30, Col: 9}},
+		{path: "deploy.kubectl", expected: Position{Line: 25, Col: 1}},
+	}
+
+	positions := yamlPositions([]byte(positionsYaml))
+	for _, test := range tests {
+		testutil.Run(t, test.path, func(t *testutil.T) {
+			pos, found := lookup(positions, test.path)
+
+			t.CheckDeepEqual(true, found)
+			t.CheckDeepEqual(test.expected, pos)
+		})
+	}
+
+	// Block scalars are not indexed.
+	_, found := positions["build.tagPolicy.envTemplate.template.image"]
+	testutil.CheckDeepEqual(t, false, found)
+}
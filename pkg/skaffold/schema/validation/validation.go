@@ -32,10 +32,7 @@ var (
 
 // Process checks if the Skaffold pipeline is valid and returns all encountered errors as a concatenated string
 func Process(config *latest.SkaffoldConfig) error {
-	errs := visitStructs(config, validateYamltags)
-	errs = append(errs, validateDockerNetworkMode(config.Build.Artifacts)...)
-	errs = append(errs, validateCustomDependencies(config.Build.Artifacts)...)
-	errs = append(errs, validateSyncRules(config.Build.Artifacts)...)
+	errs := validate(config)
 
 	if len(errs) == 0 {
 		return nil
@@ -48,9 +45,46 @@ func Process(config *latest.SkaffoldConfig) error {
 	return fmt.Errorf(strings.Join(messages, " | "))
 }
 
+func validate(config *latest.SkaffoldConfig) []error {
+	errs := visitStructs(config, "", validateYamltags)
+	errs = append(errs, validateDockerNetworkMode(config.Build.Artifacts)...)
+	errs = append(errs, validateCustomDependencies(config.Build.Artifacts)...)
+	errs = append(errs, validateSyncRules(config.Build.Artifacts)...)
+	errs = append(errs, validatePortForwardResources(config.PortForward)...)
+	errs = append(errs, validateUniqueImages(config.Build.Artifacts)...)
+	return errs
+}
+
+// fieldError is an error found on a field of the configuration.
+type fieldError struct {
+	// path is the yaml path to the field, for example `build.artifacts[0].image`.
+	path string
+	err  error
+}
+
+func (e *fieldError) Error() string {
+	return e.err.Error()
+}
+
+func fieldErrorf(path string, format string, a ...interface{}) error {
+	return &fieldError{path: path, err: fmt.Errorf(format, a...)}
+}
+
+// validateUniqueImages makes sure that an image is built by only one artifact.
+func validateUniqueImages(artifacts []*latest.Artifact) (errs []error) {
+	seen := map[string]bool{}
+	for i, a := range artifacts {
+		if seen[a.ImageName] {
+			errs = append(errs, fieldErrorf(fmt.Sprintf("build.artifacts[%d].image", i), "duplicate artifact image %s", a.ImageName))
+		}
+		seen[a.ImageName] = true
+	}
+	return
+}
+
 // validateDockerNetworkMode makes sure that networkMode is one of `Bridge`, `None`, or `Host` if set.
 func validateDockerNetworkMode(artifacts []*latest.Artifact) (errs []error) {
-	for _, a := range artifacts {
+	for i, a := range artifacts {
 		if a.DockerArtifact == nil || a.DockerArtifact.NetworkMode == "" {
 			continue
 		}
@@ -58,14 +92,38 @@ func validateDockerNetworkMode(artifacts []*latest.Artifact) (errs []error) {
 		if mode == "none" || mode == "bridge" || mode == "host" {
 			continue
 		}
-		errs = append(errs, fmt.Errorf("artifact %s has invalid networkMode '%s'", a.ImageName, mode))
+		errs = append(errs, fieldErrorf(fmt.Sprintf("build.artifacts[%d].docker.network", i), "artifact %s has invalid networkMode '%s'", a.ImageName, mode))
+	}
+	return
+}
+
+// validatePortForwardResources makes sure that port forwarded resources have a supported type,
+// a name or a selector, and a remote port.
+func validatePortForwardResources(resources []*latest.PortForwardResource) (errs []error) {
+	for i, r := range resources {
+		path := fmt.Sprintf("portForward[%d]", i)
+		switch latest.ResourceType(strings.ToLower(string(r.Type))) {
+		case latest.Service, latest.Deployment:
+			if r.Name == "" {
+				errs = append(errs, fieldErrorf(path, "port forwarded %s has no resourceName", r.Type))
+			}
+		case latest.Pod:
+			if r.Name == "" && r.Selector == "" {
+				errs = append(errs, fieldErrorf(path, "port forwarded pod has neither resourceName nor selector"))
+			}
+		default:
+			errs = append(errs, fieldErrorf(path+".resourceType", "port forwarded resource %s has invalid resourceType '%s'", r.Name, r.Type))
+		}
+		if r.Port <= 0 {
+			errs = append(errs, fieldErrorf(path+".port", "port forwarded resource %s has no port", r.Name))
+		}
 	}
 	return
 }
 
 // validateCustomDependencies makes sure that dependencies.ignore is only used in conjunction with dependencies.paths
 func validateCustomDependencies(artifacts []*latest.Artifact) (errs []error) {
-	for _, a := range artifacts {
+	for i, a := range artifacts {
 		if a.CustomArtifact == nil {
 			continue
 		}
@@ -73,14 +131,15 @@ func validateCustomDependencies(artifacts []*latest.Artifact) (errs []error) {
 			continue
 		}
 		if a.CustomArtifact.Dependencies.Dockerfile != nil || a.CustomArtifact.Dependencies.Command != "" {
-			errs = append(errs, fmt.Errorf("artifact %s has invalid dependencies; dependencies.ignore can only be used in conjunction with dependencies.paths", a.ImageName))
+			errs = append(errs, fieldErrorf(fmt.Sprintf("build.artifacts[%d].custom.dependencies.ignore", i), "artifact %s has invalid dependencies; dependencies.ignore can only be used in conjunction with dependencies.paths", a.ImageName))
 		}
 	}
 	return
 }
 
-// visitStructs recursively visits all fields in the config and collects errors found by the visitor
-func visitStructs(s interface{}, visitor func(interface{}) error) []error {
+// visitStructs recursively visits all fields in the config and collects errors found by the visitor.
+// Errors are attached to the yaml path of the struct they were found on.
+func visitStructs(s interface{}, path string, visitor func(interface{}) error) []error {
 	v := reflect.ValueOf(s)
 	t := reflect.TypeOf(s)
 
@@ -88,6 +147,9 @@ func visitStructs(s interface{}, visitor func(interface{}) error) []error {
 	case reflect.Struct:
 		var errs []error
 		if err := visitor(v.Interface()); err != nil {
+			if path != "" {
+				err = &fieldError{path: path, err: err}
+			}
 			errs = append(errs, err)
 		}
 
@@ -96,7 +158,7 @@ func visitStructs(s interface{}, visitor func(interface{}) error) []error {
 			if !v.Field(i).CanInterface() {
 				continue
 			}
-			if fieldErrs := visitStructs(v.Field(i).Interface(), visitor); fieldErrs != nil {
+			if fieldErrs := visitStructs(v.Field(i).Interface(), fieldPath(path, t.Field(i)), visitor); fieldErrs != nil {
 				errs = append(errs, fieldErrs...)
 			}
 		}
@@ -107,7 +169,7 @@ func visitStructs(s interface{}, visitor func(interface{}) error) []error {
 		// for slices check each element
 		var errs []error
 		for i := 0; i < v.Len(); i++ {
-			if elemErrs := visitStructs(v.Index(i).Interface(), visitor); elemErrs != nil {
+			if elemErrs := visitStructs(v.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i), visitor); elemErrs != nil {
 				errs = append(errs, elemErrs...)
 			}
 		}
@@ -118,7 +180,7 @@ func visitStructs(s interface{}, visitor func(interface{}) error) []error {
 		if v.IsNil() {
 			return nil
 		}
-		return visitStructs(v.Elem().Interface(), visitor)
+		return visitStructs(v.Elem().Interface(), path, visitor)
 
 	default:
 		// other values are fine
@@ -126,14 +188,31 @@ func visitStructs(s interface{}, visitor func(interface{}) error) []error {
 	}
 }
 
+// fieldPath returns the yaml path of a struct field. Inlined fields share the path of their struct.
+func fieldPath(path string, field reflect.StructField) string {
+	tag := strings.Split(field.Tag.Get("yaml"), ",")
+	if len(tag) > 1 && tag[1] == "inline" {
+		return path
+	}
+
+	name := tag[0]
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
 // validateSyncRules checks that all manual sync rules have a valid strip prefix
 func validateSyncRules(artifacts []*latest.Artifact) []error {
 	var errs []error
-	for _, a := range artifacts {
+	for i, a := range artifacts {
 		if a.Sync != nil {
-			for _, r := range a.Sync.Manual {
+			for j, r := range a.Sync.Manual {
 				if !strings.HasPrefix(r.Src, r.Strip) {
-					err := fmt.Errorf("sync rule pattern '%s' does not have prefix '%s'", r.Src, r.Strip)
+					err := fieldErrorf(fmt.Sprintf("build.artifacts[%d].sync.manual[%d].strip", i, j), "sync rule pattern '%s' does not have prefix '%s'", r.Src, r.Strip)
 					errs = append(errs, err)
 				}
 			}
@@ -77,6 +77,21 @@ func TestValidateSchema(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			description: "git tagger variant in lower case",
+			cfg: &latest.SkaffoldConfig{
+				APIVersion: "foo",
+				Kind:       "bar",
+				Pipeline: latest.Pipeline{
+					Build: latest.BuildConfig{
+						TagPolicy: latest.TagPolicy{
+							GitTagger: &latest.GitTagger{Variant: "commitsha"},
+						},
+					},
+				},
+			},
+			shouldErr: false,
+		},
 	}
 	for _, test := range tests {
 		testutil.Run(t, test.description, func(t *testutil.T) {
@@ -244,13 +259,37 @@ func TestVisitStructs(t *testing.T) {
 	}
 	for _, test := range tests {
 		testutil.Run(t, test.description, func(t *testutil.T) {
-			actual := visitStructs(test.input, alwaysErr)
+			actual := visitStructs(test.input, "", alwaysErr)
 
 			t.CheckDeepEqual(test.expectedErrs, len(actual))
 		})
 	}
 }
 
+func TestVisitStructsPaths(t *testing.T) {
+	config := &latest.SkaffoldConfig{
+		Pipeline: latest.Pipeline{
+			Build: latest.BuildConfig{
+				Artifacts: []*latest.Artifact{
+					{ImageName: "app"},
+					{ImageName: "web", ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{}}},
+				},
+			},
+		},
+	}
+	failOnDocker := func(s interface{}) error {
+		if _, ok := s.(latest.DockerArtifact); ok {
+			return fmt.Errorf("invalid docker artifact")
+		}
+		return nil
+	}
+
+	errs := visitStructs(config, "", failOnDocker)
+
+	testutil.CheckDeepEqual(t, 1, len(errs))
+	testutil.CheckDeepEqual(t, "build.artifacts[1].docker", errs[0].(*fieldError).path)
+}
+
 func TestValidateNetworkMode(t *testing.T) {
 	tests := []struct {
 		description string
@@ -518,3 +557,116 @@ func TestValidateCustomDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePortForwardResources(t *testing.T) {
+	var tests = []struct {
+		description    string
+		resource       *latest.PortForwardResource
+		expectedErrors int
+	}{
+		{
+			description: "service",
+			resource:    &latest.PortForwardResource{Type: "service", Name: "web", Port: 80},
+		},
+		{
+			description: "deployment type is case insensitive",
+			resource:    &latest.PortForwardResource{Type: "Deployment", Name: "web", Port: 8080},
+		},
+		{
+			description: "pod by selector",
+			resource:    &latest.PortForwardResource{Type: "pod", Selector: "app=web", Port: 8080},
+		},
+		{
+			description:    "service without name",
+			resource:       &latest.PortForwardResource{Type: "service", Selector: "app=web", Port: 80},
+			expectedErrors: 1,
+		},
+		{
+			description:    "pod without name nor selector",
+			resource:       &latest.PortForwardResource{Type: "pod", Port: 8080},
+			expectedErrors: 1,
+		},
+		{
+			description:    "unknown type without port",
+			resource:       &latest.PortForwardResource{Type: "statefulset", Name: "db"},
+			expectedErrors: 2,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			errs := validatePortForwardResources([]*latest.PortForwardResource{test.resource})
+
+			t.CheckDeepEqual(test.expectedErrors, len(errs))
+		})
+	}
+}
+
+func TestValidateUniqueImages(t *testing.T) {
+	artifacts := []*latest.Artifact{{ImageName: "a"}, {ImageName: "b"}, {ImageName: "a"}}
+
+	errs := validateUniqueImages(artifacts)
+
+	testutil.CheckDeepEqual(t, 1, len(errs))
+	testutil.CheckDeepEqual(t, "build.artifacts[2].image", errs[0].(*fieldError).path)
+}
+
+func TestValidateImageReferences(t *testing.T) {
+	tests := []struct {
+		description   string
+		config        *latest.SkaffoldConfig
+		expectedPaths []string
+	}{
+		{
+			description: "built images",
+			config: &latest.SkaffoldConfig{Pipeline: latest.Pipeline{
+				Test: []*latest.TestCase{{ImageName: "built"}},
+				Deploy: latest.DeployConfig{DeployType: latest.DeployType{HelmDeploy: &latest.HelmDeploy{
+					Releases: []latest.HelmRelease{{Values: map[string]string{"image": "built"}}},
+				}}},
+			}},
+		},
+		{
+			description: "unknown images",
+			config: &latest.SkaffoldConfig{Pipeline: latest.Pipeline{
+				Test: []*latest.TestCase{{ImageName: "built"}, {ImageName: "unknown"}},
+				Deploy: latest.DeployConfig{DeployType: latest.DeployType{HelmDeploy: &latest.HelmDeploy{
+					Releases: []latest.HelmRelease{{Values: map[string]string{"image": "built", "b": "unknown", "a": "unknown"}}},
+				}}},
+			}},
+			expectedPaths: []string{"test[1].image", "deploy.helm.releases[0].values.a", "deploy.helm.releases[0].values.b"},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			errs := validateImageReferences(test.config, map[string]bool{"built": true})
+
+			var paths []string
+			for _, err := range errs {
+				paths = append(paths, err.(*fieldError).path)
+			}
+			t.CheckDeepEqual(test.expectedPaths, paths)
+		})
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+	testutil.Run(t, "", func(t *testutil.T) {
+		tmpDir := t.NewTempDir().
+			Write("app/Dockerfile", "FROM scratch").
+			Write("other/file", "")
+		t.Chdir(tmpDir.Root())
+
+		errs := validateFiles([]*latest.Artifact{
+			{ImageName: "app", Workspace: "app", ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{DockerfilePath: "Dockerfile"}}},
+			{ImageName: "other", Workspace: "other", ArtifactType: latest.ArtifactType{DockerArtifact: &latest.DockerArtifact{DockerfilePath: "Dockerfile"}}},
+			{ImageName: "missing", Workspace: "missing"},
+			{ImageName: "file", Workspace: "other/file"},
+		})
+
+		var paths []string
+		for _, err := range errs {
+			paths = append(paths, err.(*fieldError).path)
+		}
+		t.CheckDeepEqual([]string{"build.artifacts[1].docker.dockerfile", "build.artifacts[2].context", "build.artifacts[3].context"}, paths)
+	})
+}
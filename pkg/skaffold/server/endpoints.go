@@ -20,8 +20,12 @@ import (
 	"context"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/kubernetes"
+	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server/proto"
 	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (s *server) GetState(context.Context, *empty.Empty) (*proto.State, error) {
@@ -41,3 +45,65 @@ func (s *server) Build(context.Context, *empty.Empty) (*empty.Empty, error) {
 	s.trigger <- true
 	return &empty.Empty{}, nil
 }
+
+func (s *server) Pause(ctx context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	paused := true
+	return s.sendIntent(ctx, runcontext.Intent{Paused: &paused})
+}
+
+func (s *server) Resume(ctx context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	paused := false
+	return s.sendIntent(ctx, runcontext.Intent{Paused: &paused})
+}
+
+func (s *server) AutoBuild(ctx context.Context, req *proto.TriggerRequest) (*empty.Empty, error) {
+	enabled := req.GetEnabled()
+	return s.sendIntent(ctx, runcontext.Intent{AutoBuild: &enabled})
+}
+
+func (s *server) AutoSync(ctx context.Context, req *proto.TriggerRequest) (*empty.Empty, error) {
+	enabled := req.GetEnabled()
+	return s.sendIntent(ctx, runcontext.Intent{AutoSync: &enabled})
+}
+
+func (s *server) AutoDeploy(ctx context.Context, req *proto.TriggerRequest) (*empty.Empty, error) {
+	enabled := req.GetEnabled()
+	return s.sendIntent(ctx, runcontext.Intent{AutoDeploy: &enabled})
+}
+
+func (s *server) Execute(ctx context.Context, req *proto.UserIntentRequest) (*empty.Empty, error) {
+	intent := req.GetIntent()
+	return s.sendIntent(ctx, runcontext.Intent{
+		Build:     intent.GetBuild(),
+		Sync:      intent.GetSync(),
+		Deploy:    intent.GetDeploy(),
+		Artifacts: intent.GetArtifacts(),
+	})
+}
+
+func (s *server) SetLogFilter(ctx context.Context, req *proto.LogFilterRequest) (*empty.Empty, error) {
+	if _, err := kubernetes.NewLogFilter(req.GetInclude(), req.GetExclude()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.sendIntent(ctx, runcontext.Intent{
+		LogFilters: &runcontext.LogFilters{
+			Include: req.GetInclude(),
+			Exclude: req.GetExclude(),
+		},
+	})
+}
+
+// sendIntent hands an intent over to the dev loop. It blocks until the dev loop
+// has received it or the request is cancelled.
+func (s *server) sendIntent(ctx context.Context, intent runcontext.Intent) (*empty.Empty, error) {
+	if s.intents == nil {
+		return nil, status.Error(codes.Unavailable, "no dev loop is running")
+	}
+
+	select {
+	case s.intents <- intent:
+		return &empty.Empty{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -145,12 +145,19 @@ func (m *Request) GetName() string {
 }
 
 type State struct {
-	BuildState           *BuildState           `protobuf:"bytes,1,opt,name=buildState,proto3" json:"buildState,omitempty"`
-	DeployState          *DeployState          `protobuf:"bytes,2,opt,name=deployState,proto3" json:"deployState,omitempty"`
-	ForwardedPorts       map[string]*PortEvent `protobuf:"bytes,3,rep,name=forwardedPorts,proto3" json:"forwardedPorts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	BuildState           *BuildState                         `protobuf:"bytes,1,opt,name=buildState,proto3" json:"buildState,omitempty"`
+	DeployState          *DeployState                        `protobuf:"bytes,2,opt,name=deployState,proto3" json:"deployState,omitempty"`
+	ForwardedPorts       map[string]*PortEvent               `protobuf:"bytes,3,rep,name=forwardedPorts,proto3" json:"forwardedPorts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DebuggingContainers  map[string]*DebuggingContainerEvent `protobuf:"bytes,4,rep,name=debuggingContainers,proto3" json:"debuggingContainers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TestState            *TestState                          `protobuf:"bytes,5,opt,name=testState,proto3" json:"testState,omitempty"`
+	FileSyncState        *FileSyncState                      `protobuf:"bytes,6,opt,name=fileSyncState,proto3" json:"fileSyncState,omitempty"`
+	CacheState           *CacheState                         `protobuf:"bytes,7,opt,name=cacheState,proto3" json:"cacheState,omitempty"`
+	ConfigReloadState    *ConfigReloadState                  `protobuf:"bytes,8,opt,name=configReloadState,proto3" json:"configReloadState,omitempty"`
+	FileChanges          map[string]*FileChangeEvent         `protobuf:"bytes,9,rep,name=fileChanges,proto3" json:"fileChanges,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DevLoopIteration     int32                               `protobuf:"varint,10,opt,name=devLoopIteration,proto3" json:"devLoopIteration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
 }
 
 func (m *State) Reset()         { *m = State{} }
@@ -199,6 +206,55 @@ func (m *State) GetForwardedPorts() map[string]*PortEvent {
 	return nil
 }
 
+func (m *State) GetDebuggingContainers() map[string]*DebuggingContainerEvent {
+	if m != nil {
+		return m.DebuggingContainers
+	}
+	return nil
+}
+
+func (m *State) GetTestState() *TestState {
+	if m != nil {
+		return m.TestState
+	}
+	return nil
+}
+
+func (m *State) GetFileSyncState() *FileSyncState {
+	if m != nil {
+		return m.FileSyncState
+	}
+	return nil
+}
+
+func (m *State) GetCacheState() *CacheState {
+	if m != nil {
+		return m.CacheState
+	}
+	return nil
+}
+
+func (m *State) GetConfigReloadState() *ConfigReloadState {
+	if m != nil {
+		return m.ConfigReloadState
+	}
+	return nil
+}
+
+func (m *State) GetFileChanges() map[string]*FileChangeEvent {
+	if m != nil {
+		return m.FileChanges
+	}
+	return nil
+}
+
+func (m *State) GetDevLoopIteration() int32 {
+	if m != nil {
+		return m.DevLoopIteration
+	}
+	return 0
+}
+
 // BuildState contains a map of all skaffold artifacts to their current build
 // states
 type BuildState struct {
@@ -280,12 +336,178 @@ func (m *DeployState) GetStatus() string {
 	return ""
 }
 
+// TestState contains a map of the tested artifacts to their current test states
+type TestState struct {
+	Artifacts            map[string]string `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TestState) Reset()         { *m = TestState{} }
+func (m *TestState) String() string { return proto.CompactTextString(m) }
+func (*TestState) ProtoMessage()    {}
+func (*TestState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{6}
+}
+
+func (m *TestState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestState.Unmarshal(m, b)
+}
+func (m *TestState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestState.Marshal(b, m, deterministic)
+}
+func (m *TestState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestState.Merge(m, src)
+}
+func (m *TestState) XXX_Size() int {
+	return xxx_messageInfo_TestState.Size(m)
+}
+func (m *TestState) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestState proto.InternalMessageInfo
+
+func (m *TestState) GetArtifacts() map[string]string {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+// FileSyncState contains a map of artifacts to the status of their latest file sync
+type FileSyncState struct {
+	Artifacts            map[string]string `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *FileSyncState) Reset()         { *m = FileSyncState{} }
+func (m *FileSyncState) String() string { return proto.CompactTextString(m) }
+func (*FileSyncState) ProtoMessage()    {}
+func (*FileSyncState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{7}
+}
+
+func (m *FileSyncState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileSyncState.Unmarshal(m, b)
+}
+func (m *FileSyncState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileSyncState.Marshal(b, m, deterministic)
+}
+func (m *FileSyncState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileSyncState.Merge(m, src)
+}
+func (m *FileSyncState) XXX_Size() int {
+	return xxx_messageInfo_FileSyncState.Size(m)
+}
+func (m *FileSyncState) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileSyncState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileSyncState proto.InternalMessageInfo
+
+func (m *FileSyncState) GetArtifacts() map[string]string {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+// CacheState contains a map of artifacts to the result of their latest cache lookup
+type CacheState struct {
+	Artifacts            map[string]string `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CacheState) Reset()         { *m = CacheState{} }
+func (m *CacheState) String() string { return proto.CompactTextString(m) }
+func (*CacheState) ProtoMessage()    {}
+func (*CacheState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{8}
+}
+
+func (m *CacheState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheState.Unmarshal(m, b)
+}
+func (m *CacheState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheState.Marshal(b, m, deterministic)
+}
+func (m *CacheState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheState.Merge(m, src)
+}
+func (m *CacheState) XXX_Size() int {
+	return xxx_messageInfo_CacheState.Size(m)
+}
+func (m *CacheState) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheState proto.InternalMessageInfo
+
+func (m *CacheState) GetArtifacts() map[string]string {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+// ConfigReloadState contains the status of the latest reload of the skaffold configuration
+type ConfigReloadState struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigReloadState) Reset()         { *m = ConfigReloadState{} }
+func (m *ConfigReloadState) String() string { return proto.CompactTextString(m) }
+func (*ConfigReloadState) ProtoMessage()    {}
+func (*ConfigReloadState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{9}
+}
+
+func (m *ConfigReloadState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigReloadState.Unmarshal(m, b)
+}
+func (m *ConfigReloadState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigReloadState.Marshal(b, m, deterministic)
+}
+func (m *ConfigReloadState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigReloadState.Merge(m, src)
+}
+func (m *ConfigReloadState) XXX_Size() int {
+	return xxx_messageInfo_ConfigReloadState.Size(m)
+}
+func (m *ConfigReloadState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigReloadState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigReloadState proto.InternalMessageInfo
+
+func (m *ConfigReloadState) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
 type Event struct {
 	// Types that are valid to be assigned to EventType:
 	//	*Event_MetaEvent
 	//	*Event_BuildEvent
 	//	*Event_DeployEvent
 	//	*Event_PortEvent
+	//	*Event_DebuggingContainerEvent
+	//	*Event_TestEvent
+	//	*Event_FileSyncEvent
+	//	*Event_FileChangeEvent
+	//	*Event_CacheEvent
+	//	*Event_ConfigReloadEvent
 	EventType            isEvent_EventType `protobuf_oneof:"event_type"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -296,7 +518,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{6}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{10}
 }
 
 func (m *Event) XXX_Unmarshal(b []byte) error {
@@ -337,6 +559,30 @@ type Event_PortEvent struct {
 	PortEvent *PortEvent `protobuf:"bytes,4,opt,name=portEvent,proto3,oneof"`
 }
 
+type Event_DebuggingContainerEvent struct {
+	DebuggingContainerEvent *DebuggingContainerEvent `protobuf:"bytes,5,opt,name=debuggingContainerEvent,proto3,oneof"`
+}
+
+type Event_TestEvent struct {
+	TestEvent *TestEvent `protobuf:"bytes,6,opt,name=testEvent,proto3,oneof"`
+}
+
+type Event_FileSyncEvent struct {
+	FileSyncEvent *FileSyncEvent `protobuf:"bytes,7,opt,name=fileSyncEvent,proto3,oneof"`
+}
+
+type Event_FileChangeEvent struct {
+	FileChangeEvent *FileChangeEvent `protobuf:"bytes,8,opt,name=fileChangeEvent,proto3,oneof"`
+}
+
+type Event_CacheEvent struct {
+	CacheEvent *CacheEvent `protobuf:"bytes,9,opt,name=cacheEvent,proto3,oneof"`
+}
+
+type Event_ConfigReloadEvent struct {
+	ConfigReloadEvent *ConfigReloadEvent `protobuf:"bytes,10,opt,name=configReloadEvent,proto3,oneof"`
+}
+
 func (*Event_MetaEvent) isEvent_EventType() {}
 
 func (*Event_BuildEvent) isEvent_EventType() {}
@@ -345,6 +591,18 @@ func (*Event_DeployEvent) isEvent_EventType() {}
 
 func (*Event_PortEvent) isEvent_EventType() {}
 
+func (*Event_DebuggingContainerEvent) isEvent_EventType() {}
+
+func (*Event_TestEvent) isEvent_EventType() {}
+
+func (*Event_FileSyncEvent) isEvent_EventType() {}
+
+func (*Event_FileChangeEvent) isEvent_EventType() {}
+
+func (*Event_CacheEvent) isEvent_EventType() {}
+
+func (*Event_ConfigReloadEvent) isEvent_EventType() {}
+
 func (m *Event) GetEventType() isEvent_EventType {
 	if m != nil {
 		return m.EventType
@@ -380,6 +638,48 @@ func (m *Event) GetPortEvent() *PortEvent {
 	return nil
 }
 
+func (m *Event) GetDebuggingContainerEvent() *DebuggingContainerEvent {
+	if x, ok := m.GetEventType().(*Event_DebuggingContainerEvent); ok {
+		return x.DebuggingContainerEvent
+	}
+	return nil
+}
+
+func (m *Event) GetTestEvent() *TestEvent {
+	if x, ok := m.GetEventType().(*Event_TestEvent); ok {
+		return x.TestEvent
+	}
+	return nil
+}
+
+func (m *Event) GetFileSyncEvent() *FileSyncEvent {
+	if x, ok := m.GetEventType().(*Event_FileSyncEvent); ok {
+		return x.FileSyncEvent
+	}
+	return nil
+}
+
+func (m *Event) GetFileChangeEvent() *FileChangeEvent {
+	if x, ok := m.GetEventType().(*Event_FileChangeEvent); ok {
+		return x.FileChangeEvent
+	}
+	return nil
+}
+
+func (m *Event) GetCacheEvent() *CacheEvent {
+	if x, ok := m.GetEventType().(*Event_CacheEvent); ok {
+		return x.CacheEvent
+	}
+	return nil
+}
+
+func (m *Event) GetConfigReloadEvent() *ConfigReloadEvent {
+	if x, ok := m.GetEventType().(*Event_ConfigReloadEvent); ok {
+		return x.ConfigReloadEvent
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Event) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -387,6 +687,12 @@ func (*Event) XXX_OneofWrappers() []interface{} {
 		(*Event_BuildEvent)(nil),
 		(*Event_DeployEvent)(nil),
 		(*Event_PortEvent)(nil),
+		(*Event_DebuggingContainerEvent)(nil),
+		(*Event_TestEvent)(nil),
+		(*Event_FileSyncEvent)(nil),
+		(*Event_FileChangeEvent)(nil),
+		(*Event_CacheEvent)(nil),
+		(*Event_ConfigReloadEvent)(nil),
 	}
 }
 
@@ -401,7 +707,7 @@ func (m *MetaEvent) Reset()         { *m = MetaEvent{} }
 func (m *MetaEvent) String() string { return proto.CompactTextString(m) }
 func (*MetaEvent) ProtoMessage()    {}
 func (*MetaEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{7}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{11}
 }
 
 func (m *MetaEvent) XXX_Unmarshal(b []byte) error {
@@ -442,7 +748,7 @@ func (m *BuildEvent) Reset()         { *m = BuildEvent{} }
 func (m *BuildEvent) String() string { return proto.CompactTextString(m) }
 func (*BuildEvent) ProtoMessage()    {}
 func (*BuildEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{8}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{12}
 }
 
 func (m *BuildEvent) XXX_Unmarshal(b []byte) error {
@@ -496,7 +802,7 @@ func (m *DeployEvent) Reset()         { *m = DeployEvent{} }
 func (m *DeployEvent) String() string { return proto.CompactTextString(m) }
 func (*DeployEvent) ProtoMessage()    {}
 func (*DeployEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{9}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{13}
 }
 
 func (m *DeployEvent) XXX_Unmarshal(b []byte) error {
@@ -547,7 +853,7 @@ func (m *PortEvent) Reset()         { *m = PortEvent{} }
 func (m *PortEvent) String() string { return proto.CompactTextString(m) }
 func (*PortEvent) ProtoMessage()    {}
 func (*PortEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{10}
+	return fileDescriptor_4f2d38e344f9dbf5, []int{14}
 }
 
 func (m *PortEvent) XXX_Unmarshal(b []byte) error {
@@ -610,148 +916,890 @@ func (m *PortEvent) GetPortName() string {
 	return ""
 }
 
-type LogEntry struct {
-	Timestamp            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Event                *Event               `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
-	Entry                string               `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+// DebuggingContainerEvent describes a container configured for debugging when its pod starts or terminates.
+// localPort is the local port forwarded to the debug port, when known.
+type DebuggingContainerEvent struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PodName              string   `protobuf:"bytes,2,opt,name=podName,proto3" json:"podName,omitempty"`
+	ContainerName        string   `protobuf:"bytes,3,opt,name=containerName,proto3" json:"containerName,omitempty"`
+	Namespace            string   `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Runtime              string   `protobuf:"bytes,5,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	WorkingDir           string   `protobuf:"bytes,6,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	DebugPortName        string   `protobuf:"bytes,7,opt,name=debugPortName,proto3" json:"debugPortName,omitempty"`
+	DebugPort            int32    `protobuf:"varint,8,opt,name=debugPort,proto3" json:"debugPort,omitempty"`
+	LocalPort            int32    `protobuf:"varint,9,opt,name=localPort,proto3" json:"localPort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LogEntry) Reset()         { *m = LogEntry{} }
-func (m *LogEntry) String() string { return proto.CompactTextString(m) }
-func (*LogEntry) ProtoMessage()    {}
-func (*LogEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f2d38e344f9dbf5, []int{11}
+func (m *DebuggingContainerEvent) Reset()         { *m = DebuggingContainerEvent{} }
+func (m *DebuggingContainerEvent) String() string { return proto.CompactTextString(m) }
+func (*DebuggingContainerEvent) ProtoMessage()    {}
+func (*DebuggingContainerEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{15}
 }
 
-func (m *LogEntry) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+func (m *DebuggingContainerEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebuggingContainerEvent.Unmarshal(m, b)
 }
-func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+func (m *DebuggingContainerEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebuggingContainerEvent.Marshal(b, m, deterministic)
 }
-func (m *LogEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogEntry.Merge(m, src)
+func (m *DebuggingContainerEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebuggingContainerEvent.Merge(m, src)
 }
-func (m *LogEntry) XXX_Size() int {
-	return xxx_messageInfo_LogEntry.Size(m)
+func (m *DebuggingContainerEvent) XXX_Size() int {
+	return xxx_messageInfo_DebuggingContainerEvent.Size(m)
 }
-func (m *LogEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+func (m *DebuggingContainerEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebuggingContainerEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+var xxx_messageInfo_DebuggingContainerEvent proto.InternalMessageInfo
 
-func (m *LogEntry) GetTimestamp() *timestamp.Timestamp {
+func (m *DebuggingContainerEvent) GetStatus() string {
 	if m != nil {
-		return m.Timestamp
+		return m.Status
 	}
-	return nil
+	return ""
 }
 
-func (m *LogEntry) GetEvent() *Event {
+func (m *DebuggingContainerEvent) GetPodName() string {
 	if m != nil {
-		return m.Event
+		return m.PodName
 	}
-	return nil
+	return ""
 }
 
-func (m *LogEntry) GetEntry() string {
+func (m *DebuggingContainerEvent) GetContainerName() string {
 	if m != nil {
-		return m.Entry
+		return m.ContainerName
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*StateResponse)(nil), "proto.StateResponse")
-	proto.RegisterType((*Response)(nil), "proto.Response")
-	proto.RegisterType((*Request)(nil), "proto.Request")
-	proto.RegisterType((*State)(nil), "proto.State")
-	proto.RegisterMapType((map[string]*PortEvent)(nil), "proto.State.ForwardedPortsEntry")
-	proto.RegisterType((*BuildState)(nil), "proto.BuildState")
-	proto.RegisterMapType((map[string]string)(nil), "proto.BuildState.ArtifactsEntry")
-	proto.RegisterType((*DeployState)(nil), "proto.DeployState")
-	proto.RegisterType((*Event)(nil), "proto.Event")
-	proto.RegisterType((*MetaEvent)(nil), "proto.MetaEvent")
-	proto.RegisterType((*BuildEvent)(nil), "proto.BuildEvent")
-	proto.RegisterType((*DeployEvent)(nil), "proto.DeployEvent")
-	proto.RegisterType((*PortEvent)(nil), "proto.PortEvent")
-	proto.RegisterType((*LogEntry)(nil), "proto.LogEntry")
+func (m *DebuggingContainerEvent) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("skaffold.proto", fileDescriptor_4f2d38e344f9dbf5) }
+func (m *DebuggingContainerEvent) GetRuntime() string {
+	if m != nil {
+		return m.Runtime
+	}
+	return ""
+}
 
-var fileDescriptor_4f2d38e344f9dbf5 = []byte{
-	// 754 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0xfe, 0xd9, 0xa9, 0xd3, 0x78, 0xd2, 0xa6, 0xed, 0xfe, 0xaa, 0x2a, 0x32, 0x01, 0xca, 0x0a,
-	0x50, 0xd5, 0x43, 0xd2, 0x3f, 0x08, 0xaa, 0x0a, 0x21, 0x51, 0x5a, 0xe8, 0xa1, 0x45, 0xc8, 0xe1,
-	0x8e, 0xb6, 0xc9, 0x24, 0x44, 0x75, 0xbc, 0xc6, 0xde, 0x04, 0xe5, 0xc2, 0x81, 0x23, 0x17, 0x0e,
-	0x3c, 0x0d, 0x67, 0x1e, 0x81, 0x57, 0x40, 0x3c, 0x07, 0xda, 0x7f, 0xb1, 0xd3, 0x26, 0x27, 0x7b,
-	0x66, 0xbe, 0x6f, 0x76, 0x66, 0xbe, 0xdd, 0x81, 0x5a, 0x76, 0xcd, 0x7a, 0x3d, 0x1e, 0x75, 0x9b,
-	0x49, 0xca, 0x05, 0x27, 0x9e, 0xfa, 0x04, 0x8d, 0x3e, 0xe7, 0xfd, 0x08, 0x5b, 0x2c, 0x19, 0xb4,
-	0x58, 0x1c, 0x73, 0xc1, 0xc4, 0x80, 0xc7, 0x99, 0x06, 0x05, 0xf7, 0x4d, 0x54, 0x59, 0x57, 0xa3,
-	0x5e, 0x4b, 0x0c, 0x86, 0x98, 0x09, 0x36, 0x4c, 0x0c, 0xe0, 0xce, 0x4d, 0x00, 0x0e, 0x13, 0x31,
-	0xd1, 0x41, 0x7a, 0x08, 0xab, 0x6d, 0xc1, 0x04, 0x86, 0x98, 0x25, 0x3c, 0xce, 0x90, 0x50, 0xf0,
-	0x32, 0xe9, 0xa8, 0x3b, 0xdb, 0xce, 0x4e, 0xf5, 0x60, 0x45, 0xe3, 0x9a, 0x1a, 0xa4, 0x43, 0xb4,
-	0x01, 0x95, 0x29, 0x7e, 0x1d, 0x4a, 0xc3, 0xac, 0xaf, 0xd0, 0x7e, 0x28, 0x7f, 0xe9, 0x5d, 0x58,
-	0x0e, 0xf1, 0xd3, 0x08, 0x33, 0x41, 0x08, 0x2c, 0xc5, 0x6c, 0x88, 0x26, 0xaa, 0xfe, 0xe9, 0x77,
-	0x17, 0x3c, 0x95, 0x8d, 0xec, 0x03, 0x5c, 0x8d, 0x06, 0x51, 0xb7, 0x5d, 0x38, 0x6f, 0xc3, 0x9c,
-	0x77, 0x32, 0x0d, 0x84, 0x05, 0x10, 0x79, 0x02, 0xd5, 0x2e, 0x26, 0x11, 0x9f, 0x68, 0x8e, 0xab,
-	0x38, 0xc4, 0x70, 0x4e, 0xf3, 0x48, 0x58, 0x84, 0x91, 0x73, 0xa8, 0xf5, 0x78, 0xfa, 0x99, 0xa5,
-	0x5d, 0xec, 0xbe, 0xe3, 0xa9, 0xc8, 0xea, 0xa5, 0xed, 0xd2, 0x4e, 0xf5, 0x60, 0xbb, 0xd8, 0x5c,
-	0xf3, 0xf5, 0x0c, 0xe4, 0x2c, 0x16, 0xe9, 0x24, 0xbc, 0xc1, 0x0b, 0xda, 0xf0, 0xff, 0x1c, 0x98,
-	0x1c, 0xc2, 0x35, 0x4e, 0xec, 0x10, 0xae, 0x71, 0x42, 0x1e, 0x83, 0x37, 0x66, 0xd1, 0xc8, 0x96,
-	0xb8, 0x6e, 0x4e, 0x92, 0x9c, 0xb3, 0x31, 0xc6, 0x22, 0xd4, 0xe1, 0x63, 0xf7, 0xc8, 0xa1, 0xdf,
-	0x1c, 0x80, 0xbc, 0x5f, 0xf2, 0x02, 0x7c, 0x96, 0x8a, 0x41, 0x8f, 0x75, 0x44, 0x56, 0x77, 0x66,
-	0x0a, 0xcd, 0x51, 0xcd, 0x97, 0x16, 0xa2, 0x0b, 0xcd, 0x29, 0xc1, 0x73, 0xa8, 0xcd, 0x06, 0xe7,
-	0x94, 0xb7, 0x59, 0x2c, 0xcf, 0x2f, 0x16, 0xf3, 0x08, 0xaa, 0x85, 0x39, 0x92, 0x2d, 0x28, 0x4b,
-	0xcd, 0x47, 0x99, 0x61, 0x1b, 0x8b, 0xfe, 0x75, 0xc0, 0x53, 0x8d, 0x90, 0x3d, 0xf0, 0x87, 0x28,
-	0x98, 0x32, 0x8c, 0x88, 0xb6, 0xdb, 0x4b, 0xeb, 0x3f, 0xff, 0x2f, 0xcc, 0x41, 0xe4, 0xd0, 0xe8,
-	0xae, 0x29, 0xee, 0x6d, 0xdd, 0x2d, 0xa7, 0x00, 0x23, 0x4f, 0xad, 0xf2, 0x9a, 0x55, 0x9a, 0xa3,
-	0xbc, 0xa5, 0x15, 0x81, 0xb2, 0xbc, 0xc4, 0x0e, 0xbd, 0xbe, 0x34, 0x5f, 0x0c, 0x59, 0xde, 0x14,
-	0x74, 0xb2, 0x02, 0x80, 0xf2, 0xe7, 0x83, 0x98, 0x24, 0x48, 0x1f, 0x80, 0x3f, 0x6d, 0x43, 0x8e,
-	0x0d, 0xe5, 0x44, 0xcd, 0x30, 0xb4, 0x41, 0x43, 0x23, 0x9f, 0xc6, 0x04, 0x50, 0xb1, 0x5a, 0x18,
-	0xd8, 0xd4, 0x2e, 0x4c, 0xd3, 0x2d, 0x4e, 0x53, 0x0a, 0x84, 0x69, 0xaa, 0x9a, 0xf2, 0x43, 0xf9,
-	0x4b, 0x9f, 0x59, 0x19, 0x74, 0xd2, 0x05, 0x32, 0x58, 0xa2, 0x9b, 0x13, 0x7f, 0x39, 0xe0, 0x4f,
-	0x1b, 0x23, 0x0d, 0xf0, 0x23, 0xde, 0x61, 0x91, 0xf4, 0x28, 0xaa, 0x17, 0xe6, 0x0e, 0x72, 0x0f,
-	0x20, 0xc5, 0x21, 0x17, 0xa8, 0xc2, 0xae, 0x0a, 0x17, 0x3c, 0xa4, 0x0e, 0xcb, 0x09, 0xef, 0xbe,
-	0x95, 0x2f, 0x58, 0x97, 0x66, 0x4d, 0xf2, 0x10, 0x56, 0x3b, 0x3c, 0x16, 0x6c, 0x10, 0x63, 0xaa,
-	0xe2, 0x4b, 0x2a, 0x3e, 0xeb, 0x94, 0xa7, 0xcb, 0x27, 0x9f, 0x25, 0xac, 0x83, 0x75, 0x4f, 0x21,
-	0x72, 0x87, 0x1c, 0x94, 0x1c, 0xba, 0xa2, 0x97, 0xf5, 0xa0, 0xac, 0x4d, 0xbf, 0x40, 0xe5, 0x82,
-	0xf7, 0xf5, 0xed, 0x3d, 0x02, 0x7f, 0xba, 0xd2, 0xcc, 0x05, 0x0b, 0x9a, 0x7a, 0xa7, 0x35, 0xed,
-	0x4e, 0x6b, 0xbe, 0xb7, 0x88, 0x30, 0x07, 0xcb, 0x5d, 0x86, 0x85, 0x3b, 0x66, 0x77, 0x99, 0x79,
-	0x80, 0x38, 0x2b, 0x69, 0xa9, 0x20, 0xe9, 0xc1, 0x4f, 0x17, 0xd6, 0xda, 0x66, 0x19, 0xb7, 0x31,
-	0x1d, 0x0f, 0x3a, 0x48, 0x5e, 0x41, 0xe5, 0x0d, 0x0a, 0xf3, 0x2c, 0x6e, 0x15, 0x70, 0x26, 0x97,
-	0x6a, 0x30, 0xb3, 0x2e, 0xe9, 0xc6, 0xd7, 0xdf, 0x7f, 0x7e, 0xb8, 0x55, 0xe2, 0xb7, 0xc6, 0xfb,
-	0x2d, 0xb5, 0x3a, 0xc9, 0x29, 0x54, 0xd4, 0xf1, 0x17, 0xbc, 0x4f, 0xd6, 0x0c, 0xd8, 0x76, 0x1a,
-	0xdc, 0x74, 0x50, 0xa2, 0x12, 0xac, 0x10, 0x90, 0x09, 0x54, 0xbd, 0xd9, 0x8e, 0xb3, 0xe7, 0x90,
-	0x0b, 0x28, 0x9f, 0xb3, 0xb8, 0x1b, 0x21, 0x99, 0xe9, 0x29, 0x58, 0x50, 0x16, 0x6d, 0xa8, 0x3c,
-	0x5b, 0x74, 0x23, 0xcf, 0xd3, 0xfa, 0xa8, 0x12, 0x1c, 0x3b, 0xbb, 0xe4, 0x12, 0x3c, 0x75, 0x7f,
-	0x17, 0x76, 0xb5, 0x28, 0xed, 0xa6, 0x4a, 0x5b, 0xa3, 0xaa, 0x3f, 0xf5, 0x56, 0x8f, 0x9d, 0xdd,
-	0xab, 0xb2, 0x42, 0x1d, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xa9, 0x56, 0xe0, 0xb5, 0xce, 0x06,
-	0x00, 0x00,
+func (m *DebuggingContainerEvent) GetWorkingDir() string {
+	if m != nil {
+		return m.WorkingDir
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *DebuggingContainerEvent) GetDebugPortName() string {
+	if m != nil {
+		return m.DebugPortName
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *DebuggingContainerEvent) GetDebugPort() int32 {
+	if m != nil {
+		return m.DebugPort
+	}
+	return 0
+}
 
-// SkaffoldServiceClient is the client API for SkaffoldService service.
-//
+func (m *DebuggingContainerEvent) GetLocalPort() int32 {
+	if m != nil {
+		return m.LocalPort
+	}
+	return 0
+}
+
+// TestEvent describes the tests of an artifact.
+// durationMs is set once the tests have completed or failed.
+// iteration is the dev loop iteration, 0 being the first run.
+type TestEvent struct {
+	Artifact             string   `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Err                  string   `protobuf:"bytes,3,opt,name=err,proto3" json:"err,omitempty"`
+	DurationMs           int64    `protobuf:"varint,4,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
+	Iteration            int32    `protobuf:"varint,5,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestEvent) Reset()         { *m = TestEvent{} }
+func (m *TestEvent) String() string { return proto.CompactTextString(m) }
+func (*TestEvent) ProtoMessage()    {}
+func (*TestEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{16}
+}
+
+func (m *TestEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestEvent.Unmarshal(m, b)
+}
+func (m *TestEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestEvent.Marshal(b, m, deterministic)
+}
+func (m *TestEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestEvent.Merge(m, src)
+}
+func (m *TestEvent) XXX_Size() int {
+	return xxx_messageInfo_TestEvent.Size(m)
+}
+func (m *TestEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestEvent proto.InternalMessageInfo
+
+func (m *TestEvent) GetArtifact() string {
+	if m != nil {
+		return m.Artifact
+	}
+	return ""
+}
+
+func (m *TestEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TestEvent) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *TestEvent) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *TestEvent) GetIteration() int32 {
+	if m != nil {
+		return m.Iteration
+	}
+	return 0
+}
+
+// FileSyncEvent describes the copy of changed files into the running containers of an artifact.
+type FileSyncEvent struct {
+	Artifact             string   `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Err                  string   `protobuf:"bytes,3,opt,name=err,proto3" json:"err,omitempty"`
+	FileCount            int32    `protobuf:"varint,4,opt,name=fileCount,proto3" json:"fileCount,omitempty"`
+	DurationMs           int64    `protobuf:"varint,5,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
+	Iteration            int32    `protobuf:"varint,6,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileSyncEvent) Reset()         { *m = FileSyncEvent{} }
+func (m *FileSyncEvent) String() string { return proto.CompactTextString(m) }
+func (*FileSyncEvent) ProtoMessage()    {}
+func (*FileSyncEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{17}
+}
+
+func (m *FileSyncEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileSyncEvent.Unmarshal(m, b)
+}
+func (m *FileSyncEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileSyncEvent.Marshal(b, m, deterministic)
+}
+func (m *FileSyncEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileSyncEvent.Merge(m, src)
+}
+func (m *FileSyncEvent) XXX_Size() int {
+	return xxx_messageInfo_FileSyncEvent.Size(m)
+}
+func (m *FileSyncEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileSyncEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileSyncEvent proto.InternalMessageInfo
+
+func (m *FileSyncEvent) GetArtifact() string {
+	if m != nil {
+		return m.Artifact
+	}
+	return ""
+}
+
+func (m *FileSyncEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *FileSyncEvent) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *FileSyncEvent) GetFileCount() int32 {
+	if m != nil {
+		return m.FileCount
+	}
+	return 0
+}
+
+func (m *FileSyncEvent) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *FileSyncEvent) GetIteration() int32 {
+	if m != nil {
+		return m.Iteration
+	}
+	return 0
+}
+
+// FileChangeEvent lists the files changed for a watched component: an artifact,
+// or one of `test`, `deploy` and `config` for the test, deploy and skaffold configurations.
+// The changes are applied by the dev loop iteration that follows iteration.
+type FileChangeEvent struct {
+	Component            string   `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	Added                []string `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`
+	Modified             []string `protobuf:"bytes,3,rep,name=modified,proto3" json:"modified,omitempty"`
+	Deleted              []string `protobuf:"bytes,4,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	Iteration            int32    `protobuf:"varint,5,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileChangeEvent) Reset()         { *m = FileChangeEvent{} }
+func (m *FileChangeEvent) String() string { return proto.CompactTextString(m) }
+func (*FileChangeEvent) ProtoMessage()    {}
+func (*FileChangeEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{18}
+}
+
+func (m *FileChangeEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChangeEvent.Unmarshal(m, b)
+}
+func (m *FileChangeEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChangeEvent.Marshal(b, m, deterministic)
+}
+func (m *FileChangeEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChangeEvent.Merge(m, src)
+}
+func (m *FileChangeEvent) XXX_Size() int {
+	return xxx_messageInfo_FileChangeEvent.Size(m)
+}
+func (m *FileChangeEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChangeEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChangeEvent proto.InternalMessageInfo
+
+func (m *FileChangeEvent) GetComponent() string {
+	if m != nil {
+		return m.Component
+	}
+	return ""
+}
+
+func (m *FileChangeEvent) GetAdded() []string {
+	if m != nil {
+		return m.Added
+	}
+	return nil
+}
+
+func (m *FileChangeEvent) GetModified() []string {
+	if m != nil {
+		return m.Modified
+	}
+	return nil
+}
+
+func (m *FileChangeEvent) GetDeleted() []string {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
+func (m *FileChangeEvent) GetIteration() int32 {
+	if m != nil {
+		return m.Iteration
+	}
+	return 0
+}
+
+// CacheEvent describes the result of looking up an artifact in the cache, where status is `Hit` or `Miss`.
+type CacheEvent struct {
+	Artifact             string   `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Tag                  string   `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	DurationMs           int64    `protobuf:"varint,4,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
+	Iteration            int32    `protobuf:"varint,5,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CacheEvent) Reset()         { *m = CacheEvent{} }
+func (m *CacheEvent) String() string { return proto.CompactTextString(m) }
+func (*CacheEvent) ProtoMessage()    {}
+func (*CacheEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{19}
+}
+
+func (m *CacheEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheEvent.Unmarshal(m, b)
+}
+func (m *CacheEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheEvent.Marshal(b, m, deterministic)
+}
+func (m *CacheEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheEvent.Merge(m, src)
+}
+func (m *CacheEvent) XXX_Size() int {
+	return xxx_messageInfo_CacheEvent.Size(m)
+}
+func (m *CacheEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheEvent proto.InternalMessageInfo
+
+func (m *CacheEvent) GetArtifact() string {
+	if m != nil {
+		return m.Artifact
+	}
+	return ""
+}
+
+func (m *CacheEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CacheEvent) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *CacheEvent) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *CacheEvent) GetIteration() int32 {
+	if m != nil {
+		return m.Iteration
+	}
+	return 0
+}
+
+// ConfigReloadEvent describes the reload of the skaffold configuration after it changed.
+type ConfigReloadEvent struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Err                  string   `protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
+	DurationMs           int64    `protobuf:"varint,3,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
+	Iteration            int32    `protobuf:"varint,4,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigReloadEvent) Reset()         { *m = ConfigReloadEvent{} }
+func (m *ConfigReloadEvent) String() string { return proto.CompactTextString(m) }
+func (*ConfigReloadEvent) ProtoMessage()    {}
+func (*ConfigReloadEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{20}
+}
+
+func (m *ConfigReloadEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigReloadEvent.Unmarshal(m, b)
+}
+func (m *ConfigReloadEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigReloadEvent.Marshal(b, m, deterministic)
+}
+func (m *ConfigReloadEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigReloadEvent.Merge(m, src)
+}
+func (m *ConfigReloadEvent) XXX_Size() int {
+	return xxx_messageInfo_ConfigReloadEvent.Size(m)
+}
+func (m *ConfigReloadEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigReloadEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigReloadEvent proto.InternalMessageInfo
+
+func (m *ConfigReloadEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ConfigReloadEvent) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *ConfigReloadEvent) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *ConfigReloadEvent) GetIteration() int32 {
+	if m != nil {
+		return m.Iteration
+	}
+	return 0
+}
+
+// TriggerRequest enables or disables the automatic trigger of a phase of the dev loop.
+type TriggerRequest struct {
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerRequest) Reset()         { *m = TriggerRequest{} }
+func (m *TriggerRequest) String() string { return proto.CompactTextString(m) }
+func (*TriggerRequest) ProtoMessage()    {}
+func (*TriggerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{21}
+}
+
+func (m *TriggerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerRequest.Unmarshal(m, b)
+}
+func (m *TriggerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerRequest.Marshal(b, m, deterministic)
+}
+func (m *TriggerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerRequest.Merge(m, src)
+}
+func (m *TriggerRequest) XXX_Size() int {
+	return xxx_messageInfo_TriggerRequest.Size(m)
+}
+func (m *TriggerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerRequest proto.InternalMessageInfo
+
+func (m *TriggerRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+// Intent describes the phases of the dev loop that should be run once.
+// When build is set, only the listed artifacts are rebuilt, or every
+// artifact with pending changes if none is listed.
+type Intent struct {
+	Build                bool     `protobuf:"varint,1,opt,name=build,proto3" json:"build,omitempty"`
+	Sync                 bool     `protobuf:"varint,2,opt,name=sync,proto3" json:"sync,omitempty"`
+	Deploy               bool     `protobuf:"varint,3,opt,name=deploy,proto3" json:"deploy,omitempty"`
+	Artifacts            []string `protobuf:"bytes,4,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Intent) Reset()         { *m = Intent{} }
+func (m *Intent) String() string { return proto.CompactTextString(m) }
+func (*Intent) ProtoMessage()    {}
+func (*Intent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{22}
+}
+
+func (m *Intent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Intent.Unmarshal(m, b)
+}
+func (m *Intent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Intent.Marshal(b, m, deterministic)
+}
+func (m *Intent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Intent.Merge(m, src)
+}
+func (m *Intent) XXX_Size() int {
+	return xxx_messageInfo_Intent.Size(m)
+}
+func (m *Intent) XXX_DiscardUnknown() {
+	xxx_messageInfo_Intent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Intent proto.InternalMessageInfo
+
+func (m *Intent) GetBuild() bool {
+	if m != nil {
+		return m.Build
+	}
+	return false
+}
+
+func (m *Intent) GetSync() bool {
+	if m != nil {
+		return m.Sync
+	}
+	return false
+}
+
+func (m *Intent) GetDeploy() bool {
+	if m != nil {
+		return m.Deploy
+	}
+	return false
+}
+
+func (m *Intent) GetArtifacts() []string {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+type UserIntentRequest struct {
+	Intent               *Intent  `protobuf:"bytes,1,opt,name=intent,proto3" json:"intent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserIntentRequest) Reset()         { *m = UserIntentRequest{} }
+func (m *UserIntentRequest) String() string { return proto.CompactTextString(m) }
+func (*UserIntentRequest) ProtoMessage()    {}
+func (*UserIntentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{23}
+}
+
+func (m *UserIntentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserIntentRequest.Unmarshal(m, b)
+}
+func (m *UserIntentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserIntentRequest.Marshal(b, m, deterministic)
+}
+func (m *UserIntentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserIntentRequest.Merge(m, src)
+}
+func (m *UserIntentRequest) XXX_Size() int {
+	return xxx_messageInfo_UserIntentRequest.Size(m)
+}
+func (m *UserIntentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserIntentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserIntentRequest proto.InternalMessageInfo
+
+func (m *UserIntentRequest) GetIntent() *Intent {
+	if m != nil {
+		return m.Intent
+	}
+	return nil
+}
+
+// LogFilterRequest replaces the filters that decide which containers have their logs printed.
+// Each filter is a regular expression, optionally prefixed with `image=`, `pod=` or `container=`.
+type LogFilterRequest struct {
+	Include              []string `protobuf:"bytes,1,rep,name=include,proto3" json:"include,omitempty"`
+	Exclude              []string `protobuf:"bytes,2,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogFilterRequest) Reset()         { *m = LogFilterRequest{} }
+func (m *LogFilterRequest) String() string { return proto.CompactTextString(m) }
+func (*LogFilterRequest) ProtoMessage()    {}
+func (*LogFilterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{24}
+}
+
+func (m *LogFilterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogFilterRequest.Unmarshal(m, b)
+}
+func (m *LogFilterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogFilterRequest.Marshal(b, m, deterministic)
+}
+func (m *LogFilterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogFilterRequest.Merge(m, src)
+}
+func (m *LogFilterRequest) XXX_Size() int {
+	return xxx_messageInfo_LogFilterRequest.Size(m)
+}
+func (m *LogFilterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogFilterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogFilterRequest proto.InternalMessageInfo
+
+func (m *LogFilterRequest) GetInclude() []string {
+	if m != nil {
+		return m.Include
+	}
+	return nil
+}
+
+func (m *LogFilterRequest) GetExclude() []string {
+	if m != nil {
+		return m.Exclude
+	}
+	return nil
+}
+
+type LogEntry struct {
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Event                *Event               `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Entry                string               `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f2d38e344f9dbf5, []int{25}
+}
+
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (m *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(m, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *LogEntry) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *LogEntry) GetEntry() string {
+	if m != nil {
+		return m.Entry
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*StateResponse)(nil), "proto.StateResponse")
+	proto.RegisterType((*Response)(nil), "proto.Response")
+	proto.RegisterType((*Request)(nil), "proto.Request")
+	proto.RegisterType((*State)(nil), "proto.State")
+	proto.RegisterMapType((map[string]*DebuggingContainerEvent)(nil), "proto.State.DebuggingContainersEntry")
+	proto.RegisterMapType((map[string]*FileChangeEvent)(nil), "proto.State.FileChangesEntry")
+	proto.RegisterMapType((map[string]*PortEvent)(nil), "proto.State.ForwardedPortsEntry")
+	proto.RegisterType((*BuildState)(nil), "proto.BuildState")
+	proto.RegisterMapType((map[string]string)(nil), "proto.BuildState.ArtifactsEntry")
+	proto.RegisterType((*DeployState)(nil), "proto.DeployState")
+	proto.RegisterType((*TestState)(nil), "proto.TestState")
+	proto.RegisterMapType((map[string]string)(nil), "proto.TestState.ArtifactsEntry")
+	proto.RegisterType((*FileSyncState)(nil), "proto.FileSyncState")
+	proto.RegisterMapType((map[string]string)(nil), "proto.FileSyncState.ArtifactsEntry")
+	proto.RegisterType((*CacheState)(nil), "proto.CacheState")
+	proto.RegisterMapType((map[string]string)(nil), "proto.CacheState.ArtifactsEntry")
+	proto.RegisterType((*ConfigReloadState)(nil), "proto.ConfigReloadState")
+	proto.RegisterType((*Event)(nil), "proto.Event")
+	proto.RegisterType((*MetaEvent)(nil), "proto.MetaEvent")
+	proto.RegisterType((*BuildEvent)(nil), "proto.BuildEvent")
+	proto.RegisterType((*DeployEvent)(nil), "proto.DeployEvent")
+	proto.RegisterType((*PortEvent)(nil), "proto.PortEvent")
+	proto.RegisterType((*DebuggingContainerEvent)(nil), "proto.DebuggingContainerEvent")
+	proto.RegisterType((*TestEvent)(nil), "proto.TestEvent")
+	proto.RegisterType((*FileSyncEvent)(nil), "proto.FileSyncEvent")
+	proto.RegisterType((*FileChangeEvent)(nil), "proto.FileChangeEvent")
+	proto.RegisterType((*CacheEvent)(nil), "proto.CacheEvent")
+	proto.RegisterType((*ConfigReloadEvent)(nil), "proto.ConfigReloadEvent")
+	proto.RegisterType((*TriggerRequest)(nil), "proto.TriggerRequest")
+	proto.RegisterType((*Intent)(nil), "proto.Intent")
+	proto.RegisterType((*UserIntentRequest)(nil), "proto.UserIntentRequest")
+	proto.RegisterType((*LogFilterRequest)(nil), "proto.LogFilterRequest")
+	proto.RegisterType((*LogEntry)(nil), "proto.LogEntry")
+}
+
+func init() { proto.RegisterFile("skaffold.proto", fileDescriptor_4f2d38e344f9dbf5) }
+
+var fileDescriptor_4f2d38e344f9dbf5 = []byte{
+	// 1611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcb, 0x6e, 0x1c, 0x45,
+	0x17, 0xce, 0xcc, 0x78, 0x2e, 0x7d, 0x7c, 0xaf, 0x38, 0x76, 0xab, 0xe3, 0x24, 0x4e, 0xff, 0x7f,
+	0x7e, 0x59, 0xfe, 0xd1, 0x4c, 0x6e, 0x82, 0xc8, 0x0a, 0xa0, 0xc4, 0x17, 0x1c, 0xc9, 0x81, 0xa8,
+	0x26, 0x80, 0x04, 0x42, 0x51, 0x7b, 0xba, 0xa6, 0xdd, 0xf2, 0x4c, 0xd7, 0xd0, 0x5d, 0xed, 0x64,
+	0x84, 0xc4, 0x82, 0x15, 0x62, 0x43, 0x24, 0x56, 0xbc, 0x01, 0x12, 0x2b, 0x9e, 0x81, 0x47, 0xe0,
+	0x15, 0x78, 0x05, 0x76, 0x2c, 0x50, 0xdd, 0xfa, 0x3a, 0xe3, 0x60, 0x25, 0x59, 0x4d, 0xd7, 0xb9,
+	0x7c, 0xe7, 0x9c, 0xaa, 0x73, 0xa9, 0x1a, 0x58, 0x88, 0x4e, 0x9c, 0x7e, 0x9f, 0x0e, 0xdc, 0xf6,
+	0x28, 0xa4, 0x8c, 0xa2, 0xba, 0xf8, 0xb1, 0xd6, 0x3d, 0x4a, 0xbd, 0x01, 0xe9, 0x38, 0x23, 0xbf,
+	0xe3, 0x04, 0x01, 0x65, 0x0e, 0xf3, 0x69, 0x10, 0x49, 0x21, 0xeb, 0x9a, 0xe2, 0x8a, 0xd5, 0x51,
+	0xdc, 0xef, 0x30, 0x7f, 0x48, 0x22, 0xe6, 0x0c, 0x47, 0x4a, 0xe0, 0x72, 0x51, 0x80, 0x0c, 0x47,
+	0x6c, 0x2c, 0x99, 0xf6, 0x1d, 0x98, 0xef, 0x32, 0x87, 0x11, 0x4c, 0xa2, 0x11, 0x0d, 0x22, 0x82,
+	0x6c, 0xa8, 0x47, 0x9c, 0x60, 0x56, 0x36, 0x2a, 0x9b, 0xb3, 0xb7, 0xe7, 0xa4, 0x5c, 0x5b, 0x0a,
+	0x49, 0x96, 0xbd, 0x0e, 0xad, 0x44, 0x7e, 0x09, 0x6a, 0xc3, 0xc8, 0x13, 0xd2, 0x06, 0xe6, 0x9f,
+	0xf6, 0x15, 0x68, 0x62, 0xf2, 0x75, 0x4c, 0x22, 0x86, 0x10, 0xcc, 0x04, 0xce, 0x90, 0x28, 0xae,
+	0xf8, 0xb6, 0xff, 0x6e, 0x40, 0x5d, 0xa0, 0xa1, 0x5b, 0x00, 0x47, 0xb1, 0x3f, 0x70, 0xbb, 0x19,
+	0x7b, 0xcb, 0xca, 0xde, 0xc3, 0x84, 0x81, 0x33, 0x42, 0xe8, 0x2e, 0xcc, 0xba, 0x64, 0x34, 0xa0,
+	0x63, 0xa9, 0x53, 0x15, 0x3a, 0x48, 0xe9, 0xec, 0xa6, 0x1c, 0x9c, 0x15, 0x43, 0x07, 0xb0, 0xd0,
+	0xa7, 0xe1, 0x73, 0x27, 0x74, 0x89, 0xfb, 0x84, 0x86, 0x2c, 0x32, 0x6b, 0x1b, 0xb5, 0xcd, 0xd9,
+	0xdb, 0x1b, 0xd9, 0xe0, 0xda, 0xfb, 0x39, 0x91, 0xbd, 0x80, 0x85, 0x63, 0x5c, 0xd0, 0x43, 0x9f,
+	0xc3, 0x45, 0x97, 0x1c, 0xc5, 0x9e, 0xe7, 0x07, 0xde, 0x0e, 0x0d, 0x98, 0xe3, 0x07, 0x24, 0x8c,
+	0xcc, 0x19, 0x01, 0x77, 0x23, 0x07, 0xb7, 0x5b, 0x96, 0x93, 0x98, 0x93, 0x10, 0x50, 0x1b, 0x0c,
+	0x46, 0x22, 0x26, 0xc3, 0xaa, 0x8b, 0xb0, 0x96, 0x14, 0xdc, 0x53, 0x4d, 0xc7, 0xa9, 0x08, 0xda,
+	0x86, 0xf9, 0xbe, 0x3f, 0x20, 0xdd, 0x71, 0xd0, 0x93, 0x3a, 0x0d, 0xa1, 0xb3, 0xa2, 0x74, 0xf6,
+	0xb3, 0x3c, 0x9c, 0x17, 0xe5, 0xfb, 0xde, 0x73, 0x7a, 0xc7, 0x44, 0x2a, 0x36, 0x73, 0xfb, 0xbe,
+	0x93, 0x30, 0x70, 0x46, 0x08, 0xed, 0xc3, 0x72, 0x8f, 0x06, 0x7d, 0xdf, 0xc3, 0x64, 0x40, 0x1d,
+	0x75, 0x62, 0x2d, 0xa1, 0x69, 0x6a, 0xcd, 0x22, 0x1f, 0x97, 0x55, 0xd0, 0x87, 0x30, 0xcb, 0x7d,
+	0xd9, 0x39, 0x76, 0x02, 0x8f, 0x44, 0xa6, 0x21, 0xf6, 0xed, 0x4a, 0xfe, 0x18, 0x52, 0xbe, 0xdc,
+	0xaf, 0xac, 0x06, 0xda, 0x82, 0x25, 0x97, 0x9c, 0x1e, 0x52, 0x3a, 0x7a, 0xc4, 0x48, 0x28, 0x0a,
+	0xc1, 0x84, 0x8d, 0xca, 0x66, 0x1d, 0x97, 0xe8, 0x56, 0x17, 0x2e, 0x4e, 0x38, 0x53, 0x9e, 0xb1,
+	0x27, 0x64, 0xac, 0x33, 0xf6, 0x84, 0x8c, 0xd1, 0xff, 0xa0, 0x7e, 0xea, 0x0c, 0x62, 0x9d, 0x4f,
+	0x7a, 0xe3, 0xb9, 0xce, 0xde, 0x29, 0x09, 0x18, 0x96, 0xec, 0xed, 0xea, 0xbd, 0x8a, 0xd5, 0x07,
+	0x73, 0xda, 0xc9, 0x4e, 0x40, 0xbe, 0x9b, 0x47, 0xbe, 0x9a, 0x64, 0x6a, 0x11, 0xa1, 0x64, 0xe7,
+	0x33, 0x58, 0x2a, 0xee, 0xc4, 0x04, 0xfc, 0x77, 0xf2, 0xf8, 0xab, 0x99, 0xe3, 0x97, 0x9a, 0x45,
+	0x5c, 0xfb, 0x87, 0x0a, 0x40, 0x5a, 0x5c, 0xe8, 0x03, 0x30, 0x9c, 0x90, 0xf9, 0x7d, 0xa7, 0xc7,
+	0x22, 0xb3, 0x92, 0xab, 0x8a, 0x54, 0xaa, 0xfd, 0x40, 0x8b, 0xc8, 0x13, 0x49, 0x55, 0xac, 0xfb,
+	0xb0, 0x90, 0x67, 0x4e, 0x70, 0x72, 0x25, 0xeb, 0xa4, 0x91, 0x75, 0xe6, 0x06, 0xcc, 0x66, 0x8a,
+	0x16, 0xad, 0x42, 0x23, 0x62, 0x0e, 0x8b, 0x23, 0xa5, 0xad, 0x56, 0xf6, 0xf7, 0x15, 0x30, 0x92,
+	0x2a, 0x40, 0xef, 0x97, 0x5d, 0xbe, 0x56, 0x2c, 0x95, 0xb7, 0xe6, 0xf1, 0xcb, 0x0a, 0xcc, 0xe7,
+	0x8a, 0x0b, 0x3d, 0x28, 0xbb, 0xf3, 0x9f, 0x49, 0x55, 0xf8, 0xd6, 0x5c, 0xe2, 0x27, 0x9a, 0x96,
+	0xed, 0x59, 0x27, 0x9a, 0x4a, 0xbd, 0x35, 0x67, 0xfe, 0x0f, 0xcb, 0xa5, 0x46, 0x30, 0xf5, 0x5c,
+	0xff, 0x9a, 0x81, 0xba, 0x48, 0x50, 0x74, 0x13, 0x8c, 0x21, 0x61, 0x8e, 0x58, 0x98, 0x95, 0x5c,
+	0x15, 0x3e, 0xd6, 0xf4, 0x83, 0x0b, 0x38, 0x15, 0x42, 0x77, 0xd4, 0xf0, 0x90, 0x2a, 0xd5, 0xf2,
+	0xf0, 0xd0, 0x3a, 0x19, 0x31, 0xf4, 0xae, 0x1e, 0x1f, 0x52, 0xab, 0x36, 0x61, 0x7c, 0x68, 0xb5,
+	0xac, 0x20, 0x77, 0x6f, 0xa4, 0x9b, 0x81, 0x39, 0x33, 0xb9, 0x49, 0x70, 0xf7, 0x12, 0x21, 0xf4,
+	0x05, 0xac, 0xb9, 0x93, 0x8b, 0xdc, 0xac, 0xff, 0x9b, 0x56, 0x70, 0x70, 0x01, 0x4f, 0x03, 0xe0,
+	0xde, 0x30, 0x12, 0x29, 0x6f, 0x1a, 0xa5, 0x59, 0x91, 0x78, 0x93, 0x08, 0xa1, 0xfb, 0xe9, 0xb4,
+	0x90, 0x5a, 0xcd, 0x89, 0xd3, 0x42, 0x6b, 0xe6, 0x85, 0xd1, 0x43, 0x58, 0xec, 0xe7, 0x1b, 0x8a,
+	0xd9, 0x3a, 0xab, 0xdd, 0x1c, 0x5c, 0xc0, 0x45, 0x05, 0x7e, 0x5c, 0x62, 0x9c, 0x48, 0x75, 0xa3,
+	0x3c, 0x73, 0x92, 0xe3, 0x4a, 0xc5, 0xd0, 0x41, 0x7e, 0xea, 0x48, 0x5d, 0x98, 0x3a, 0x75, 0x34,
+	0x44, 0x59, 0xe9, 0xe1, 0x1c, 0x00, 0xe1, 0x1f, 0xcf, 0xd8, 0x78, 0x44, 0xec, 0xeb, 0x60, 0x24,
+	0x59, 0xc5, 0x73, 0x99, 0xf0, 0x34, 0x57, 0xb9, 0x29, 0x17, 0x36, 0x56, 0x5d, 0x52, 0xca, 0x58,
+	0xd0, 0xd2, 0x05, 0xa2, 0xc4, 0x92, 0x75, 0x26, 0xb9, 0xab, 0xd9, 0xe4, 0xe6, 0x55, 0x43, 0xc2,
+	0x50, 0xe4, 0x98, 0x81, 0xf9, 0xa7, 0xfd, 0x9e, 0xee, 0x76, 0x12, 0x74, 0x4a, 0x55, 0x68, 0xc5,
+	0x6a, 0xaa, 0xf8, 0x7b, 0x05, 0x8c, 0x24, 0xcf, 0xd0, 0x3a, 0x18, 0x03, 0xda, 0x73, 0x06, 0x9c,
+	0x22, 0x54, 0xeb, 0x38, 0x25, 0xa0, 0xab, 0x00, 0x21, 0x19, 0x52, 0x46, 0x04, 0xbb, 0x2a, 0xd8,
+	0x19, 0x0a, 0x32, 0xa1, 0x39, 0xa2, 0xee, 0xc7, 0xfc, 0x56, 0x26, 0x5d, 0xd3, 0x4b, 0xf4, 0x5f,
+	0x98, 0xef, 0xe9, 0x44, 0x13, 0xfc, 0x19, 0xc1, 0xcf, 0x13, 0xb9, 0x75, 0x7e, 0x8d, 0x8b, 0x46,
+	0x4e, 0x4f, 0x5e, 0x54, 0x0c, 0x9c, 0x12, 0xf8, 0x46, 0xf1, 0x1a, 0x10, 0xea, 0x0d, 0xb9, 0x51,
+	0x7a, 0x6d, 0xff, 0x5a, 0x85, 0xb5, 0x29, 0xd9, 0x3e, 0x75, 0x2f, 0x32, 0xde, 0x56, 0x5f, 0xe1,
+	0x6d, 0xed, 0x95, 0xde, 0xce, 0x14, 0xbd, 0x35, 0xa1, 0x19, 0xc6, 0x01, 0xbf, 0x2f, 0xab, 0x48,
+	0xf4, 0x92, 0xef, 0xe2, 0x73, 0x1a, 0x9e, 0xf8, 0x81, 0xb7, 0xeb, 0x87, 0x2a, 0x92, 0x0c, 0x85,
+	0x5b, 0x17, 0xd5, 0xf9, 0x44, 0x07, 0xdb, 0x94, 0xd6, 0x73, 0x44, 0x6e, 0x3d, 0x21, 0x88, 0x92,
+	0xa9, 0xe3, 0x94, 0x90, 0x3f, 0x47, 0xa3, 0x70, 0x8e, 0xf6, 0x8f, 0x6a, 0xe6, 0xbd, 0xc1, 0x04,
+	0xe4, 0x51, 0xb9, 0xb1, 0xbc, 0x1c, 0x3d, 0x8e, 0xc4, 0x76, 0xd4, 0x70, 0x86, 0xc2, 0x3d, 0xf2,
+	0x93, 0x5b, 0x55, 0x5d, 0x7a, 0x94, 0x10, 0xec, 0xdf, 0x32, 0xa3, 0xef, 0x4d, 0x7a, 0xb5, 0x0e,
+	0x86, 0xe8, 0x16, 0x34, 0x56, 0xcd, 0xb5, 0x8e, 0x53, 0x42, 0xc1, 0xe7, 0xfa, 0xd9, 0x3e, 0x37,
+	0x8a, 0x3e, 0xff, 0x5c, 0x81, 0xc5, 0x42, 0x77, 0xe2, 0x1a, 0x3d, 0x3a, 0x1c, 0xd1, 0x40, 0xcf,
+	0x1a, 0x03, 0xa7, 0x04, 0xde, 0x0e, 0x1c, 0xd7, 0x25, 0xae, 0x59, 0xdd, 0xa8, 0xf1, 0x76, 0x20,
+	0x16, 0x3c, 0xd2, 0x21, 0x75, 0xfd, 0xbe, 0x4f, 0x5c, 0xf1, 0x76, 0x30, 0x70, 0xb2, 0xe6, 0x59,
+	0xe4, 0x92, 0x01, 0x61, 0xc4, 0x15, 0xef, 0x00, 0x03, 0xeb, 0xe5, 0x2b, 0xf6, 0xf3, 0xa5, 0x9e,
+	0xdb, 0xaf, 0xb5, 0x99, 0xcc, 0xf1, 0xf4, 0x66, 0x32, 0xc7, 0x7b, 0xcd, 0x23, 0xfe, 0x26, 0x3f,
+	0xbd, 0xcf, 0xd9, 0xa7, 0x0a, 0xc6, 0x6b, 0x67, 0x1b, 0x9f, 0x29, 0x1a, 0xdf, 0x82, 0x85, 0xa7,
+	0xa1, 0xef, 0x79, 0x24, 0xd4, 0xcf, 0x47, 0x13, 0x9a, 0x24, 0x70, 0x8e, 0x06, 0xc4, 0x15, 0xa6,
+	0x5b, 0x58, 0x2f, 0xed, 0x63, 0x68, 0x3c, 0x0a, 0x98, 0x3a, 0x2f, 0x31, 0xe0, 0x95, 0x84, 0x5c,
+	0xf0, 0x87, 0x67, 0x34, 0x0e, 0x7a, 0xc2, 0xb9, 0x16, 0x16, 0xdf, 0x3c, 0x0e, 0x39, 0xd3, 0x85,
+	0x67, 0x2d, 0xac, 0x56, 0xdc, 0xab, 0xf4, 0xc2, 0x24, 0x4f, 0x30, 0x25, 0xd8, 0xdb, 0xb0, 0xfc,
+	0x69, 0x44, 0x42, 0x69, 0x4d, 0x3b, 0x76, 0x03, 0x1a, 0xbe, 0x20, 0xa8, 0xbb, 0xca, 0xbc, 0x9a,
+	0x46, 0x4a, 0x4a, 0x31, 0xed, 0x7d, 0x58, 0x3a, 0xa4, 0xde, 0xbe, 0x3f, 0x60, 0xb9, 0x98, 0xfc,
+	0xa0, 0x37, 0x88, 0x5d, 0x22, 0x2e, 0x67, 0x06, 0xd6, 0x4b, 0x11, 0xed, 0x0b, 0xc9, 0x91, 0xb9,
+	0xa7, 0x97, 0xf6, 0xb7, 0xd0, 0x3a, 0xa4, 0x9e, 0xbc, 0x8c, 0xdd, 0x03, 0x23, 0x79, 0xe0, 0x2b,
+	0xeb, 0x56, 0x5b, 0xbe, 0xf0, 0xdb, 0xfa, 0x85, 0xdf, 0x7e, 0xaa, 0x25, 0x70, 0x2a, 0xcc, 0x5f,
+	0xf6, 0x24, 0x73, 0x59, 0xd2, 0x2f, 0x7b, 0xf5, 0x42, 0x20, 0xf9, 0x61, 0x58, 0xcb, 0x0c, 0xc3,
+	0xdb, 0xbf, 0x34, 0x61, 0xb1, 0xab, 0xfe, 0x9a, 0xe8, 0x92, 0xf0, 0xd4, 0xef, 0x11, 0xb4, 0x03,
+	0xad, 0x8f, 0x88, 0xba, 0x91, 0xaf, 0x96, 0x1c, 0xd8, 0xe3, 0x7f, 0x31, 0x58, 0xb9, 0x3f, 0x0f,
+	0xec, 0xe5, 0xef, 0xfe, 0xf8, 0xf3, 0xa7, 0xea, 0x2c, 0x32, 0x3a, 0xa7, 0xb7, 0x3a, 0xe2, 0x8f,
+	0x04, 0xb4, 0x0b, 0x2d, 0x61, 0xfe, 0x90, 0x7a, 0x68, 0x51, 0x09, 0xeb, 0x48, 0xad, 0x22, 0xc1,
+	0x46, 0x02, 0x60, 0x0e, 0x01, 0x07, 0x10, 0xfe, 0x46, 0x9b, 0x95, 0x9b, 0x15, 0x74, 0x08, 0x8d,
+	0x03, 0x27, 0x70, 0x07, 0x04, 0xe5, 0x62, 0xb2, 0xa6, 0xb8, 0x65, 0xaf, 0x0b, 0x9c, 0x55, 0x7b,
+	0x39, 0xc5, 0xe9, 0x1c, 0x0b, 0x80, 0xed, 0xca, 0x16, 0x7a, 0x0c, 0x75, 0x31, 0xf9, 0xa7, 0x46,
+	0x35, 0x0d, 0x76, 0x45, 0xc0, 0x2e, 0xd8, 0x22, 0x3e, 0x91, 0x86, 0x0a, 0xee, 0x89, 0x13, 0x47,
+	0xe4, 0xf5, 0xe0, 0x46, 0x1c, 0x82, 0xc3, 0x7d, 0x02, 0x0d, 0x4c, 0xa2, 0x78, 0x78, 0x7e, 0xbc,
+	0x4b, 0x02, 0x6f, 0xd1, 0x16, 0xbb, 0x17, 0x0a, 0x0c, 0x0e, 0xf8, 0x15, 0x18, 0x0f, 0x62, 0x46,
+	0x65, 0xc8, 0x97, 0xf4, 0x35, 0x32, 0x57, 0x87, 0x53, 0x21, 0xaf, 0x0b, 0xc8, 0xcb, 0xd6, 0x6a,
+	0x12, 0x71, 0xc7, 0x89, 0x19, 0x7d, 0xc6, 0xa4, 0x3a, 0x87, 0xff, 0x12, 0x5a, 0x1c, 0x9e, 0xcf,
+	0x8c, 0xf3, 0xa2, 0x6f, 0x08, 0x74, 0xcb, 0xba, 0x24, 0xf2, 0x65, 0x1c, 0xf4, 0x4a, 0xe0, 0xcf,
+	0x00, 0x38, 0xb8, 0xbc, 0x54, 0x9d, 0x17, 0xde, 0x16, 0xf0, 0xeb, 0xd6, 0x1a, 0x87, 0x97, 0xad,
+	0xa0, 0x64, 0xa0, 0x0b, 0xcd, 0xbd, 0x17, 0xa4, 0x17, 0x33, 0x82, 0xf4, 0x85, 0xb3, 0xd4, 0x0c,
+	0xa6, 0x1a, 0x58, 0x15, 0x06, 0x96, 0xec, 0x59, 0x91, 0x66, 0x12, 0x46, 0x6e, 0xc9, 0x5c, 0x97,
+	0xb0, 0xa4, 0x31, 0xa0, 0xb5, 0x34, 0xcf, 0x73, 0xad, 0x62, 0x2a, 0xb0, 0x25, 0x80, 0x57, 0xac,
+	0x45, 0x0e, 0x3c, 0xa0, 0x5e, 0xd4, 0xe9, 0x0b, 0xbd, 0xed, 0xca, 0xd6, 0x51, 0x43, 0xc8, 0xde,
+	0xf9, 0x67, 0x00, 0x79, 0x36, 0xb2, 0xe3, 0x4b, 0x14, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SkaffoldServiceClient is the client API for SkaffoldService service.
+//
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type SkaffoldServiceClient interface {
 	GetState(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*State, error)
 	EventLog(ctx context.Context, opts ...grpc.CallOption) (SkaffoldService_EventLogClient, error)
 	Handle(ctx context.Context, in *Event, opts ...grpc.CallOption) (*empty.Empty, error)
 	Build(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	Pause(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	Resume(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	AutoBuild(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	AutoSync(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	AutoDeploy(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Execute(ctx context.Context, in *UserIntentRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetLogFilter(ctx context.Context, in *LogFilterRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type skaffoldServiceClient struct {
@@ -820,12 +1868,82 @@ func (c *skaffoldServiceClient) Build(ctx context.Context, in *empty.Empty, opts
 	return out, nil
 }
 
+func (c *skaffoldServiceClient) Pause(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.SkaffoldService/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skaffoldServiceClient) Resume(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.SkaffoldService/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skaffoldServiceClient) AutoBuild(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.SkaffoldService/AutoBuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skaffoldServiceClient) AutoSync(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.SkaffoldService/AutoSync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skaffoldServiceClient) AutoDeploy(ctx context.Context, in *TriggerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.SkaffoldService/AutoDeploy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skaffoldServiceClient) Execute(ctx context.Context, in *UserIntentRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.SkaffoldService/Execute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skaffoldServiceClient) SetLogFilter(ctx context.Context, in *LogFilterRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.SkaffoldService/SetLogFilter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SkaffoldServiceServer is the server API for SkaffoldService service.
 type SkaffoldServiceServer interface {
 	GetState(context.Context, *empty.Empty) (*State, error)
 	EventLog(SkaffoldService_EventLogServer) error
 	Handle(context.Context, *Event) (*empty.Empty, error)
 	Build(context.Context, *empty.Empty) (*empty.Empty, error)
+	Pause(context.Context, *empty.Empty) (*empty.Empty, error)
+	Resume(context.Context, *empty.Empty) (*empty.Empty, error)
+	AutoBuild(context.Context, *TriggerRequest) (*empty.Empty, error)
+	AutoSync(context.Context, *TriggerRequest) (*empty.Empty, error)
+	AutoDeploy(context.Context, *TriggerRequest) (*empty.Empty, error)
+	Execute(context.Context, *UserIntentRequest) (*empty.Empty, error)
+	SetLogFilter(context.Context, *LogFilterRequest) (*empty.Empty, error)
 }
 
 // UnimplementedSkaffoldServiceServer can be embedded to have forward compatible implementations.
@@ -844,6 +1962,27 @@ func (*UnimplementedSkaffoldServiceServer) Handle(ctx context.Context, req *Even
 func (*UnimplementedSkaffoldServiceServer) Build(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Build not implemented")
 }
+func (*UnimplementedSkaffoldServiceServer) Pause(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (*UnimplementedSkaffoldServiceServer) Resume(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (*UnimplementedSkaffoldServiceServer) AutoBuild(ctx context.Context, req *TriggerRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AutoBuild not implemented")
+}
+func (*UnimplementedSkaffoldServiceServer) AutoSync(ctx context.Context, req *TriggerRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AutoSync not implemented")
+}
+func (*UnimplementedSkaffoldServiceServer) AutoDeploy(ctx context.Context, req *TriggerRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AutoDeploy not implemented")
+}
+func (*UnimplementedSkaffoldServiceServer) Execute(ctx context.Context, req *UserIntentRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
+}
+func (*UnimplementedSkaffoldServiceServer) SetLogFilter(ctx context.Context, req *LogFilterRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogFilter not implemented")
+}
 
 func RegisterSkaffoldServiceServer(s *grpc.Server, srv SkaffoldServiceServer) {
 	s.RegisterService(&_SkaffoldService_serviceDesc, srv)
@@ -929,6 +2068,132 @@ func _SkaffoldService_Build_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SkaffoldService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkaffoldServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SkaffoldService/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkaffoldServiceServer).Pause(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkaffoldService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkaffoldServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SkaffoldService/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkaffoldServiceServer).Resume(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkaffoldService_AutoBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkaffoldServiceServer).AutoBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SkaffoldService/AutoBuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkaffoldServiceServer).AutoBuild(ctx, req.(*TriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkaffoldService_AutoSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkaffoldServiceServer).AutoSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SkaffoldService/AutoSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkaffoldServiceServer).AutoSync(ctx, req.(*TriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkaffoldService_AutoDeploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkaffoldServiceServer).AutoDeploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SkaffoldService/AutoDeploy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkaffoldServiceServer).AutoDeploy(ctx, req.(*TriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkaffoldService_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkaffoldServiceServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SkaffoldService/Execute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkaffoldServiceServer).Execute(ctx, req.(*UserIntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkaffoldService_SetLogFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkaffoldServiceServer).SetLogFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.SkaffoldService/SetLogFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkaffoldServiceServer).SetLogFilter(ctx, req.(*LogFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SkaffoldService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.SkaffoldService",
 	HandlerType: (*SkaffoldServiceServer)(nil),
@@ -945,6 +2210,34 @@ var _SkaffoldService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Build",
 			Handler:    _SkaffoldService_Build_Handler,
 		},
+		{
+			MethodName: "Pause",
+			Handler:    _SkaffoldService_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _SkaffoldService_Resume_Handler,
+		},
+		{
+			MethodName: "AutoBuild",
+			Handler:    _SkaffoldService_AutoBuild_Handler,
+		},
+		{
+			MethodName: "AutoSync",
+			Handler:    _SkaffoldService_AutoSync_Handler,
+		},
+		{
+			MethodName: "AutoDeploy",
+			Handler:    _SkaffoldService_AutoDeploy_Handler,
+		},
+		{
+			MethodName: "Execute",
+			Handler:    _SkaffoldService_Execute_Handler,
+		},
+		{
+			MethodName: "SetLogFilter",
+			Handler:    _SkaffoldService_SetLogFilter_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
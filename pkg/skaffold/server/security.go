@@ -0,0 +1,132 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const bearerPrefix = "Bearer "
+
+// readOnlyMethods are the only RPCs allowed in read-only mode.
+var readOnlyMethods = map[string]bool{
+	"/proto.SkaffoldService/GetState": true,
+	"/proto.SkaffoldService/EventLog": true,
+}
+
+// listen listens on the loopback interface or, if a path is given, on a unix domain
+// socket that is only accessible to the current user.
+func listen(socket string, port int) (net.Listener, error) {
+	if socket == "" {
+		return net.Listen("tcp", fmt.Sprintf("%s:%d", util.Loopback, port))
+	}
+
+	// Replace the socket left by a previous run, but nothing else.
+	if info, err := os.Stat(socket); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s already exists and is not a socket", socket)
+		}
+		if err := os.Remove(socket); err != nil {
+			return nil, errors.Wrap(err, "removing existing socket")
+		}
+	}
+
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(socket, 0600); err != nil {
+		l.Close()
+		return nil, errors.Wrap(err, "setting socket permissions")
+	}
+	return l, nil
+}
+
+// validBearerToken checks the value of an Authorization header.
+func validBearerToken(header, token string) bool {
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, bearerPrefix)), []byte(token)) == 1
+}
+
+// checkRequest authenticates a gRPC request and checks that the method is allowed.
+func checkRequest(ctx context.Context, method string, token string, readOnly bool) error {
+	if token != "" {
+		authenticated := false
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, header := range md.Get("authorization") {
+			if validBearerToken(header, token) {
+				authenticated = true
+			}
+		}
+		if !authenticated {
+			return status.Error(codes.Unauthenticated, "invalid or missing bearer token")
+		}
+	}
+
+	if readOnly && !readOnlyMethods[method] {
+		return status.Errorf(codes.PermissionDenied, "%s is disabled in read-only mode", method)
+	}
+	return nil
+}
+
+func unaryInterceptor(token string, readOnly bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkRequest(ctx, info.FullMethod, token, readOnly); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamInterceptor(token string, readOnly bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkRequest(ss.Context(), info.FullMethod, token, readOnly); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// withBearerToken rejects the HTTP requests that don't carry the token.
+// Requests to the gateway are checked again by the gRPC server.
+func withBearerToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !validBearerToken(r.Header.Get("Authorization"), token) {
+			http.Error(w, "invalid or missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2019 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server/proto"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidBearerToken(t *testing.T) {
+	tests := []struct {
+		description string
+		header      string
+		expected    bool
+	}{
+		{"valid", "Bearer secret", true},
+		{"wrong token", "Bearer other", false},
+		{"prefix of the token", "Bearer sec", false},
+		{"no scheme", "secret", false},
+		{"basic auth", "Basic secret", false},
+		{"empty", "", false},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			t.CheckDeepEqual(test.expected, validBearerToken(test.header, "secret"))
+		})
+	}
+}
+
+func TestCheckRequest(t *testing.T) {
+	tests := []struct {
+		description  string
+		header       string
+		method       string
+		token        string
+		readOnly     bool
+		expectedCode codes.Code
+	}{
+		{
+			description:  "no token required",
+			method:       "/proto.SkaffoldService/Build",
+			expectedCode: codes.OK,
+		},
+		{
+			description:  "valid token",
+			header:       "Bearer secret",
+			method:       "/proto.SkaffoldService/Build",
+			token:        "secret",
+			expectedCode: codes.OK,
+		},
+		{
+			description:  "missing token",
+			method:       "/proto.SkaffoldService/GetState",
+			token:        "secret",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			description:  "read-only allows state",
+			method:       "/proto.SkaffoldService/GetState",
+			readOnly:     true,
+			expectedCode: codes.OK,
+		},
+		{
+			description:  "read-only allows event log",
+			method:       "/proto.SkaffoldService/EventLog",
+			readOnly:     true,
+			expectedCode: codes.OK,
+		},
+		{
+			description:  "read-only forbids handle",
+			method:       "/proto.SkaffoldService/Handle",
+			readOnly:     true,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			description:  "read-only forbids build",
+			method:       "/proto.SkaffoldService/Build",
+			readOnly:     true,
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			ctx := context.Background()
+			if test.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", test.header))
+			}
+
+			err := checkRequest(ctx, test.method, test.token, test.readOnly)
+
+			t.CheckDeepEqual(test.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestWithBearerToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		description  string
+		token        string
+		header       string
+		expectedCode int
+	}{
+		{"no token required", "", "", http.StatusOK},
+		{"valid token", "secret", "Bearer secret", http.StatusOK},
+		{"missing token", "secret", "", http.StatusUnauthorized},
+		{"wrong token", "secret", "Bearer other", http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			request := httptest.NewRequest("GET", "/v1/state", nil)
+			if test.header != "" {
+				request.Header.Set("Authorization", test.header)
+			}
+			recorder := httptest.NewRecorder()
+
+			withBearerToken(test.token, ok).ServeHTTP(recorder, request)
+
+			t.CheckDeepEqual(test.expectedCode, recorder.Code)
+		})
+	}
+}
+
+func TestListenSocket(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	socket := tmpDir.Path("skaffold.sock")
+	l, err := listen(socket, 0)
+	testutil.CheckError(t, false, err)
+
+	info, err := os.Stat(socket)
+	testutil.CheckErrorAndDeepEqual(t, false, err, os.FileMode(0600), info.Mode().Perm())
+
+	// A socket left by a previous run is replaced
+	l2, err := listen(socket, 0)
+	testutil.CheckError(t, false, err)
+	l2.Close()
+	l.Close()
+
+	// Other files are not
+	tmpDir.Write("file", "")
+	_, err = listen(tmpDir.Path("file"), 0)
+	testutil.CheckError(t, true, err)
+}
+
+func TestSecureGRPCServer(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	opts := &config.SkaffoldOptions{
+		RPCSocket:   tmpDir.Path("grpc.sock"),
+		RPCToken:    "secret",
+		RPCReadOnly: true,
+	}
+	shutdown, err := newGRPCServer(opts, 0, nil, nil)
+	testutil.CheckError(t, false, err)
+	defer shutdown()
+
+	conn, err := grpc.Dial(opts.RPCSocket, grpc.WithInsecure(), grpc.WithDialer(func(socket string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", socket, timeout)
+	}))
+	testutil.CheckError(t, false, err)
+	defer conn.Close()
+	client := proto.NewSkaffoldServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = client.Build(ctx, &empty.Empty{})
+	testutil.CheckDeepEqual(t, codes.Unauthenticated, status.Code(err))
+
+	authenticated := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	_, err = client.Build(authenticated, &empty.Empty{})
+	testutil.CheckDeepEqual(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestSecureHTTPServer(t *testing.T) {
+	tmpDir, cleanup := testutil.NewTempDir(t)
+	defer cleanup()
+
+	opts := &config.SkaffoldOptions{
+		RPCHTTPSocket: tmpDir.Path("http.sock"),
+		RPCToken:      "secret",
+	}
+	shutdown, err := newHTTPServer(opts, 0, 0)
+	testutil.CheckError(t, false, err)
+	defer shutdown()
+
+	client := http.Client{
+		Transport: &http.Transport{
+			Dial: func(string, string) (net.Conn, error) {
+				return net.Dial("unix", opts.RPCHTTPSocket)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://skaffold/metrics")
+	testutil.CheckError(t, false, err)
+	resp.Body.Close()
+	testutil.CheckDeepEqual(t, http.StatusUnauthorized, resp.StatusCode)
+
+	request, _ := http.NewRequest("GET", "http://skaffold/metrics", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	resp, err = client.Do(request)
+	testutil.CheckError(t, false, err)
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	testutil.CheckDeepEqual(t, http.StatusOK, resp.StatusCode)
+	testutil.CheckDeepEqual(t, true, len(body) > 0)
+}
@@ -18,12 +18,16 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 	"sync"
+	"time"
 
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/constants"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/metrics"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server/proto"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -31,24 +35,44 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var once sync.Once
 
 type server struct {
 	trigger chan bool
+	intents chan runcontext.Intent
 }
 
-func newGRPCServer(port int, trigger chan bool) (func() error, error) {
-	l, err := net.Listen("tcp", fmt.Sprintf("%s:%d", util.Loopback, port))
+func newGRPCServer(opts *config.SkaffoldOptions, port int, trigger chan bool, intents chan runcontext.Intent) (func() error, error) {
+	l, err := listen(opts.RPCSocket, port)
 	if err != nil {
 		return func() error { return nil }, errors.Wrap(err, "creating listener")
 	}
-	logrus.Infof("starting gRPC server on port %d", port)
+	if opts.RPCSocket != "" {
+		logrus.Infof("starting gRPC server on socket %s", opts.RPCSocket)
+	} else {
+		logrus.Infof("starting gRPC server on port %d", port)
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(unaryInterceptor(opts.RPCToken, opts.RPCReadOnly)),
+		grpc.StreamInterceptor(streamInterceptor(opts.RPCToken, opts.RPCReadOnly)),
+	}
+	if opts.RPCTLSCert != "" {
+		creds, err := credentials.NewServerTLSFromFile(opts.RPCTLSCert, opts.RPCTLSKey)
+		if err != nil {
+			l.Close()
+			return func() error { return nil }, errors.Wrap(err, "loading TLS certificate")
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(serverOpts...)
 	proto.RegisterSkaffoldServiceServer(s, &server{
 		trigger: trigger,
+		intents: intents,
 	})
 
 	go func() {
@@ -62,21 +86,46 @@ func newGRPCServer(port int, trigger chan bool) (func() error, error) {
 	}, nil
 }
 
-func newHTTPServer(port, proxyPort int) (func() error, error) {
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithInsecure()}
-	err := proto.RegisterSkaffoldServiceHandlerFromEndpoint(context.Background(), mux, fmt.Sprintf("%s:%d", util.Loopback, proxyPort), opts)
+func newHTTPServer(opts *config.SkaffoldOptions, port, proxyPort int) (func() error, error) {
+	gateway := runtime.NewServeMux()
+	endpoint := fmt.Sprintf("%s:%d", util.Loopback, proxyPort)
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if opts.RPCSocket != "" {
+		endpoint = opts.RPCSocket
+		dialOpts = append(dialOpts, grpc.WithDialer(func(socket string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", socket, timeout)
+		}))
+	}
+	if opts.RPCTLSCert != "" {
+		// The gateway connects to the gRPC server of the same process,
+		// whose certificate may not be valid for the loopback address.
+		dialOpts[0] = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}))
+	}
+	err := proto.RegisterSkaffoldServiceHandlerFromEndpoint(context.Background(), gateway, endpoint, dialOpts)
 	if err != nil {
 		return func() error { return nil }, err
 	}
 
-	l, err := net.Listen("tcp", fmt.Sprintf("%s:%d", util.Loopback, port))
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/", gateway)
+	handler := withBearerToken(opts.RPCToken, mux)
+
+	l, err := listen(opts.RPCHTTPSocket, port)
 	if err != nil {
 		return func() error { return nil }, errors.Wrap(err, "creating listener")
 	}
-	logrus.Infof("starting gRPC HTTP server on port %d", port)
+	if opts.RPCHTTPSocket != "" {
+		logrus.Infof("starting gRPC HTTP server on socket %s", opts.RPCHTTPSocket)
+	} else {
+		logrus.Infof("starting gRPC HTTP server on port %d", port)
+	}
 
-	go http.Serve(l, mux)
+	if opts.RPCTLSCert != "" {
+		go http.ServeTLS(l, handler, opts.RPCTLSCert, opts.RPCTLSKey)
+	} else {
+		go http.Serve(l, handler)
+	}
 
 	return l.Close, nil
 }
@@ -98,11 +147,15 @@ func initialize(runctx *runcontext.RunContext) (func() error, error) {
 	if originalRPCPort == -1 {
 		return func() error { return nil }, nil
 	}
+	if (runctx.Opts.RPCTLSCert == "") != (runctx.Opts.RPCTLSKey == "") {
+		return func() error { return nil }, errors.New("both a TLS certificate and a private key are required")
+	}
+
 	rpcPort := util.GetAvailablePort(originalRPCPort, &sync.Map{})
 	if rpcPort != originalRPCPort && originalRPCPort != constants.DefaultRPCPort {
 		logrus.Warnf("provided port %d already in use: using %d instead", originalRPCPort, rpcPort)
 	}
-	grpcCallback, err := newGRPCServer(rpcPort, runctx.Trigger)
+	grpcCallback, err := newGRPCServer(runctx.Opts, rpcPort, runctx.Trigger, runctx.Intents)
 	if err != nil {
 		return grpcCallback, errors.Wrap(err, "starting gRPC server")
 	}
@@ -115,7 +168,7 @@ func initialize(runctx *runcontext.RunContext) (func() error, error) {
 		logrus.Warnf("provided port %d already in use: using %d instead", originalHTTPPort, httpPort)
 	}
 
-	httpCallback, err := newHTTPServer(httpPort, rpcPort)
+	httpCallback, err := newHTTPServer(runctx.Opts, httpPort, rpcPort)
 	callback := func() error {
 		httpErr := httpCallback()
 		grpcErr := grpcCallback()
@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"testing"
@@ -24,7 +25,11 @@ import (
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/config"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/server/proto"
+	"github.com/GoogleContainerTools/skaffold/testutil"
+	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -65,3 +70,11 @@ func TestServerStartup(t *testing.T) {
 		httpConn.Close()
 	}
 }
+
+func TestSendIntentWithoutDevLoop(t *testing.T) {
+	s := &server{}
+
+	_, err := s.Pause(context.Background(), &empty.Empty{})
+
+	testutil.CheckDeepEqual(t, codes.Unavailable, status.Code(err))
+}
@@ -49,9 +49,10 @@ type Syncer interface {
 type syncMap map[string][]string
 
 type Item struct {
-	Image  string
-	Copy   map[string][]string
-	Delete map[string][]string
+	Image    string
+	Artifact string
+	Copy     map[string][]string
+	Delete   map[string][]string
 }
 
 func NewItem(a *latest.Artifact, e watch.Events, builds []build.Artifact, insecureRegistries map[string]bool) (*Item, error) {
@@ -86,9 +87,10 @@ func NewItem(a *latest.Artifact, e watch.Events, builds []build.Artifact, insecu
 	}
 
 	return &Item{
-		Image:  tag,
-		Copy:   toCopy,
-		Delete: toDelete,
+		Image:    tag,
+		Artifact: a.ImageName,
+		Copy:     toCopy,
+		Delete:   toDelete,
 	}, nil
 }
 
@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -64,7 +64,8 @@ func TestNewSyncItem(t *testing.T) {
 				Added: []string{"index.html"},
 			},
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					"index.html": {"index.html"},
 				},
@@ -116,7 +117,8 @@ func TestNewSyncItem(t *testing.T) {
 				Deleted:  []string{filepath.Join("node", "package.json")},
 			},
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					filepath.Join("node", "server.js"):  {"server.js"},
 					filepath.Join("node", "index.html"): {"index.html"},
@@ -148,7 +150,8 @@ func TestNewSyncItem(t *testing.T) {
 			},
 			workingDir: "/",
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					filepath.Join("node", "src/app/server/server.js"): {"/src/app/server/server.js"},
 				},
@@ -178,7 +181,8 @@ func TestNewSyncItem(t *testing.T) {
 				Deleted:  []string{filepath.Join("node", "package.json")},
 			},
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					filepath.Join("node", "server.js"):  {"server.js"},
 					filepath.Join("node", "index.html"): {"index.html"},
@@ -283,7 +287,8 @@ func TestNewSyncItem(t *testing.T) {
 				Added: []string{filepath.Join("dir1", "dir2", "node.js")},
 			},
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					filepath.Join("dir1", "dir2", "node.js"): {"/some/dir1/dir2/node.js"},
 				},
@@ -312,7 +317,8 @@ func TestNewSyncItem(t *testing.T) {
 				Added: []string{filepath.Join("dir1", "dir2/node.js")},
 			},
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					filepath.Join("dir1", "dir2/node.js"): {"/some/dir/dir2/node.js"},
 				},
@@ -342,7 +348,8 @@ func TestNewSyncItem(t *testing.T) {
 				Added: []string{filepath.Join("dir1", "dir2", "node.js")},
 			},
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					filepath.Join("dir1", "dir2", "node.js"): {"/some/dir2/node.js", "/some/dir1/dir2/node.js"},
 				},
@@ -375,7 +382,8 @@ func TestNewSyncItem(t *testing.T) {
 				},
 			},
 			expected: &Item{
-				Image: "test:123",
+				Image:    "test:123",
+				Artifact: "test",
 				Copy: map[string][]string{
 					filepath.Join("dir1a", "dir2", "dir3", "node.js"): {"/tstar/dir2/dir3/node.js"},
 					filepath.Join("dir1b", "dir1", "node.js"):         {"/dstar/dir1b/dir1/node.js"},
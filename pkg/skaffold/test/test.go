@@ -19,8 +19,10 @@ package test
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/test/structure"
@@ -72,15 +74,26 @@ func (t FullTester) runStructureTests(ctx context.Context, out io.Writer, bRes [
 		return nil
 	}
 
+	event.TestInProgress(testCase.ImageName)
+	start := time.Now()
+
 	files, err := util.ExpandPathsGlob(t.workingDir, testCase.StructureTests)
 	if err != nil {
-		return errors.Wrap(err, "expanding test file paths")
+		err = errors.Wrap(err, "expanding test file paths")
+		event.TestFailed(testCase.ImageName, err, time.Since(start))
+		return err
 	}
 
 	fqn := resolveArtifactImageTag(testCase.ImageName, bRes)
 
 	runner := structure.NewRunner(files)
-	return runner.Test(ctx, out, fqn)
+	if err := runner.Test(ctx, out, fqn); err != nil {
+		event.TestFailed(testCase.ImageName, err, time.Since(start))
+		return err
+	}
+
+	event.TestComplete(testCase.ImageName, time.Since(start))
+	return nil
 }
 
 func resolveArtifactImageTag(imageName string, bRes []build.Artifact) string {
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/build"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/event"
 	runcontext "github.com/GoogleContainerTools/skaffold/pkg/skaffold/runner/context"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
 	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/util"
@@ -73,6 +74,8 @@ func TestWrongPattern(t *testing.T) {
 		},
 	}
 
+	event.InitializeState(runCtx)
+
 	tester := NewTester(runCtx)
 
 	_, err := tester.TestDependencies()
@@ -122,6 +125,7 @@ func TestTestSuccess(t *testing.T) {
 			},
 		},
 	}
+	event.InitializeState(runCtx)
 
 	err := NewTester(runCtx).Test(context.Background(), ioutil.Discard, []build.Artifact{{
 		ImageName: "image",
@@ -153,6 +157,7 @@ func TestTestFailure(t *testing.T) {
 			},
 		},
 	}
+	event.InitializeState(runCtx)
 
 	err := NewTester(runCtx).Test(context.Background(), ioutil.Discard, []build.Artifact{{}})
 
@@ -84,7 +84,12 @@ func (t *pollTrigger) Start(ctx context.Context) (<-chan bool, error) {
 		for {
 			select {
 			case <-ticker.C:
-				trigger <- true
+				select {
+				case trigger <- true:
+				case <-ctx.Done():
+					ticker.Stop()
+					return
+				}
 			case <-ctx.Done():
 				ticker.Stop()
 				return
@@ -173,9 +178,15 @@ func (t *fsNotifyTrigger) Start(ctx context.Context) (<-chan bool, error) {
 				// This way, rapid stream of events will be grouped.
 				timer.Reset(t.Interval)
 			case <-timer.C:
-				trigger <- true
+				select {
+				case trigger <- true:
+				case <-ctx.Done():
+					notify.Stop(c)
+					return
+				}
 			case <-ctx.Done():
 				timer.Stop()
+				notify.Stop(c)
 				return
 			}
 		}
@@ -197,9 +208,13 @@ func (t *apiTrigger) Start(ctx context.Context) (<-chan bool, error) {
 			select {
 			case <-t.Trigger:
 				logrus.Debugln("build request received")
-				trigger <- true
+				select {
+				case trigger <- true:
+				case <-ctx.Done():
+					return
+				}
 			case <-ctx.Done():
-				break
+				return
 			}
 		}
 	}()
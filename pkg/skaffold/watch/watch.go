@@ -32,6 +32,7 @@ type Factory func() Watcher
 type Watcher interface {
 	Register(deps func() ([]string, error), onChange func(Events)) error
 	Run(ctx context.Context, out io.Writer, onChange func() error) error
+	Reset()
 }
 
 type watchList struct {
@@ -68,6 +69,11 @@ func (w *watchList) Register(deps func() ([]string, error), onChange func(Events
 	return nil
 }
 
+// Reset removes all the components from the watch list.
+func (w *watchList) Reset() {
+	w.components = nil
+}
+
 // Run watches files until the context is cancelled or an error occurs.
 func (w *watchList) Run(ctx context.Context, out io.Writer, onChange func() error) error {
 	ctxTrigger, cancelTrigger := context.WithCancel(ctx)
@@ -19,11 +19,20 @@ package yamltags
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// DurationPattern matches the durations accepted by the `duration` tag. It is also
+// used in the json schema, so that editors and Skaffold accept the same values.
+const DurationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+var durationRegexp = regexp.MustCompile(DurationPattern)
+
 type fieldSet map[string]struct{}
 
 // ValidateStruct validates and processes the provided pointer to a struct.
@@ -49,7 +58,7 @@ func ValidateStruct(s interface{}) error {
 func processTags(yamltags string, val reflect.Value, parentStruct reflect.Value, field reflect.StructField) error {
 	tags := strings.Split(yamltags, ",")
 	for _, tag := range tags {
-		tagParts := strings.Split(tag, "=")
+		tagParts := strings.SplitN(tag, "=", 2)
 		var yt yamlTag
 		switch tagParts[0] {
 		case "required":
@@ -61,6 +70,32 @@ func processTags(yamltags string, val reflect.Value, parentStruct reflect.Value,
 				Field:  field,
 				Parent: parentStruct,
 			}
+		case "enum":
+			yt = &enumTag{
+				Field: field,
+			}
+		case "enumIgnoreCase":
+			yt = &enumTag{
+				Field:      field,
+				ignoreCase: true,
+			}
+		case "pattern":
+			yt = &patternTag{
+				Field: field,
+			}
+		case "duration":
+			yt = &durationTag{
+				Field: field,
+			}
+		case "min", "max":
+			yt = &rangeTag{
+				Field: field,
+			}
+		case "requiredIf":
+			yt = &requiredIfTag{
+				Field:  field,
+				Parent: parentStruct,
+			}
 		default:
 			logrus.Panicf("unknown yaml tag in %s", yamltags)
 		}
@@ -89,14 +124,177 @@ func (rt *requiredTag) Load(s []string) error {
 
 func (rt *requiredTag) Process(val reflect.Value) error {
 	if isZeroValue(val) {
-		if tags, ok := rt.Field.Tag.Lookup("yaml"); ok {
-			return fmt.Errorf("required value not set: %s", strings.Split(tags, ",")[0])
+		return fmt.Errorf("required value not set: %s", fieldName(rt.Field))
+	}
+	return nil
+}
+
+// enumTag checks that a string, if set, is one of the values separated by `|`.
+// With `enumIgnoreCase`, the values are compared without regard to case.
+type enumTag struct {
+	Field      reflect.StructField
+	ignoreCase bool
+	values     []string
+}
+
+func (et *enumTag) Load(s []string) error {
+	if len(s) != 2 || s[1] == "" {
+		return fmt.Errorf("invalid enum struct tag: %v, expected %s=value1|value2", s, s[0])
+	}
+	et.values = strings.Split(s[1], "|")
+	return nil
+}
+
+func (et *enumTag) Process(val reflect.Value) error {
+	if isZeroValue(val) {
+		return nil
+	}
+	for _, value := range et.values {
+		if val.String() == value || (et.ignoreCase && strings.EqualFold(val.String(), value)) {
+			return nil
 		}
-		return fmt.Errorf("required value not set: %s", rt.Field.Name)
 	}
+	return fmt.Errorf("invalid value '%s' for %s: should be one of %s", val.String(), fieldName(et.Field), strings.Join(et.values, ", "))
+}
+
+// patternTag checks that a string, if set, matches a regular expression.
+// The expression can't contain commas, since they separate the tags.
+type patternTag struct {
+	Field   reflect.StructField
+	pattern *regexp.Regexp
+}
+
+func (pt *patternTag) Load(s []string) error {
+	if len(s) != 2 {
+		return fmt.Errorf("invalid pattern struct tag: %v, expected pattern=regex", s)
+	}
+	re, err := regexp.Compile(s[1])
+	if err != nil {
+		return fmt.Errorf("invalid pattern struct tag: %s", err)
+	}
+	pt.pattern = re
 	return nil
 }
 
+func (pt *patternTag) Process(val reflect.Value) error {
+	if isZeroValue(val) || pt.pattern.MatchString(val.String()) {
+		return nil
+	}
+	return fmt.Errorf("invalid value '%s' for %s: should match %s", val.String(), fieldName(pt.Field), pt.pattern)
+}
+
+// durationTag checks that a string, if set, is a duration such as `1m30s`.
+type durationTag struct {
+	Field reflect.StructField
+}
+
+func (dt *durationTag) Load(s []string) error {
+	return nil
+}
+
+func (dt *durationTag) Process(val reflect.Value) error {
+	if isZeroValue(val) {
+		return nil
+	}
+	if durationRegexp.MatchString(val.String()) {
+		if _, err := time.ParseDuration(val.String()); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value '%s' for %s: should be a duration, such as 1m30s", val.String(), fieldName(dt.Field))
+}
+
+// rangeTag checks that a number, if set, is above a minimum or below a maximum.
+type rangeTag struct {
+	Field reflect.StructField
+	max   bool
+	limit int64
+}
+
+func (rt *rangeTag) Load(s []string) error {
+	if len(s) != 2 {
+		return fmt.Errorf("invalid %s struct tag: %v, expected %s=number", s[0], s, s[0])
+	}
+	limit, err := strconv.ParseInt(s[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s struct tag: %s", s[0], err)
+	}
+	rt.max = s[0] == "max"
+	rt.limit = limit
+	return nil
+}
+
+func (rt *rangeTag) Process(val reflect.Value) error {
+	if isZeroValue(val) {
+		return nil
+	}
+	switch {
+	case rt.max && val.Int() > rt.limit:
+		return fmt.Errorf("invalid value %d for %s: should be at most %d", val.Int(), fieldName(rt.Field), rt.limit)
+	case !rt.max && val.Int() < rt.limit:
+		return fmt.Errorf("invalid value %d for %s: should be at least %d", val.Int(), fieldName(rt.Field), rt.limit)
+	}
+	return nil
+}
+
+// requiredIfTag makes a field required when another field of the struct, named by its yaml name,
+// is set. With `requiredIf=field:value1|value2`, the other field must have one of these values.
+type requiredIfTag struct {
+	Field  reflect.StructField
+	Parent reflect.Value
+	other  string
+	values []string
+}
+
+func (rt *requiredIfTag) Load(s []string) error {
+	if len(s) != 2 || s[1] == "" {
+		return fmt.Errorf("invalid requiredIf struct tag: %v, expected requiredIf=field", s)
+	}
+	parts := strings.SplitN(s[1], ":", 2)
+	rt.other = parts[0]
+	if len(parts) == 2 {
+		rt.values = strings.Split(parts[1], "|")
+	}
+	return nil
+}
+
+func (rt *requiredIfTag) Process(val reflect.Value) error {
+	if !isZeroValue(val) {
+		return nil
+	}
+
+	t := rt.Parent.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if fieldName(t.Field(i)) != rt.other {
+			continue
+		}
+		other := rt.Parent.Field(i)
+		if isZeroValue(other) {
+			return nil
+		}
+		if len(rt.values) == 0 {
+			return fmt.Errorf("required value not set: %s, when %s is set", fieldName(rt.Field), rt.other)
+		}
+		for _, value := range rt.values {
+			if fmt.Sprintf("%v", other.Interface()) == value {
+				return fmt.Errorf("required value not set: %s, when %s is %s", fieldName(rt.Field), rt.other, value)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid requiredIf struct tag: no field %s", rt.other)
+}
+
+// fieldName is the yaml name of a field.
+func fieldName(field reflect.StructField) string {
+	if tags, ok := field.Tag.Lookup("yaml"); ok {
+		if name := strings.Split(tags, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
 // A program can have many structs, that each have many oneOfSets.
 // Each oneOfSet is a map of a oneOf-set name to the set of fields that belong to that oneOf-set
 // Only one field in that set may have a non-zero value.
@@ -119,7 +317,7 @@ type oneOfTag struct {
 }
 
 func (oot *oneOfTag) Load(s []string) error {
-	if len(s) != 2 {
+	if len(s) != 2 || strings.Contains(s[1], "=") {
 		return fmt.Errorf("invalid default struct tag: %v, expected key=value", s)
 	}
 	oot.setName = s[1]
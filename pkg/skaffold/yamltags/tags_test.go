@@ -186,3 +186,126 @@ func TestIsZeroValue(t *testing.T) {
 	nonZeroMap := make(map[string]string)
 	testutil.CheckDeepEqual(t, false, isZeroValue(reflect.ValueOf(nonZeroMap)))
 }
+
+type constrained struct {
+	Variant  string `yaml:"variant" yamltags:"enum=Tags|CommitSha"`
+	Mode     string `yaml:"mode" yamltags:"enumIgnoreCase=Tags|CommitSha"`
+	Timeout  string `yaml:"timeout" yamltags:"duration"`
+	Secret   string `yaml:"secret" yamltags:"pattern=\\.json$"`
+	Port     int    `yaml:"port" yamltags:"min=1,max=65535"`
+	Op       string `yaml:"op"`
+	From     string `yaml:"from" yamltags:"requiredIf=op:move|copy"`
+	Cache    *otherstruct
+	HostPath string `yaml:"hostPath" yamltags:"requiredIf=Cache"`
+}
+
+func TestValidateStructConstraints(t *testing.T) {
+	tests := []struct {
+		description string
+		s           *constrained
+		expected    string
+	}{
+		{
+			description: "zero values",
+			s:           &constrained{},
+		},
+		{
+			description: "valid values",
+			s:           &constrained{Variant: "CommitSha", Mode: "commitSHA", Timeout: "1m30s", Secret: "key.json", Port: 8080, Op: "move", From: "/a", Cache: &otherstruct{A: 1}, HostPath: "/cache"},
+		},
+		{
+			description: "enum",
+			s:           &constrained{Variant: "commitsha"},
+			expected:    "invalid value 'commitsha' for variant: should be one of Tags, CommitSha",
+		},
+		{
+			description: "enum ignoring case",
+			s:           &constrained{Mode: "sha"},
+			expected:    "invalid value 'sha' for mode: should be one of Tags, CommitSha",
+		},
+		{
+			description: "duration",
+			s:           &constrained{Timeout: "20"},
+			expected:    "invalid value '20' for timeout: should be a duration, such as 1m30s",
+		},
+		{
+			description: "pattern",
+			s:           &constrained{Secret: "key.yaml"},
+			expected:    `invalid value 'key.yaml' for secret: should match \.json$`,
+		},
+		{
+			description: "max",
+			s:           &constrained{Port: 70000},
+			expected:    "invalid value 70000 for port: should be at most 65535",
+		},
+		{
+			description: "min",
+			s:           &constrained{Port: -1},
+			expected:    "invalid value -1 for port: should be at least 1",
+		},
+		{
+			description: "required if value",
+			s:           &constrained{Op: "copy"},
+			expected:    "required value not set: from, when op is copy",
+		},
+		{
+			description: "not required for other values",
+			s:           &constrained{Op: "add"},
+		},
+		{
+			description: "required if set",
+			s:           &constrained{Cache: &otherstruct{A: 1}},
+			expected:    "required value not set: hostPath, when Cache is set",
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			err := ValidateStruct(test.s)
+
+			if test.expected == "" {
+				t.CheckNoError(err)
+			} else {
+				t.CheckErrorContains(test.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateStructInvalidConstraints(t *testing.T) {
+	tests := []struct {
+		description string
+		s           interface{}
+	}{
+		{
+			description: "empty enum",
+			s: &struct {
+				A string `yamltags:"enum="`
+			}{A: "a"},
+		},
+		{
+			description: "invalid pattern",
+			s: &struct {
+				A string `yamltags:"pattern=("`
+			}{A: "a"},
+		},
+		{
+			description: "invalid max",
+			s: &struct {
+				A int `yamltags:"max=ten"`
+			}{A: 1},
+		},
+		{
+			description: "unknown requiredIf field",
+			s: &struct {
+				A string `yamltags:"requiredIf=other"`
+			}{},
+		},
+	}
+	for _, test := range tests {
+		testutil.Run(t, test.description, func(t *testutil.T) {
+			err := ValidateStruct(test.s)
+
+			t.CheckError(true, err)
+		})
+	}
+}